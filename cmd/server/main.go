@@ -12,15 +12,19 @@ import (
 	"github.com/rs/zerolog"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/constants"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http"
 	"gitlab.com/wordbyword.io/microservices/pkg/logger"
 
 	ActionApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/action_api"
 	AuthApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/auth_api"
+	EventConsumerClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/event_consumer"
+	EventPublisherClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/event_publisher"
 	GoogleAuthApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/google_auth_api"
 	LanguageApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/language_api"
 	NotificationApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/notification_api"
+	RedisClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/redis"
 	SpeakerApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/speaker_api"
 	TranslationApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/translation_api"
 	UserApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/user_api"
@@ -62,33 +66,44 @@ func runApp(cfg *config.Config, lgr zerolog.Logger, prom *prometheus.Exporter) {
 	//---------------------------
 	// 1) Clients Initialization
 	//---------------------------
-	notificationApiClient := NotificationApiClient.NewNotificationApiClient(cfg, lgr)
+	deps := dependency_status.NewRegistry(cfg)
+
+	notificationApiClient := NotificationApiClient.NewNotificationApiClient(cfg, lgr, prom, deps)
 	defer notificationApiClient.Shutdown()
 
-	authApiClient := AuthApiClient.NewAuthApiClient(cfg, lgr)
+	authApiClient := AuthApiClient.NewAuthApiClient(cfg, lgr, deps)
 	defer authApiClient.Shutdown()
 
-	userApiClient := UserApiClient.NewUserApiClient(cfg, lgr)
+	userApiClient := UserApiClient.NewUserApiClient(cfg, lgr, deps)
 	defer userApiClient.Shutdown()
 
-	actionApiClient := ActionApiClient.NewActionApiClient(cfg, lgr)
+	actionApiClient := ActionApiClient.NewActionApiClient(cfg, lgr, deps)
 	defer actionApiClient.Shutdown()
 
-	vocabularyApiClient := VocabularyApiClient.NewVocabularyApiClient(cfg, lgr)
+	vocabularyApiClient := VocabularyApiClient.NewVocabularyApiClient(cfg, lgr, deps)
 	defer vocabularyApiClient.Shutdown()
 
-	speakerApiClient := SpeakerApiClient.NewSpeakerApiClient(cfg, lgr)
+	speakerApiClient := SpeakerApiClient.NewSpeakerApiClient(cfg, lgr, deps)
 	defer speakerApiClient.Shutdown()
 
-	languageApiClient := LanguageApiClient.NewLanguageApiClient(cfg, lgr)
+	languageApiClient := LanguageApiClient.NewLanguageApiClient(cfg, lgr, deps)
 	defer languageApiClient.Shutdown()
 
-	translationApiClient := TranslationApiClient.NewTranslationApiClient(cfg, lgr)
+	translationApiClient := TranslationApiClient.NewTranslationApiClient(cfg, lgr, deps)
 	defer translationApiClient.Shutdown()
 
-	googleAuthApiClient := GoogleAuthApiClient.NewGoogleAuthApiClient(cfg, lgr)
+	googleAuthApiClient := GoogleAuthApiClient.NewGoogleAuthApiClient(cfg, lgr, deps)
 	defer googleAuthApiClient.Shutdown()
 
+	eventPublisherClient := EventPublisherClient.NewEventPublisherClient(cfg, lgr, prom, deps)
+	defer eventPublisherClient.Shutdown()
+
+	eventConsumerClient := EventConsumerClient.NewEventConsumerClient(cfg, lgr, prom)
+	defer eventConsumerClient.Shutdown()
+
+	redisClient := RedisClient.NewRedisClient(cfg, lgr, deps)
+	defer redisClient.Shutdown()
+
 	//---------------------------
 	// 2) Services Initialization
 	//---------------------------
@@ -102,6 +117,9 @@ func runApp(cfg *config.Config, lgr zerolog.Logger, prom *prometheus.Exporter) {
 		languageApiClient,
 		translationApiClient,
 		googleAuthApiClient,
+		eventPublisherClient,
+		eventConsumerClient,
+		redisClient.Client,
 	)
 	defer gatewayApiService.Shutdown()
 
@@ -121,7 +139,7 @@ func runApp(cfg *config.Config, lgr zerolog.Logger, prom *prometheus.Exporter) {
 		lgr.Fatal().Err(err).Msg("failed to init net.Listen for http")
 	}
 
-	httpServer, err := http.NewServer(cfg, lgr, prom, httpListener, httpEndpoints)
+	httpServer, err := http.NewServer(cfg, lgr, prom, deps, httpListener, httpEndpoints)
 	if err != nil {
 		lgr.Fatal().Err(err).Stack().Msg("failed to init http server")
 	}
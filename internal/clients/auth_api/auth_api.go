@@ -7,6 +7,7 @@ import (
 	"github.com/rs/zerolog"
 	AuthApiProto "gitlab.com/wbwapis/go-genproto/wbw/auth/auth_api/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
 	_grpc "gitlab.com/wordbyword.io/microservices/pkg/grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -22,8 +23,11 @@ type AuthApiClient struct {
 	pool *_grpc.Pool
 }
 
-func NewAuthApiClient(cfg *config.Config, lgr zerolog.Logger) *AuthApiClient {
+func NewAuthApiClient(cfg *config.Config, lgr zerolog.Logger, deps *dependency_status.Registry) *AuthApiClient {
 	lgr = lgr.With().Str("client", clientName).Logger()
+	dependency := deps.Register(clientName, cfg.AuthApi.URI, func(ctx context.Context) error {
+		return dependency_status.TCPDialCheck(ctx, cfg.AuthApi.URI, 200*time.Millisecond)
+	})
 
 	var credentialsOption grpc.DialOption
 	if cfg.AuthApi.WithTLS {
@@ -45,6 +49,7 @@ func NewAuthApiClient(cfg *config.Config, lgr zerolog.Logger) *AuthApiClient {
 			grpc.WithChainUnaryInterceptor(
 				_grpc.AddRequestIdToOutgoingContext,
 				_grpc.AddAcceptLanguageToOutgoingContext,
+				dependency.UnaryClientInterceptor(),
 			),
 		)
 		if err != nil {
@@ -64,6 +69,8 @@ func NewAuthApiClient(cfg *config.Config, lgr zerolog.Logger) *AuthApiClient {
 		lgr.Fatal().Err(err).Msg(clientName + " create connection pool failed")
 	}
 
+	dependency.WithPoolStats(dependency_status.GrpcPoolStats(pool))
+
 	return &AuthApiClient{
 		cfg:  cfg,
 		lgr:  lgr,
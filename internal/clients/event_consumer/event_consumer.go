@@ -0,0 +1,90 @@
+package event_consumer
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog"
+	"github.com/wagslane/go-rabbitmq"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+	"time"
+)
+
+const clientName = "rabbit_mq_events_consumer"
+
+type EventConsumerClient struct {
+	cfg        *config.Config
+	lgr        zerolog.Logger
+	prom       *prometheus.Exporter
+	connection *rabbitmq.Conn
+	consumer   *rabbitmq.Consumer
+}
+
+func NewEventConsumerClient(cfg *config.Config, lgr zerolog.Logger, prom *prometheus.Exporter) *EventConsumerClient {
+	return &EventConsumerClient{
+		cfg:  cfg,
+		lgr:  lgr.With().Str("client", clientName).Logger(),
+		prom: prom,
+	}
+}
+
+// Consume binds the queue of this instance to the events exchange. The queue is exclusive and deleted
+// with the connection, so every instance receives all the events and nothing is kept for the stopped ones.
+// The event failed by the handler is dropped. It does nothing while the events are disabled.
+func (c *EventConsumerClient) Consume(routingKeys []string, handler func(ctx context.Context, routingKey string, body []byte) error) error {
+	if !c.cfg.Rabbit.Events.Enabled {
+		return nil
+	}
+
+	var err error
+	c.connection, err = rabbitmq.NewConn(
+		c.cfg.Rabbit.URI,
+		rabbitmq.WithConnectionOptionsLogging,
+		rabbitmq.WithConnectionOptionsReconnectInterval(5*time.Second),
+	)
+	if err != nil {
+		return err
+	}
+
+	options := []func(*rabbitmq.ConsumerOptions){
+		rabbitmq.WithConsumerOptionsLogging,
+		rabbitmq.WithConsumerOptionsExchangeName(c.cfg.Rabbit.Events.Exchange),
+		rabbitmq.WithConsumerOptionsExchangeKind(amqp091.ExchangeTopic),
+		rabbitmq.WithConsumerOptionsExchangeDurable,
+		rabbitmq.WithConsumerOptionsExchangeDeclare,
+		rabbitmq.WithConsumerOptionsQueueExclusive,
+		rabbitmq.WithConsumerOptionsQueueAutoDelete,
+	}
+	for _, routingKey := range routingKeys {
+		options = append(options, rabbitmq.WithConsumerOptionsRoutingKey(routingKey))
+	}
+
+	queue := fmt.Sprintf("%s.%s", c.cfg.Rabbit.Events.Queue, uuid.NewString())
+	c.consumer, err = rabbitmq.NewConsumer(c.connection, func(d rabbitmq.Delivery) rabbitmq.Action {
+		if err := handler(context.Background(), d.RoutingKey, d.Body); err != nil {
+			c.lgr.Error().Err(err).Str("routing_key", d.RoutingKey).Str("message_id", d.MessageId).Msg("failed to handle the event")
+			c.prom.RabbitConsumeCount.WithLabelValues(c.cfg.Rabbit.Events.Exchange, "failed").Inc()
+			return rabbitmq.NackDiscard
+		}
+		c.prom.RabbitConsumeCount.WithLabelValues(c.cfg.Rabbit.Events.Exchange, "handled").Inc()
+		return rabbitmq.Ack
+	}, queue, options...)
+	if err != nil {
+		_ = c.connection.Close()
+		return err
+	}
+
+	c.lgr.Info().Str("queue", queue).Strs("routing_keys", routingKeys).Msg("consuming the events")
+	return nil
+}
+
+func (c *EventConsumerClient) Shutdown() {
+	if c.consumer != nil {
+		c.consumer.Close()
+	}
+	if c.connection != nil {
+		_ = c.connection.Close()
+	}
+}
@@ -0,0 +1,63 @@
+package event_publisher
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/constants"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/outbox"
+	pkgConstants "gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+// Publish stores the event for the background publishing.
+// It never returns an error, so an event can not fail the user request: the failures are logged and counted.
+func (c *EventPublisherClient) Publish(ctx context.Context, lgr zerolog.Logger, name string, event proto.Message) {
+	if !c.cfg.Rabbit.Events.Enabled {
+		return
+	}
+
+	requestId := utils.AnyToString(ctx.Value(pkgConstants.RequestIdKey))
+	lgr = lgr.With().
+		Str("api", "Publish").
+		Str("event", name).
+		Str(pkgConstants.RequestIdKey, requestId).
+		Logger()
+
+	body, err := proto.Marshal(event)
+	if err != nil {
+		c.prom.EventDropCount.WithLabelValues(name).Inc()
+		lgr.Error().Err(err).Msg("failed to marshal the event")
+		return
+	}
+
+	headers := map[string]string{
+		pkgConstants.RequestIdKey: requestId,
+		"event_version":           events.Version,
+	}
+	if traceParent, ok := ctx.Value(constants.TraceParentKey).(string); ok {
+		headers[constants.TraceParentKey] = traceParent
+	}
+	if traceState, ok := ctx.Value(constants.TraceStateKey).(string); ok {
+		headers[constants.TraceStateKey] = traceState
+	}
+
+	msg := &outbox.Message{
+		Exchange:    c.cfg.Rabbit.Events.Exchange,
+		RoutingKeys: []string{name},
+		ContentType: "application/x-protobuf",
+		Type:        string(proto.MessageName(event)),
+		Headers:     headers,
+		Body:        body,
+	}
+	if err = c.outbox.Put(msg); err != nil {
+		c.prom.EventDropCount.WithLabelValues(name).Inc()
+		lgr.Error().Err(err).Msg("failed to store the event in the outbox")
+		return
+	}
+	c.dispatcher.Notify()
+
+	lgr.Debug().Str("message_id", msg.Id).Msg("executed")
+}
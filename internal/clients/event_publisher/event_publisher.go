@@ -0,0 +1,158 @@
+package event_publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog"
+	"github.com/wagslane/go-rabbitmq"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/outbox"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+	"time"
+)
+
+const clientName = "rabbit_mq_events"
+
+var ErrEventNacked = errors.New("event is nacked by the broker")
+
+type EventPublisherClient struct {
+	cfg        *config.Config
+	lgr        zerolog.Logger
+	prom       *prometheus.Exporter
+	connection *rabbitmq.Conn
+	publisher  *rabbitmq.Publisher
+	dependency *dependency_status.Dependency
+	outbox     *outbox.Outbox
+	dispatcher *outbox.Dispatcher
+}
+
+// NewEventPublisherClient returns the disabled client while the events are disabled, its Publish does nothing
+func NewEventPublisherClient(cfg *config.Config, lgr zerolog.Logger, prom *prometheus.Exporter, deps *dependency_status.Registry) *EventPublisherClient {
+	lgr = lgr.With().Str("client", clientName).Logger()
+	c := &EventPublisherClient{
+		cfg:  cfg,
+		lgr:  lgr,
+		prom: prom,
+	}
+	if !cfg.Rabbit.Events.Enabled {
+		return c
+	}
+
+	c.dependency = deps.Register(clientName, cfg.Rabbit.URI, func(ctx context.Context) error {
+		connection, err := amqp091.ParseURI(cfg.Rabbit.URI)
+		if err != nil {
+			return err
+		}
+		return dependency_status.TCPDialCheck(ctx, fmt.Sprintf("%s:%d", connection.Host, connection.Port), 50*time.Millisecond)
+	})
+
+	var err error
+	c.connection, err = rabbitmq.NewConn(
+		cfg.Rabbit.URI,
+		rabbitmq.WithConnectionOptionsLogging,
+		rabbitmq.WithConnectionOptionsReconnectInterval(5*time.Second),
+	)
+	if err != nil {
+		lgr.Fatal().Err(err).Msg("AMQP connection error")
+	}
+
+	c.publisher, err = rabbitmq.NewPublisher(
+		c.connection,
+		rabbitmq.WithPublisherOptionsLogging,
+		rabbitmq.WithPublisherOptionsConfirm,
+		rabbitmq.WithPublisherOptionsExchangeName(cfg.Rabbit.Events.Exchange),
+		rabbitmq.WithPublisherOptionsExchangeKind(amqp091.ExchangeTopic),
+		rabbitmq.WithPublisherOptionsExchangeDurable,
+		rabbitmq.WithPublisherOptionsExchangeDeclare,
+	)
+	if err != nil {
+		lgr.Fatal().Err(err).Msg("AMQP connection error")
+	}
+
+	c.outbox, err = outbox.Open(cfg.Rabbit.Events.FilePath)
+	if err != nil {
+		lgr.Fatal().Err(err).Str("path", cfg.Rabbit.Events.FilePath).Msg("failed to open the outbox")
+	}
+
+	c.dispatcher = outbox.NewDispatcher(c.outbox, c.publish,
+		outbox.DispatcherConfig{
+			Interval:       cfg.Rabbit.Outbox.Interval,
+			BatchSize:      cfg.Rabbit.Outbox.BatchSize,
+			PublishTimeout: cfg.Rabbit.Outbox.PublishTimeout,
+			MinBackoff:     cfg.Rabbit.Outbox.MinBackoff,
+			MaxBackoff:     cfg.Rabbit.Outbox.MaxBackoff,
+			MaxAttempts:    cfg.Rabbit.Outbox.MaxAttempts,
+		},
+		lgr.With().Str("worker", "outbox").Logger(),
+		func(stats outbox.Stats) {
+			prom.OutboxDepth.WithLabelValues(clientName).Set(float64(stats.Depth))
+			prom.OutboxOldestAge.WithLabelValues(clientName).Set(stats.OldestAge().Seconds())
+			prom.OutboxDeadLetters.WithLabelValues(clientName).Set(float64(stats.Dead))
+		},
+	)
+	c.dispatcher.Start()
+
+	return c
+}
+
+// publish sends the event to the exchange and waits for the broker confirm.
+// The events are not mandatory, an event without subscribers is dropped by the broker.
+func (c *EventPublisherClient) publish(ctx context.Context, msg *outbox.Message) error {
+	err := c.publishConfirmed(ctx, msg)
+	c.dependency.Observe(err)
+	return err
+}
+
+func (c *EventPublisherClient) publishConfirmed(ctx context.Context, msg *outbox.Message) error {
+	headers := make(rabbitmq.Table, len(msg.Headers)+1)
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers["message_id"] = msg.Id
+
+	confirms, err := c.publisher.PublishWithDeferredConfirmWithContext(ctx, msg.Body, msg.RoutingKeys,
+		rabbitmq.WithPublishOptionsContentType(msg.ContentType),
+		rabbitmq.WithPublishOptionsType(msg.Type),
+		rabbitmq.WithPublishOptionsExchange(msg.Exchange),
+		rabbitmq.WithPublishOptionsHeaders(headers),
+		rabbitmq.WithPublishOptionsMessageID(msg.Id),
+		rabbitmq.WithPublishOptionsTimestamp(msg.CreatedAt),
+		rabbitmq.WithPublishOptionsPersistentDelivery,
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Rabbit.ConfirmTimeout)
+	defer cancel()
+
+	for _, confirm := range confirms {
+		if confirm == nil {
+			return errors.New("publisher is not in the confirm mode")
+		}
+		acked, err := confirm.WaitContext(ctx)
+		if err != nil {
+			return err
+		}
+		if !acked {
+			c.prom.RabbitPublishCount.WithLabelValues(msg.Exchange, "nacked").Inc()
+			return ErrEventNacked
+		}
+	}
+
+	c.prom.RabbitPublishCount.WithLabelValues(msg.Exchange, "confirmed").Inc()
+	return nil
+}
+
+func (c *EventPublisherClient) Shutdown() {
+	if !c.cfg.Rabbit.Events.Enabled {
+		return
+	}
+	c.dispatcher.Stop()
+	_ = c.outbox.Close()
+	_ = c.connection.Close()
+	c.publisher.Close()
+}
@@ -7,29 +7,49 @@ import (
 	"fmt"
 	"github.com/rs/zerolog"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
 const clientName = "google_auth_api"
 
 type GoogleAuthApiClient struct {
-	cfg    *config.Config
-	lgr    zerolog.Logger
-	Client http.Client
+	cfg        *config.Config
+	lgr        zerolog.Logger
+	Client     http.Client
+	dependency *dependency_status.Dependency
 }
 
-func NewGoogleAuthApiClient(cfg *config.Config, lgr zerolog.Logger) *GoogleAuthApiClient {
+func NewGoogleAuthApiClient(cfg *config.Config, lgr zerolog.Logger, deps *dependency_status.Registry) *GoogleAuthApiClient {
 	lgr = lgr.With().Str("client", clientName).Logger()
 	client := http.Client{
 		Timeout: time.Duration(cfg.GoogleApi.Timeout) * time.Second,
 	}
 
+	dependency := deps.Register(clientName, cfg.GoogleApi.URI, func(ctx context.Context) error {
+		u, err := url.Parse(cfg.GoogleApi.URI)
+		if err != nil {
+			return err
+		}
+		port := u.Port()
+		if port == "" {
+			port = "443"
+			if u.Scheme == "http" {
+				port = "80"
+			}
+		}
+		return dependency_status.TCPDialCheck(ctx, net.JoinHostPort(u.Hostname(), port), 200*time.Millisecond)
+	})
+
 	return &GoogleAuthApiClient{
-		cfg:    cfg,
-		lgr:    lgr,
-		Client: client,
+		cfg:        cfg,
+		lgr:        lgr,
+		Client:     client,
+		dependency: dependency,
 	}
 }
 
@@ -61,6 +81,7 @@ func (c *GoogleAuthApiClient) Call(ctx context.Context, method string, body inte
 	req.Header.Add("Accept", `application/json`)
 
 	resp, err := c.Client.Do(req)
+	c.dependency.Observe(err)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/rs/zerolog"
 	LanguageApiProto "gitlab.com/wbwapis/go-genproto/wbw/language/language_api/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
 	_grpc "gitlab.com/wordbyword.io/microservices/pkg/grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -22,8 +23,11 @@ type LanguageApiClient struct {
 	pool *_grpc.Pool
 }
 
-func NewLanguageApiClient(cfg *config.Config, lgr zerolog.Logger) *LanguageApiClient {
+func NewLanguageApiClient(cfg *config.Config, lgr zerolog.Logger, deps *dependency_status.Registry) *LanguageApiClient {
 	lgr = lgr.With().Str("client", clientName).Logger()
+	dependency := deps.Register(clientName, cfg.LanguageApi.URI, func(ctx context.Context) error {
+		return dependency_status.TCPDialCheck(ctx, cfg.LanguageApi.URI, 200*time.Millisecond)
+	})
 
 	var credentialsOption grpc.DialOption
 	if cfg.LanguageApi.WithTLS {
@@ -45,6 +49,7 @@ func NewLanguageApiClient(cfg *config.Config, lgr zerolog.Logger) *LanguageApiCl
 			grpc.WithChainUnaryInterceptor(
 				_grpc.AddRequestIdToOutgoingContext,
 				_grpc.AddAcceptLanguageToOutgoingContext,
+				dependency.UnaryClientInterceptor(),
 			),
 		)
 		if err != nil {
@@ -64,6 +69,8 @@ func NewLanguageApiClient(cfg *config.Config, lgr zerolog.Logger) *LanguageApiCl
 		lgr.Fatal().Err(err).Msg(clientName + " create connection pool failed")
 	}
 
+	dependency.WithPoolStats(dependency_status.GrpcPoolStats(pool))
+
 	return &LanguageApiClient{
 		cfg:  cfg,
 		lgr:  lgr,
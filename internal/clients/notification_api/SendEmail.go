@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
-	"github.com/wagslane/go-rabbitmq"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/outbox"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
 	"google.golang.org/protobuf/proto"
@@ -12,7 +12,8 @@ import (
 	NotificationApiProto "gitlab.com/wbwapis/go-genproto/wbw/notification/notification_api/v1"
 )
 
-// SendEmail send message for notification worker
+// SendEmail send message for notification worker.
+// With the outbox enabled the message is only stored locally, it is published in the background.
 func (c *NotificationApiClient) SendEmail(ctx context.Context, lgr zerolog.Logger, request *NotificationApiProto.SendEmailRequest) (err error) {
 	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
 	acceptLanguage := utils.AnyToString(ctx.Value(constants.AcceptLanguageKey))
@@ -25,19 +26,29 @@ func (c *NotificationApiClient) SendEmail(ctx context.Context, lgr zerolog.Logge
 		Logger()
 
 	requestBytes, _ := proto.Marshal(request)
-	err = c.publisher.Publish(
-		requestBytes,
-		[]string{c.cfg.Rabbit.NotificationApiSendEmail.Queue},
-		rabbitmq.WithPublishOptionsContentType("application/x-protobuf"),
-		rabbitmq.WithPublishOptionsExchange(c.cfg.Rabbit.NotificationApiSendEmail.Exchange),
-		rabbitmq.WithPublishOptionsHeaders(map[string]interface{}{
+	msg := &outbox.Message{
+		Exchange:    c.cfg.Rabbit.NotificationApiSendEmail.Exchange,
+		RoutingKeys: []string{c.cfg.Rabbit.NotificationApiSendEmail.Queue},
+		ContentType: "application/x-protobuf",
+		Headers: map[string]string{
 			constants.RequestIdKey:      requestId,
 			constants.AcceptLanguageKey: acceptLanguage,
-		}),
-		rabbitmq.WithPublishOptionsMandatory,
-		rabbitmq.WithPublishOptionsPersistentDelivery,
-	)
-	if err != nil {
+		},
+		Body: requestBytes,
+	}
+
+	if c.outbox != nil {
+		if err = c.outbox.Put(msg); err != nil {
+			lgr.Error().Err(err).Msg("failed to store the message in the outbox")
+			return err
+		}
+		c.dispatcher.Notify()
+
+		lgr.Debug().Str("message_id", msg.Id).Msg("executed")
+		return nil
+	}
+
+	if err = c.publish(ctx, msg); err != nil {
 		lgr.Error().Err(err).Msg("AMQP publish error")
 		return err
 	}
@@ -1,9 +1,17 @@
 package notification_api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rabbitmq/amqp091-go"
 	"github.com/rs/zerolog"
-	"github.com/wagslane/go-rabbitmq"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/outbox"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/rabbit_confirm"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
 	"time"
 )
 
@@ -13,41 +21,127 @@ const (
 	NotificationApiConsumer = "notification-api"
 )
 
+var ErrMessageNotConfirmed = errors.New("message is not confirmed in time")
+
 type NotificationApiClient struct {
 	cfg        *config.Config
 	lgr        zerolog.Logger
-	connection *rabbitmq.Conn
-	publisher  *rabbitmq.Publisher
+	publisher  *rabbit_confirm.Publisher // mandatory publishes, the returned messages fail
+	dependency *dependency_status.Dependency
+	prom       *prometheus.Exporter
+
+	outbox     *outbox.Outbox // nil while the outbox is disabled
+	dispatcher *outbox.Dispatcher
 }
 
-func NewNotificationApiClient(cfg *config.Config, lgr zerolog.Logger) *NotificationApiClient {
+func NewNotificationApiClient(cfg *config.Config, lgr zerolog.Logger, prom *prometheus.Exporter, deps *dependency_status.Registry) *NotificationApiClient {
 	lgr = lgr.With().Str("client", clientName).Logger()
-	connection, err := rabbitmq.NewConn(
-		cfg.Rabbit.URI,
-		rabbitmq.WithConnectionOptionsLogging,
-		rabbitmq.WithConnectionOptionsReconnectInterval(5*time.Second),
-	)
-	if err != nil {
-		lgr.Fatal().Err(err).Msg("AMQP connection error")
+	dependency := deps.Register(clientName, cfg.Rabbit.URI, func(ctx context.Context) error {
+		connection, err := amqp091.ParseURI(cfg.Rabbit.URI)
+		if err != nil {
+			return err
+		}
+		return dependency_status.TCPDialCheck(ctx, fmt.Sprintf("%s:%d", connection.Host, connection.Port), 50*time.Millisecond)
+	})
+
+	c := &NotificationApiClient{
+		cfg:        cfg,
+		lgr:        lgr,
+		publisher:  rabbit_confirm.NewPublisher(cfg.Rabbit.URI, 5*time.Second, lgr),
+		dependency: dependency,
+		prom:       prom,
 	}
 
-	publisher, err := rabbitmq.NewPublisher(
-		connection,
-		rabbitmq.WithPublisherOptionsLogging,
-	)
-	if err != nil {
-		lgr.Fatal().Err(err).Msg("AMQP connection error")
+	if cfg.Rabbit.Outbox.Enabled {
+		var err error
+		c.outbox, err = outbox.Open(cfg.Rabbit.Outbox.FilePath)
+		if err != nil {
+			lgr.Fatal().Err(err).Str("path", cfg.Rabbit.Outbox.FilePath).Msg("failed to open the outbox")
+		}
+
+		c.dispatcher = outbox.NewDispatcher(c.outbox, c.publish,
+			outbox.DispatcherConfig{
+				Interval:       cfg.Rabbit.Outbox.Interval,
+				BatchSize:      cfg.Rabbit.Outbox.BatchSize,
+				PublishTimeout: cfg.Rabbit.Outbox.PublishTimeout,
+				MinBackoff:     cfg.Rabbit.Outbox.MinBackoff,
+				MaxBackoff:     cfg.Rabbit.Outbox.MaxBackoff,
+				MaxAttempts:    cfg.Rabbit.Outbox.MaxAttempts,
+			},
+			lgr.With().Str("worker", "outbox").Logger(),
+			func(stats outbox.Stats) {
+				prom.OutboxDepth.WithLabelValues(clientName).Set(float64(stats.Depth))
+				prom.OutboxOldestAge.WithLabelValues(clientName).Set(stats.OldestAge().Seconds())
+				prom.OutboxDeadLetters.WithLabelValues(clientName).Set(float64(stats.Dead))
+			},
+		)
+		c.dispatcher.Start()
 	}
 
-	return &NotificationApiClient{
-		cfg:        cfg,
-		lgr:        lgr,
-		connection: connection,
-		publisher:  publisher,
+	return c
+}
+
+// publish sends the message to RabbitMQ and waits for the broker confirm.
+// The message id lets the consumers skip the redelivered messages.
+func (c *NotificationApiClient) publish(ctx context.Context, msg *outbox.Message) error {
+	err := c.publishConfirmed(ctx, msg)
+	c.dependency.Observe(err)
+	return err
+}
+
+func (c *NotificationApiClient) publishConfirmed(ctx context.Context, msg *outbox.Message) error {
+	messageId := msg.Id
+	if messageId == "" {
+		messageId = uuid.NewString()
+	}
+
+	headers := make(amqp091.Table, len(msg.Headers)+1)
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers["message_id"] = messageId
+
+	confirms := make([]*rabbit_confirm.Confirmation, 0, len(msg.RoutingKeys))
+	for _, routingKey := range msg.RoutingKeys {
+		confirm, err := c.publisher.Publish(ctx, msg.Exchange, routingKey, amqp091.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp091.Persistent,
+			MessageId:    messageId,
+			Body:         msg.Body,
+		})
+		if err != nil {
+			return err
+		}
+		confirms = append(confirms, confirm)
 	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Rabbit.ConfirmTimeout)
+	defer cancel()
+
+	for _, confirm := range confirms {
+		err := confirm.Wait(ctx)
+		var returned *rabbit_confirm.ReturnedError
+		switch {
+		case errors.As(err, &returned):
+			c.prom.RabbitPublishCount.WithLabelValues(msg.Exchange, "returned").Inc()
+			return err
+		case errors.Is(err, rabbit_confirm.ErrNacked):
+			c.prom.RabbitPublishCount.WithLabelValues(msg.Exchange, "nacked").Inc()
+			return err
+		case err != nil:
+			return errors.Join(ErrMessageNotConfirmed, err)
+		}
+	}
+
+	c.prom.RabbitPublishCount.WithLabelValues(msg.Exchange, "confirmed").Inc()
+	return nil
 }
 
 func (c *NotificationApiClient) Shutdown() {
-	_ = c.connection.Close()
+	if c.dispatcher != nil {
+		c.dispatcher.Stop()
+		_ = c.outbox.Close()
+	}
 	c.publisher.Close()
 }
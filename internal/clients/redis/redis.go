@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
+)
+
+const clientName = "redis"
+
+// RedisClient is the Redis shared by all the instances of the gateway, it keeps the state,
+// which must be seen by every instance (e.g. the collaborators of the collections)
+type RedisClient struct {
+	Client goredis.UniversalClient
+	lgr    zerolog.Logger
+}
+
+func NewRedisClient(cfg *config.Config, lgr zerolog.Logger, deps *dependency_status.Registry) *RedisClient {
+	lgr = lgr.With().Str("client", clientName).Logger()
+
+	options, err := goredis.ParseURL(cfg.Redis.URI)
+	if err != nil {
+		lgr.Fatal().Err(err).Msg("invalid Redis URI")
+	}
+	options.PoolSize = cfg.Redis.PoolSize
+	client := goredis.NewClient(options)
+
+	dependency := deps.Register(clientName, cfg.Redis.URI, func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+	dependency.WithPoolStats(func() (int, int) {
+		stats := client.PoolStats()
+		return int(stats.IdleConns), options.PoolSize
+	})
+	client.AddHook(&observeHook{dependency: dependency})
+
+	return &RedisClient{
+		Client: client,
+		lgr:    lgr,
+	}
+}
+
+func (c *RedisClient) Shutdown() {
+	if err := c.Client.Close(); err != nil {
+		c.lgr.Error().Err(err).Msg("failed to close the Redis client")
+	}
+}
+
+// observeHook records the outcome of every command on the status page
+type observeHook struct {
+	dependency *dependency_status.Dependency
+}
+
+func (h *observeHook) DialHook(next goredis.DialHook) goredis.DialHook {
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			h.dependency.Observe(err)
+		}
+		return conn, err
+	}
+}
+
+func (h *observeHook) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		err := next(ctx, cmd)
+		h.observe(err)
+		return err
+	}
+}
+
+func (h *observeHook) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		err := next(ctx, cmds)
+		h.observe(err)
+		return err
+	}
+}
+
+// observe the missing key and the aborted transaction are the results, not the failures of Redis
+func (h *observeHook) observe(err error) {
+	if errors.Is(err, goredis.Nil) || errors.Is(err, goredis.TxFailedErr) {
+		err = nil
+	}
+	h.dependency.Observe(err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/rs/zerolog"
 	SpeakerApiProto "gitlab.com/wbwapis/go-genproto/wbw/speaker/speaker_api/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
 	_grpc "gitlab.com/wordbyword.io/microservices/pkg/grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -22,8 +23,11 @@ type SpeakerApiClient struct {
 	pool *_grpc.Pool
 }
 
-func NewSpeakerApiClient(cfg *config.Config, lgr zerolog.Logger) *SpeakerApiClient {
+func NewSpeakerApiClient(cfg *config.Config, lgr zerolog.Logger, deps *dependency_status.Registry) *SpeakerApiClient {
 	lgr = lgr.With().Str("client", clientName).Logger()
+	dependency := deps.Register(clientName, cfg.SpeakerApi.URI, func(ctx context.Context) error {
+		return dependency_status.TCPDialCheck(ctx, cfg.SpeakerApi.URI, 200*time.Millisecond)
+	})
 
 	var credentialsOption grpc.DialOption
 	if cfg.SpeakerApi.WithTLS {
@@ -45,6 +49,7 @@ func NewSpeakerApiClient(cfg *config.Config, lgr zerolog.Logger) *SpeakerApiClie
 			grpc.WithChainUnaryInterceptor(
 				_grpc.AddRequestIdToOutgoingContext,
 				_grpc.AddAcceptLanguageToOutgoingContext,
+				dependency.UnaryClientInterceptor(),
 			),
 		)
 		if err != nil {
@@ -64,6 +69,8 @@ func NewSpeakerApiClient(cfg *config.Config, lgr zerolog.Logger) *SpeakerApiClie
 		lgr.Fatal().Err(err).Msg(clientName + " create connection pool failed")
 	}
 
+	dependency.WithPoolStats(dependency_status.GrpcPoolStats(pool))
+
 	return &SpeakerApiClient{
 		cfg:  cfg,
 		lgr:  lgr,
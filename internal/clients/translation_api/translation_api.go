@@ -7,6 +7,7 @@ import (
 	"github.com/rs/zerolog"
 	TranslationApiProto "gitlab.com/wbwapis/go-genproto/wbw/translation/translation_api/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
 	_grpc "gitlab.com/wordbyword.io/microservices/pkg/grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -22,8 +23,11 @@ type TranslationApiClient struct {
 	pool *_grpc.Pool
 }
 
-func NewTranslationApiClient(cfg *config.Config, lgr zerolog.Logger) *TranslationApiClient {
+func NewTranslationApiClient(cfg *config.Config, lgr zerolog.Logger, deps *dependency_status.Registry) *TranslationApiClient {
 	lgr = lgr.With().Str("client", clientName).Logger()
+	dependency := deps.Register(clientName, cfg.TranslationApi.URI, func(ctx context.Context) error {
+		return dependency_status.TCPDialCheck(ctx, cfg.TranslationApi.URI, 200*time.Millisecond)
+	})
 
 	var credentialsOption grpc.DialOption
 	if cfg.TranslationApi.WithTLS {
@@ -45,6 +49,7 @@ func NewTranslationApiClient(cfg *config.Config, lgr zerolog.Logger) *Translatio
 			grpc.WithChainUnaryInterceptor(
 				_grpc.AddRequestIdToOutgoingContext,
 				_grpc.AddAcceptLanguageToOutgoingContext,
+				dependency.UnaryClientInterceptor(),
 			),
 		)
 		if err != nil {
@@ -64,6 +69,8 @@ func NewTranslationApiClient(cfg *config.Config, lgr zerolog.Logger) *Translatio
 		lgr.Fatal().Err(err).Msg(clientName + " create connection pool failed")
 	}
 
+	dependency.WithPoolStats(dependency_status.GrpcPoolStats(pool))
+
 	return &TranslationApiClient{
 		cfg:  cfg,
 		lgr:  lgr,
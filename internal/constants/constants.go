@@ -4,27 +4,69 @@ const (
 	AppName = "gateway-api"
 )
 
+// W3C trace context headers
 const (
-	SignUp           = "/v1/SignUp"
-	SignIn           = "/v1/SignIn"
-	RefreshTokens    = "/v1/RefreshTokens"
-	ConfirmEmail     = "/v1/ConfirmEmail"
-	AskResetPassword = "/v1/AskResetPassword"
-	ResetPassword    = "/v1/ResetPassword"
-	GetLanguages     = "/v1/GetLanguages"
-	Logout           = "/v1/Logout"
-	GetUser          = "/v1/GetUser"
-	UpdateUser       = "/v1/UpdateUser"
-	CreateCollection = "/v1/CreateCollection"
-	UpdateCollection = "/v1/UpdateCollection"
-	GetCollections   = "/v1/GetCollections"
-	GetCollection    = "/v1/GetCollection"
-	DeleteCollection = "/v1/DeleteCollection"
-	CreateTerms      = "/v1/CreateTerms"
-	UpdateTerm       = "/v1/UpdateTerm"
-	GetTerms         = "/v1/GetTerms"
-	ChangeTermStatus = "/v1/ChangeTermStatus"
-	DeleteTerms      = "/v1/DeleteTerms"
-	GetVoiceover     = "/v1/GetVoiceover"
-	GetTranslation   = "/v1/GetTranslation"
+	TraceParentKey = "traceparent"
+	TraceStateKey  = "tracestate"
+)
+
+const (
+	SignUp               = "/v1/SignUp"
+	SignIn               = "/v1/SignIn"
+	RefreshTokens        = "/v1/RefreshTokens"
+	ConfirmEmail         = "/v1/ConfirmEmail"
+	AskResetPassword     = "/v1/AskResetPassword"
+	ResetPassword        = "/v1/ResetPassword"
+	GetLanguages         = "/v1/GetLanguages"
+	Logout               = "/v1/Logout"
+	GetUser              = "/v1/GetUser"
+	UpdateUser           = "/v1/UpdateUser"
+	CreateCollection     = "/v1/CreateCollection"
+	UpdateCollection     = "/v1/UpdateCollection"
+	GetCollections       = "/v1/GetCollections"
+	GetCollection        = "/v1/GetCollection"
+	DeleteCollection     = "/v1/DeleteCollection"
+	CreateTerms          = "/v1/CreateTerms"
+	UpdateTerm           = "/v1/UpdateTerm"
+	GetTerms             = "/v1/GetTerms"
+	ChangeTermStatus     = "/v1/ChangeTermStatus"
+	DeleteTerms          = "/v1/DeleteTerms"
+	GetVoiceover         = "/v1/GetVoiceover"
+	GetTranslation       = "/v1/GetTranslation"
+	GetDueTerms          = "/v1/GetDueTerms"
+	SubmitReview         = "/v1/SubmitReview"
+	GenerateQuiz         = "/v1/GenerateQuiz"
+	ImportTerms          = "/v1/ImportTerms"
+	ExportCollection     = "/v1/ExportCollection"
+	CloneCollection      = "/v1/CloneCollection"
+	GetCollectionSource  = "/v1/GetCollectionSource"
+	SearchCollections    = "/v1/SearchCollections"
+	InviteCollaborator   = "/v1/InviteCollaborator"
+	RevokeInvite         = "/v1/RevokeInvite"
+	RemoveCollaborator   = "/v1/RemoveCollaborator"
+	AcceptInvite         = "/v1/AcceptInvite"
+	GetCollaborators     = "/v1/GetCollaborators"
+	GetInvites           = "/v1/GetInvites"
+	CreateShareLink      = "/v1/CreateShareLink"
+	RevokeShareLink      = "/v1/RevokeShareLink"
+	GetSharedCollection  = "/v1/shared/GetCollection"
+	GetSharedTerms       = "/v1/shared/GetTerms"
+	MoveTerms            = "/v1/MoveTerms"
+	CopyTerms            = "/v1/CopyTerms"
+	FindDuplicates       = "/v1/FindDuplicates"
+	GetLearningStats     = "/v1/GetLearningStats"
+	ChangeTermStatuses   = "/v1/ChangeTermStatuses"
+	ListTrash            = "/v1/ListTrash"
+	RestoreCollection    = "/v1/RestoreCollection"
+	RestoreTerms         = "/v1/RestoreTerms"
+	EmptyTrash           = "/v1/EmptyTrash"
+	ExtractTerms         = "/v1/ExtractTerms"
+	ExtractSubtitleTerms = "/v1/ExtractSubtitleTerms"
+	AcceptSubtitleTerms  = "/v1/AcceptSubtitleTerms"
+)
+
+// Admin routes
+const (
+	InvalidateCache = "/v1/admin/InvalidateCache"
+	BackfillCatalog = "/v1/admin/BackfillCatalog"
 )
@@ -0,0 +1,26 @@
+package entities
+
+import "errors"
+
+type InvalidateCacheRequest struct {
+	Caches []string `json:"caches"` // all caches are invalidated when empty
+	Keys   []string `json:"keys"`   // only the keys are removed from the caches when set, e.g. collection ids
+}
+
+func (r *InvalidateCacheRequest) Validate() error {
+	for _, cache := range r.Caches {
+		if cache == "" {
+			return errors.New("cache name is empty")
+		}
+	}
+	for _, key := range r.Keys {
+		if key == "" {
+			return errors.New("cache key is empty")
+		}
+	}
+	return nil
+}
+
+type InvalidateCacheResponse struct {
+	Invalidated []string `json:"invalidated"`
+}
@@ -0,0 +1,15 @@
+package entities
+
+// AutoFilledTerm reports the enrichment of one term of CreateTerms in the order of the request,
+// the term is created even when the enrichment failed
+type AutoFilledTerm struct {
+	Term          string   `json:"term"`
+	Meaning       string   `json:"meaning"`
+	MeaningFilled bool     `json:"meaning_filled"` // the meaning was empty and is filled with the translation
+	VoiceoverUrl  string   `json:"voiceover_url"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+type CreateTermsAutoFillResponse struct {
+	Terms []*AutoFilledTerm `json:"terms"`
+}
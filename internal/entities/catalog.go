@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/catalog"
+)
+
+type SearchCollectionsRequest struct {
+	Query      string `json:"query"`              // the words of the name or the description, any collection when empty
+	LanguageId uint64 `json:"language_id,string"` // any language when 0
+	MinTerms   uint64 `json:"min_terms"`
+	MaxTerms   uint64 `json:"max_terms"` // no limit when 0
+	Sort       string `json:"sort"`      // popular or recent, popular when empty
+	Cursor     string `json:"cursor"`    // next_cursor of the previous page
+	Limit      int    `json:"limit"`     // the configured page size when 0
+}
+
+func (r *SearchCollectionsRequest) Validate() error {
+	if r.Sort != "" && !catalog.IsSort(r.Sort) {
+		return fmt.Errorf("unknown sort %q", r.Sort)
+	}
+	if r.MaxTerms != 0 && r.MaxTerms < r.MinTerms {
+		return errors.New("max_terms is less than min_terms")
+	}
+	if r.Limit < 0 {
+		return errors.New("limit is negative")
+	}
+	return nil
+}
+
+type CatalogCollection struct {
+	CollectionId uint64    `json:"collection_id,string"`
+	UserId       uint64    `json:"user_id,string"`
+	LanguageId   uint64    `json:"language_id,string"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	TotalTerms   uint64    `json:"total_terms"`
+	Clones       uint32    `json:"clones"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type SearchCollectionsResponse struct {
+	Collections []*CatalogCollection `json:"collections"`
+	NextCursor  string               `json:"next_cursor"` // empty on the last page
+}
+
+type BackfillCatalogRequest struct {
+	UserIds []uint64 `json:"user_ids"` // the owners to load, e.g. exported from user_api
+}
+
+func (r *BackfillCatalogRequest) Validate() error {
+	if len(r.UserIds) == 0 {
+		return errors.New("user_ids is empty")
+	}
+	for _, userId := range r.UserIds {
+		if userId == 0 {
+			return errors.New("user_id is empty")
+		}
+	}
+	return nil
+}
+
+type BackfillCatalogResponse struct {
+	Owners       int      `json:"owners"`
+	Collections  int      `json:"collections"`   // the public collections found
+	FailedOwners []uint64 `json:"failed_owners"` // to be sent again
+}
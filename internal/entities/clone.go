@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+type CloneCollectionRequest struct {
+	CollectionId uint64 `json:"collection_id,string"` // the public collection or the collection of the user
+}
+
+func (r *CloneCollectionRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	return nil
+}
+
+type CloneCollectionResponse struct {
+	CollectionId       uint64 `json:"collection_id,string"`
+	SourceCollectionId uint64 `json:"source_collection_id,string"`
+	TermsCount         int    `json:"terms_count"`
+}
+
+type GetCollectionSourceRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+}
+
+func (r *GetCollectionSourceRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	return nil
+}
+
+type GetCollectionSourceResponse struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	// Source is null when the collection is not a clone
+	Source *CollectionSource `json:"source"`
+}
+
+// CollectionSource the collection was cloned from, it may be deleted or private since then
+type CollectionSource struct {
+	CollectionId uint64    `json:"collection_id,string"`
+	UserId       uint64    `json:"user_id,string"`
+	ClonedAt     time.Time `json:"cloned_at"`
+}
@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+)
+
+type InviteCollaboratorRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	Email        string `json:"email"` // the invited user is found by the email or by the username
+	Username     string `json:"username"`
+	Role         string `json:"role"` // viewer or editor
+}
+
+func (r *InviteCollaboratorRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if (r.Email == "") == (r.Username == "") {
+		return errors.New("exactly one of email and username must be set")
+	}
+	if !collaborators.IsRole(r.Role) {
+		return fmt.Errorf("unknown role %q", r.Role)
+	}
+	return nil
+}
+
+type InviteCollaboratorResponse struct {
+	InviteId  string    `json:"invite_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type AcceptInviteRequest struct {
+	InviteId string `json:"invite_id"`
+}
+
+func (r *AcceptInviteRequest) Validate() error {
+	if r.InviteId == "" {
+		return errors.New("invite_id is empty")
+	}
+	return nil
+}
+
+type AcceptInviteResponse struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	Role         string `json:"role"`
+}
+
+type RevokeInviteRequest struct {
+	InviteId string `json:"invite_id"`
+}
+
+func (r *RevokeInviteRequest) Validate() error {
+	if r.InviteId == "" {
+		return errors.New("invite_id is empty")
+	}
+	return nil
+}
+
+type RevokeInviteResponse struct{}
+
+type GetCollaboratorsRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+}
+
+func (r *GetCollaboratorsRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	return nil
+}
+
+type Collaborator struct {
+	UserId    uint64    `json:"user_id,string"`
+	Role      string    `json:"role"`
+	InvitedBy uint64    `json:"invited_by,string"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CollaboratorInvite struct {
+	InviteId  string    `json:"invite_id"`
+	UserId    uint64    `json:"user_id,string"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type GetCollaboratorsResponse struct {
+	OwnerId       uint64                `json:"owner_id,string"`
+	Collaborators []*Collaborator       `json:"collaborators"`
+	Invites       []*CollaboratorInvite `json:"invites"` // the pending invites are shown to the owner only
+}
+
+type GetInvitesRequest struct{}
+
+func (r *GetInvitesRequest) Validate() error {
+	return nil
+}
+
+// ReceivedInvite is the pending invite of the caller to the collection of another user
+type ReceivedInvite struct {
+	InviteId     string    `json:"invite_id"`
+	CollectionId uint64    `json:"collection_id,string"`
+	Role         string    `json:"role"`
+	InvitedBy    uint64    `json:"invited_by,string"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type GetInvitesResponse struct {
+	Invites []*ReceivedInvite `json:"invites"` // the expired invites are not listed
+}
+
+type RemoveCollaboratorRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	UserId       uint64 `json:"user_id,string"` // the owner removes anyone, the collaborator removes only itself
+}
+
+func (r *RemoveCollaboratorRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if r.UserId == 0 {
+		return errors.New("user_id is empty")
+	}
+	return nil
+}
+
+type RemoveCollaboratorResponse struct{}
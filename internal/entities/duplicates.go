@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_match"
+)
+
+// What CreateTerms does with the term, which duplicates the existing one
+const (
+	DuplicatePolicyAllow = "allow" // the term is created, the duplicates are reported
+	DuplicatePolicySkip  = "skip"  // the term is not created
+	DuplicatePolicyMerge = "merge" // the meaning, the example and the image are added to the duplicate in the same collection
+)
+
+// Results of the terms of CreateTerms with the duplicate detection
+const (
+	DuplicateTermCreated = "created"
+	DuplicateTermSkipped = "skipped"
+	DuplicateTermMerged  = "merged"
+	DuplicateTermFailed  = "failed"
+)
+
+// DuplicateOptions how the terms are compared, NFKC, case folding and whitespace are always applied
+type DuplicateOptions struct {
+	IgnoreDiacritics bool `json:"ignore_diacritics"`
+	Stemming         bool `json:"stemming"` // only the languages with the Snowball stemmer are stemmed
+}
+
+func (o DuplicateOptions) MatchOptions() term_match.Options {
+	return term_match.Options{
+		IgnoreDiacritics: o.IgnoreDiacritics,
+		Stemming:         o.Stemming,
+	}
+}
+
+// CreateTermsOptions the query parameters of CreateTerms
+type CreateTermsOptions struct {
+	OnDuplicate string // allow, skip or merge
+	AutoFill    bool
+	DuplicateOptions
+}
+
+func (o *CreateTermsOptions) Validate() error {
+	switch o.OnDuplicate {
+	case DuplicatePolicyAllow, DuplicatePolicySkip, DuplicatePolicyMerge:
+		return nil
+	default:
+		return fmt.Errorf("unknown on_duplicate %q", o.OnDuplicate)
+	}
+}
+
+type DuplicateTerm struct {
+	TermId       uint64 `json:"term_id,string"`
+	CollectionId uint64 `json:"collection_id,string"`
+	Term         string `json:"term"`
+	Meaning      string `json:"meaning"`
+}
+
+// DeduplicatedTerm reports one term of CreateTerms in the order of the request
+type DeduplicatedTerm struct {
+	Term       string           `json:"term"`
+	Result     string           `json:"result"`
+	Duplicates []*DuplicateTerm `json:"duplicates"`                   // the existing terms, the terms of the request are not included
+	MergedInto uint64           `json:"merged_into,string,omitempty"` // the existing term, 0 when merged into the previous term of the request
+	AutoFill   *AutoFilledTerm  `json:"auto_fill,omitempty"`          // with auto_fill only, for the created terms
+	Error      string           `json:"error,omitempty"`
+}
+
+type CreateTermsDeduplicatedResponse struct {
+	Terms   []*DeduplicatedTerm `json:"terms"`
+	Created int                 `json:"created"`
+	Skipped int                 `json:"skipped"`
+	Merged  int                 `json:"merged"`
+	Failed  int                 `json:"failed"`
+}
+
+type FindDuplicatesRequest struct {
+	CollectionIds []uint64 `json:"collection_ids"` // all the collections of the user when empty
+	DuplicateOptions
+}
+
+func (r *FindDuplicatesRequest) Validate() error {
+	for _, collectionId := range r.CollectionIds {
+		if collectionId == 0 {
+			return errors.New("collection_id is empty")
+		}
+	}
+	return nil
+}
+
+// DuplicateGroup the terms of the same language with the same normalized form
+type DuplicateGroup struct {
+	Key            string           `json:"key"` // the normalized form of the terms
+	TermLanguageId uint64           `json:"term_language_id,string"`
+	Terms          []*DuplicateTerm `json:"terms"`
+}
+
+type FindDuplicatesResponse struct {
+	Groups []*DuplicateGroup `json:"groups"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collection_export"
+)
+
+type ExportCollectionRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	Format       string `json:"format"` // csv, json or apkg
+}
+
+func (r *ExportCollectionRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if !collection_export.IsFormat(r.Format) {
+		return fmt.Errorf("unknown format %q", r.Format)
+	}
+	return nil
+}
+
+// ExportCollectionResponse is not marshalled: the file is streamed to the client by Write
+type ExportCollectionResponse struct {
+	FileName    string
+	ContentType string
+	Write       func(w io.Writer) error
+}
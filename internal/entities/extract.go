@@ -0,0 +1,45 @@
+package entities
+
+import "errors"
+
+type ExtractTermsRequest struct {
+	Text              string `json:"text"`
+	LanguageId        uint64 `json:"language_id,string"`         // the language of the text, the language of the collection when empty
+	CollectionId      uint64 `json:"collection_id,string"`       // the collection to create the terms in, its terms are known
+	MeaningLanguageId uint64 `json:"meaning_language_id,string"` // the interface language of the user when empty
+	MaxTerms          int    `json:"max_terms"`                  // the configured default when 0
+	Create            bool   `json:"create"`                     // create the candidates with the translations, except the guessed ones
+}
+
+func (r *ExtractTermsRequest) Validate() error {
+	if r.Text == "" {
+		return errors.New("text is empty")
+	}
+	if r.LanguageId == 0 && r.CollectionId == 0 {
+		return errors.New("language_id is empty")
+	}
+	if r.Create && r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if r.MaxTerms < 0 {
+		return errors.New("max_terms is negative")
+	}
+	return nil
+}
+
+// ExtractedTerm is the candidate word, which is not in the collections of the user
+type ExtractedTerm struct {
+	Term     string          `json:"term"`
+	Count    int             `json:"count"`
+	Example  string          `json:"example"`             // the first sentence with the word
+	Guessed  bool            `json:"guessed"`             // the word boundaries are guessed (Chinese), it may be no word and is never created
+	AutoFill *AutoFilledTerm `json:"auto_fill,omitempty"` // when the terms are created
+}
+
+type ExtractTermsResponse struct {
+	Terms      []*ExtractedTerm `json:"terms"`       // the most frequent first
+	TotalWords int              `json:"total_words"` // the occurrences of all the words
+	StopWords  int              `json:"stop_words"`  // the occurrences of the function words, they are dropped
+	KnownWords int              `json:"known_words"` // the distinct words already in the collections, they are dropped
+	Created    int              `json:"created"`
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_import"
+)
+
+// Statuses of the imported rows
+const (
+	ImportRowCreated = "created"
+	ImportRowInvalid = "invalid" // the row is not sent to vocabulary_api
+	ImportRowFailed  = "failed"  // vocabulary_api did not create the chunk of the row
+)
+
+// ImportTermsRequest is the multipart form, the file is in the "file" part
+type ImportTermsRequest struct {
+	CollectionId      uint64 `json:"collection_id,string"`
+	TermLanguageId    uint64 `json:"term_language_id,string"`
+	MeaningLanguageId uint64 `json:"meaning_language_id,string"`
+	Format            string `json:"format"`   // auto, csv, tsv or quizlet; auto when empty
+	Encoding          string `json:"encoding"` // the encoding of the file without BOM, when it is not UTF-8
+	FileName          string `json:"file_name"`
+	File              []byte `json:"-"`
+}
+
+func (r *ImportTermsRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if r.TermLanguageId == 0 {
+		return errors.New("term_language_id is empty")
+	}
+	if r.MeaningLanguageId == 0 {
+		return errors.New("meaning_language_id is empty")
+	}
+	if r.Format != "" && !term_import.IsFormat(r.Format) {
+		return fmt.Errorf("unknown format %q", r.Format)
+	}
+	if r.Encoding != "" && !term_import.IsFallbackEncoding(r.Encoding) {
+		return fmt.Errorf("unknown encoding %q", r.Encoding)
+	}
+	if len(r.File) == 0 {
+		return errors.New("file is empty")
+	}
+	return nil
+}
+
+type ImportRowReport struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportTermsResponse struct {
+	Format   string             `json:"format"`
+	Encoding string             `json:"encoding"`
+	Total    int                `json:"total"`
+	Created  int                `json:"created"`
+	Invalid  int                `json:"invalid"`
+	Failed   int                `json:"failed"`
+	Rows     []*ImportRowReport `json:"rows"`
+}
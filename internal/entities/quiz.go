@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/quiz"
+)
+
+const MaxQuizSize = 100
+
+type GenerateQuizRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	Mode         string `json:"mode"` // multiple_choice, reverse_multiple_choice, typing or true_false
+	Size         int    `json:"size"`
+	Seed         int64  `json:"seed,string"` // the same seed gives the same quiz, a random one is used when 0
+}
+
+func (r *GenerateQuizRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if !quiz.IsMode(r.Mode) {
+		return fmt.Errorf("unknown mode %q", r.Mode)
+	}
+	if r.Size < 1 || r.Size > MaxQuizSize {
+		return fmt.Errorf("size must be from 1 to %d", MaxQuizSize)
+	}
+	return nil
+}
+
+type QuizQuestion struct {
+	TermId    uint64   `json:"term_id,string"`
+	Prompt    string   `json:"prompt"`
+	Options   []string `json:"options,omitempty"`   // multiple choice
+	Statement string   `json:"statement,omitempty"` // true/false: the proposed meaning of the term
+	IsTrue    bool     `json:"is_true"`             // true/false: the statement is the meaning of the term
+	Answer    string   `json:"answer"`
+}
+
+type GenerateQuizResponse struct {
+	CollectionId uint64          `json:"collection_id,string"`
+	Mode         string          `json:"mode"`
+	Seed         int64           `json:"seed,string"`
+	Questions    []*QuizQuestion `json:"questions"` // fewer than the size, when the collection has not enough terms
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/spaced_repetition"
+)
+
+type GetDueTermsRequest struct {
+	CollectionIds []uint64 `json:"collection_ids"` // all the collections of the user when empty
+	Limit         int      `json:"limit"`          // the configured limit when 0
+}
+
+func (r *GetDueTermsRequest) Validate() error {
+	if r.Limit < 0 {
+		return errors.New("limit is negative")
+	}
+	for _, collectionId := range r.CollectionIds {
+		if collectionId == 0 {
+			return errors.New("collection_id is empty")
+		}
+	}
+	return nil
+}
+
+type DueTerm struct {
+	TermId            uint64     `json:"term_id,string"`
+	CollectionId      uint64     `json:"collection_id,string"`
+	TermLanguageId    uint64     `json:"term_language_id,string"`
+	MeaningLanguageId uint64     `json:"meaning_language_id,string"`
+	Term              string     `json:"term"`
+	Meaning           string     `json:"meaning"`
+	Example           string     `json:"example"`
+	ImageUrl          string     `json:"image_url"`
+	IsPhrase          bool       `json:"is_phrase"`
+	Status            string     `json:"status"`
+	RepeatedAt        *time.Time `json:"repeated_at"` // null when the term was never repeated
+	DueAt             time.Time  `json:"due_at"`
+}
+
+type CollectionDueCount struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	Due          int    `json:"due"`
+}
+
+type GetDueTermsResponse struct {
+	Terms       []*DueTerm            `json:"terms"` // the most overdue first, limited
+	Collections []*CollectionDueCount `json:"collections"`
+	TotalDue    int                   `json:"total_due"`
+}
+
+type SubmitReviewRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	TermId       uint64 `json:"term_id,string"`
+	Grade        int    `json:"grade"` // 0 - complete blackout, 5 - perfect response
+}
+
+func (r *SubmitReviewRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if r.TermId == 0 {
+		return errors.New("term_id is empty")
+	}
+	if r.Grade < spaced_repetition.MinGrade || r.Grade > spaced_repetition.MaxGrade {
+		return fmt.Errorf("grade must be from %d to %d", spaced_repetition.MinGrade, spaced_repetition.MaxGrade)
+	}
+	return nil
+}
+
+type SubmitReviewResponse struct {
+	CollectionId uint64    `json:"collection_id,string"`
+	TermId       uint64    `json:"term_id,string"`
+	OldStatus    string    `json:"old_status"`
+	Status       string    `json:"status"`
+	DueAt        time.Time `json:"due_at"`
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+type CreateShareLinkRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	ExpiresIn    int64  `json:"expires_in"` // seconds, the link does not expire when 0
+}
+
+func (r *CreateShareLinkRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if r.ExpiresIn < 0 {
+		return errors.New("expires_in is negative")
+	}
+	return nil
+}
+
+type CreateShareLinkResponse struct {
+	LinkId    string     `json:"link_id"`
+	Token     string     `json:"token"`
+	Url       string     `json:"url"`
+	ExpiresAt *time.Time `json:"expires_at"`  // null when the link does not expire
+	QrCodePng []byte     `json:"qr_code_png"` // the QR code of the url, base64 in JSON
+}
+
+type RevokeShareLinkRequest struct {
+	LinkId string `json:"link_id"`
+}
+
+func (r *RevokeShareLinkRequest) Validate() error {
+	if r.LinkId == "" {
+		return errors.New("link_id is empty")
+	}
+	return nil
+}
+
+type RevokeShareLinkResponse struct{}
+
+// SharedCollectionRequest is the request of the shared routes, they are called without the authorization
+type SharedCollectionRequest struct {
+	Token string `json:"token"`
+}
+
+func (r *SharedCollectionRequest) Validate() error {
+	if r.Token == "" {
+		return errors.New("token is empty")
+	}
+	return nil
+}
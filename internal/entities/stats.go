@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type GetLearningStatsRequest struct {
+	Days     int    `json:"days"`      // the length of the daily series, the configured default when 0
+	TimeZone string `json:"time_zone"` // the IANA name, the days are counted in UTC when empty
+}
+
+func (r *GetLearningStatsRequest) Validate() error {
+	if r.Days < 0 {
+		return errors.New("days is negative")
+	}
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return fmt.Errorf("unknown time_zone %q", r.TimeZone)
+	}
+	return nil
+}
+
+// StatusCounts the number of the terms by the status name, every status is present
+type StatusCounts map[string]int
+
+type CollectionStats struct {
+	CollectionId uint64       `json:"collection_id,string"`
+	Name         string       `json:"name"`
+	LanguageId   uint64       `json:"language_id,string"`
+	Total        int          `json:"total"`
+	Statuses     StatusCounts `json:"statuses"`
+}
+
+// LanguageStats the terms of all the collections by the term language
+type LanguageStats struct {
+	LanguageId uint64       `json:"language_id,string"`
+	Total      int          `json:"total"`
+	Statuses   StatusCounts `json:"statuses"`
+}
+
+type DailyCount struct {
+	Date  string `json:"date"` // 2006-01-02 in the requested time zone
+	Count int    `json:"count"`
+}
+
+// StudyStreak the days in a row with a review, the streak is not broken until today ends.
+// Only the last review of every term is known, the day is missed when its terms are reviewed again later,
+// so Current may be shorter than the real streak.
+type StudyStreak struct {
+	Current       int    `json:"current"`
+	StudiedToday  bool   `json:"studied_today"`
+	LastStudiedOn string `json:"last_studied_on,omitempty"`
+}
+
+// GetLearningStatsResponse covers the own collections of the user.
+// vocabulary_api keeps only the last repetition of the term, so a review is counted on the day of the last one.
+type GetLearningStatsResponse struct {
+	Total          int                `json:"total"`
+	Statuses       StatusCounts       `json:"statuses"`
+	Collections    []*CollectionStats `json:"collections"`
+	Languages      []*LanguageStats   `json:"languages"`
+	AddedPerDay    []*DailyCount      `json:"added_per_day"`    // the oldest day first, today included
+	ReviewedPerDay []*DailyCount      `json:"reviewed_per_day"` // the oldest day first, today included
+	Streak         *StudyStreak       `json:"streak"`
+	GeneratedAt    time.Time          `json:"generated_at"` // the stats are cached for a short time
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/subtitles"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_import"
+)
+
+// ExtractSubtitleTermsRequest is the multipart form, the .srt or .vtt file is in the "file" part
+type ExtractSubtitleTermsRequest struct {
+	CollectionId uint64 `json:"collection_id,string"` // the chosen collection for the terms, its terms are known
+	LanguageId   uint64 `json:"language_id,string"`   // the language of the subtitles, the language of the collection when empty
+	Format       string `json:"format"`               // auto, srt or vtt; auto when empty
+	Encoding     string `json:"encoding"`             // the encoding of the file without BOM, when it is not UTF-8
+	Source       string `json:"source"`               // the name in the references, the file name without the extension when empty
+	MaxTerms     int    `json:"max_terms"`            // the configured default when 0
+	FileName     string `json:"file_name"`
+	File         []byte `json:"-"`
+}
+
+func (r *ExtractSubtitleTermsRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if r.Format != "" && !subtitles.IsFormat(r.Format) {
+		return fmt.Errorf("unknown format %q", r.Format)
+	}
+	if r.Encoding != "" && !term_import.IsFallbackEncoding(r.Encoding) {
+		return fmt.Errorf("unknown encoding %q", r.Encoding)
+	}
+	if r.MaxTerms < 0 {
+		return errors.New("max_terms is negative")
+	}
+	if len(r.File) == 0 {
+		return errors.New("file is empty")
+	}
+	return nil
+}
+
+// SubtitleTerm is the proposed term. The chosen ones are sent to AcceptSubtitleTerms,
+// which creates them with the reference appended to the example.
+type SubtitleTerm struct {
+	CollectionId   uint64 `json:"collection_id,string"`
+	TermLanguageId uint64 `json:"term_language_id,string"`
+	Term           string `json:"term"`
+	Example        string `json:"example"` // the text of the first cue with the word
+	Count          int    `json:"count"`
+	Guessed        bool   `json:"guessed"`   // the word boundaries are guessed (Chinese), it may be no word
+	Reference      string `json:"reference"` // the source and the time of the cue, e.g. "Friends S01E02 00:12:34"
+	StartMs        int64  `json:"start_ms"`
+	EndMs          int64  `json:"end_ms"`
+}
+
+type ExtractSubtitleTermsResponse struct {
+	Format     string          `json:"format"`
+	Encoding   string          `json:"encoding"`
+	Cues       int             `json:"cues"`
+	TotalWords int             `json:"total_words"` // the occurrences of all the words
+	StopWords  int             `json:"stop_words"`  // the occurrences of the function words, they are dropped
+	KnownWords int             `json:"known_words"` // the distinct words already in the collections, they are dropped
+	Terms      []*SubtitleTerm `json:"terms"`       // the most frequent first
+}
+
+// AcceptSubtitleTermsRequest creates the chosen proposals of ExtractSubtitleTerms in the collection
+type AcceptSubtitleTermsRequest struct {
+	CollectionId uint64                  `json:"collection_id,string"`
+	Terms        []*AcceptedSubtitleTerm `json:"terms"`
+}
+
+// AcceptedSubtitleTerm is the SubtitleTerm chosen by the user, the meaning is added by the user
+type AcceptedSubtitleTerm struct {
+	TermLanguageId    uint64 `json:"term_language_id,string"`
+	MeaningLanguageId uint64 `json:"meaning_language_id,string"`
+	Term              string `json:"term"`
+	Meaning           string `json:"meaning"`
+	Example           string `json:"example"`
+	Reference         string `json:"reference"`
+}
+
+func (r *AcceptSubtitleTermsRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if len(r.Terms) == 0 {
+		return errors.New("terms are empty")
+	}
+	for i, term := range r.Terms {
+		if term == nil || strings.TrimSpace(term.Term) == "" {
+			return fmt.Errorf("terms[%d].term is empty", i)
+		}
+		if term.TermLanguageId == 0 {
+			return fmt.Errorf("terms[%d].term_language_id is empty", i)
+		}
+	}
+	return nil
+}
+
+type AcceptSubtitleTermsResponse struct {
+	Created int `json:"created"`
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Results of the items of ChangeTermStatuses
+const (
+	TermStatusChanged   = "changed"
+	TermStatusForbidden = "forbidden" // the collection is not found or not editable by the user
+	TermStatusFailed    = "failed"
+)
+
+type TermStatusChange struct {
+	TermId       uint64 `json:"term_id,string"`
+	CollectionId uint64 `json:"collection_id,string"`
+	Status       string `json:"status"` // e.g. STATUS_TYPE_LEARNED
+}
+
+type ChangeTermStatusesRequest struct {
+	Items []*TermStatusChange `json:"items"`
+}
+
+func (r *ChangeTermStatusesRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return errors.New("items is empty")
+	}
+	seen := make(map[uint64]struct{}, len(r.Items))
+	for _, item := range r.Items {
+		if item == nil {
+			return errors.New("item is empty")
+		}
+		if item.CollectionId == 0 {
+			return errors.New("collection_id is empty")
+		}
+		if item.TermId == 0 {
+			return errors.New("term_id is empty")
+		}
+		if item.Status == "" {
+			return errors.New("status is empty")
+		}
+		if _, ok := seen[item.TermId]; ok {
+			return fmt.Errorf("term_id %d is repeated", item.TermId)
+		}
+		seen[item.TermId] = struct{}{}
+	}
+	return nil
+}
+
+type TermStatusChangeResult struct {
+	TermId       uint64 `json:"term_id,string"`
+	CollectionId uint64 `json:"collection_id,string"`
+	Result       string `json:"result"`
+	Error        string `json:"error,omitempty"`
+}
+
+type ChangeTermStatusesResponse struct {
+	Items   []*TermStatusChangeResult `json:"items"` // in the order of the request
+	Changed int                       `json:"changed"`
+	Failed  int                       `json:"failed"`
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// What to do with the terms, which languages do not match the target collection
+const (
+	LanguageMismatchReject = "reject" // the term is not transferred
+	LanguageMismatchMark   = "mark"   // the term is transferred with language_mismatch set in its result
+)
+
+// Results of the transferred terms
+const (
+	TransferTermMoved            = "moved"
+	TransferTermCopied           = "copied" // for MoveTerms: copied, but not deleted from the source, see error
+	TransferTermNotFound         = "not_found"
+	TransferTermLanguageMismatch = "language_mismatch"
+	TransferTermFailed           = "failed"
+)
+
+// TransferTermsRequest is the request of MoveTerms and CopyTerms
+type TransferTermsRequest struct {
+	SourceCollectionId uint64   `json:"source_collection_id,string"`
+	TargetCollectionId uint64   `json:"target_collection_id,string"`
+	TermIds            []uint64 `json:"term_ids"`
+	OnLanguageMismatch string   `json:"on_language_mismatch"` // reject or mark, reject when empty
+}
+
+func (r *TransferTermsRequest) Validate() error {
+	if r.SourceCollectionId == 0 || r.TargetCollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if r.SourceCollectionId == r.TargetCollectionId {
+		return errors.New("source and target collections are the same")
+	}
+	if len(r.TermIds) == 0 {
+		return errors.New("term_ids is empty")
+	}
+	seen := make(map[uint64]struct{}, len(r.TermIds))
+	for _, termId := range r.TermIds {
+		if termId == 0 {
+			return errors.New("term_id is empty")
+		}
+		if _, ok := seen[termId]; ok {
+			return fmt.Errorf("term_id %d is repeated", termId)
+		}
+		seen[termId] = struct{}{}
+	}
+	switch r.OnLanguageMismatch {
+	case "", LanguageMismatchReject, LanguageMismatchMark:
+	default:
+		return fmt.Errorf("unknown on_language_mismatch %q", r.OnLanguageMismatch)
+	}
+	return nil
+}
+
+type TransferTermResult struct {
+	TermId           uint64 `json:"term_id,string"`
+	NewTermId        uint64 `json:"new_term_id,string,omitempty"`
+	Result           string `json:"result"`
+	StatusRestored   bool   `json:"status_restored"` // the status and the time of the last review are the same as in the source
+	LanguageMismatch bool   `json:"language_mismatch"`
+	Error            string `json:"error,omitempty"`
+}
+
+type TransferTermsResponse struct {
+	Terms       []*TransferTermResult `json:"terms"` // in the order of term_ids
+	Transferred int                   `json:"transferred"`
+	Failed      int                   `json:"failed"` // not found, rejected or failed
+}
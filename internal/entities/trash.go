@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type TrashedCollection struct {
+	CollectionId uint64    `json:"collection_id,string"`
+	LanguageId   uint64    `json:"language_id,string"`
+	Name         string    `json:"name"`
+	TotalTerms   uint64    `json:"total_terms"`
+	DeletedBy    uint64    `json:"deleted_by,string"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	PurgeAt      time.Time `json:"purge_at"`
+}
+
+type TrashedTerm struct {
+	TermId            uint64    `json:"term_id,string"`
+	CollectionId      uint64    `json:"collection_id,string"`
+	Term              string    `json:"term"`
+	Meaning           string    `json:"meaning"`
+	DeletedBy         uint64    `json:"deleted_by,string"`
+	DeletedAt         time.Time `json:"deleted_at"`
+	PurgeAt           time.Time `json:"purge_at"`
+	CollectionInTrash bool      `json:"collection_in_trash"` // restored together with the collection only
+}
+
+type ListTrashRequest struct {
+	// the trashed terms of the collection, the editors may list them as well; the own trash when empty
+	CollectionId uint64 `json:"collection_id,string"`
+}
+
+func (r *ListTrashRequest) Validate() error {
+	return nil
+}
+
+type ListTrashResponse struct {
+	Collections []*TrashedCollection `json:"collections"` // the last deleted first
+	Terms       []*TrashedTerm       `json:"terms"`       // the last deleted first
+}
+
+type RestoreCollectionRequest struct {
+	CollectionId uint64 `json:"collection_id,string"`
+}
+
+func (r *RestoreCollectionRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	return nil
+}
+
+type RestoreCollectionResponse struct {
+	CollectionId uint64 `json:"collection_id,string"`
+}
+
+type RestoreTermsRequest struct {
+	CollectionId uint64   `json:"collection_id,string"`
+	TermIds      []uint64 `json:"term_ids"`
+}
+
+func (r *RestoreTermsRequest) Validate() error {
+	if r.CollectionId == 0 {
+		return errors.New("collection_id is empty")
+	}
+	if len(r.TermIds) == 0 {
+		return errors.New("term_ids is empty")
+	}
+	seen := make(map[uint64]struct{}, len(r.TermIds))
+	for _, termId := range r.TermIds {
+		if termId == 0 {
+			return errors.New("term_id is empty")
+		}
+		if _, ok := seen[termId]; ok {
+			return fmt.Errorf("term_id %d is repeated", termId)
+		}
+		seen[termId] = struct{}{}
+	}
+	return nil
+}
+
+type RestoreTermsResponse struct {
+	RestoredTermIds []uint64 `json:"restored_term_ids"`
+	NotFoundTermIds []uint64 `json:"not_found_term_ids"` // not in the trash, e.g. already restored or purged
+}
+
+type EmptyTrashRequest struct{}
+
+func (r *EmptyTrashRequest) Validate() error {
+	return nil
+}
+
+type EmptyTrashResponse struct {
+	PurgedCollections int `json:"purged_collections"`
+	PurgedTerms       int `json:"purged_terms"`
+	Failed            int `json:"failed"` // stay in the trash till the next purge
+}
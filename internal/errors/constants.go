@@ -1,42 +1,95 @@
 package errors
 
 const (
-	TooManyRequestsMsg                        = "too many requests"
-	FailedToGetRequestBody                    = "failed to get request body"
-	FailedToUnmarshalRequestBody              = "failed to unmarshal request body"
-	FailedToMarshalResponseBody               = "failed to marshal response body"
-	FailedToValidateRequestBody               = "failed to validate request body"
-	FailedToCreateUserMsg                     = "failed to create user"
-	FailedToUpdateUserMsg                     = "failed to update user"
-	FailedToGetUserMsg                        = "failed to get user"
-	FailedToRefreshTokensMsg                  = "failed to refresh tokens"
-	FailedToGenerateTokensMsg                 = "failed to generate tokens"
-	FailedToDeleteTokensMsg                   = "failed to delete tokens"
-	FailedToSignInMsg                         = "failed to sign in"
-	FailedToSignInWrongPasswordMsg            = "failed to sign in, wrong password"
-	TokenClaimsDoesNotSetMsg                  = "the token is not set"
-	FailedToConfirmEmailMsg                   = "failed to confirm email"
-	FailedToResetPasswordMsg                  = "failed to reset password"
-	FailedToCreateActionMsg                   = "failed to create action"
-	FailedToSendConfirmationEmailMsg          = "failed to send confirmation email"
-	FailedToSendResetPasswordEmailMsg         = "failed to send reset password email"
-	FailedToGetLanguagesMsg                   = "failed to get languages"
-	FailedToGetCollectionsMsg                 = "failed to get collections"
-	FailedToGetCollectionMsg                  = "failed to get collection"
-	FailedToGetTranslationMsg                 = "failed to get translation"
-	FailedToGetTermsMsg                       = "failed to get terms"
-	FailedToGetCollectionPrivateCollectionMsg = "private collection"
-	FailedToDeleteTermsMsg                    = "failed to delete terms"
-	FailedToChangeTermStatusMsg               = "failed to change term status"
-	FailedToDeleteCollectionMsg               = "failed to delete collection"
-	FailedToCreateCollectionMsg               = "failed to create collection"
-	FailedToUpdateCollectionMsg               = "failed to update collection"
-	FailedToUpdateTermMsg                     = "failed to update term"
-	FailedToCreateTermsMsg                    = "failed to create terms"
-	FailedToCreateTermsCollectionMsg          = "collection_id not same"
-	FailedToGetVoiceoverMsg                   = "failed to get voiceover"
-	FailedToSignInEmailNotConfirmedMsg        = "failed to sign in, email did not confirmed"
-	FailedToGetGoogleUserMsg                  = "failed to get google user"
+	TooManyRequestsMsg                         = "too many requests"
+	FailedToGetRequestBody                     = "failed to get request body"
+	FailedToUnmarshalRequestBody               = "failed to unmarshal request body"
+	FailedToMarshalResponseBody                = "failed to marshal response body"
+	FailedToValidateRequestBody                = "failed to validate request body"
+	FailedToCreateUserMsg                      = "failed to create user"
+	FailedToUpdateUserMsg                      = "failed to update user"
+	FailedToGetUserMsg                         = "failed to get user"
+	FailedToRefreshTokensMsg                   = "failed to refresh tokens"
+	FailedToGenerateTokensMsg                  = "failed to generate tokens"
+	FailedToDeleteTokensMsg                    = "failed to delete tokens"
+	FailedToSignInMsg                          = "failed to sign in"
+	FailedToSignInWrongPasswordMsg             = "failed to sign in, wrong password"
+	TokenClaimsDoesNotSetMsg                   = "the token is not set"
+	FailedToConfirmEmailMsg                    = "failed to confirm email"
+	FailedToResetPasswordMsg                   = "failed to reset password"
+	FailedToCreateActionMsg                    = "failed to create action"
+	FailedToSendConfirmationEmailMsg           = "failed to send confirmation email"
+	FailedToSendResetPasswordEmailMsg          = "failed to send reset password email"
+	FailedToGetLanguagesMsg                    = "failed to get languages"
+	FailedToGetCollectionsMsg                  = "failed to get collections"
+	FailedToGetCollectionMsg                   = "failed to get collection"
+	FailedToGetTranslationMsg                  = "failed to get translation"
+	FailedToGetTermsMsg                        = "failed to get terms"
+	FailedToGetCollectionPrivateCollectionMsg  = "private collection"
+	FailedToDeleteTermsMsg                     = "failed to delete terms"
+	FailedToChangeTermStatusMsg                = "failed to change term status"
+	FailedToDeleteCollectionMsg                = "failed to delete collection"
+	FailedToCreateCollectionMsg                = "failed to create collection"
+	FailedToUpdateCollectionMsg                = "failed to update collection"
+	FailedToUpdateTermMsg                      = "failed to update term"
+	FailedToCreateTermsMsg                     = "failed to create terms"
+	FailedToCreateTermsCollectionMsg           = "collection_id not same"
+	FailedToGetVoiceoverMsg                    = "failed to get voiceover"
+	FailedToSignInEmailNotConfirmedMsg         = "failed to sign in, email did not confirmed"
+	FailedToGetGoogleUserMsg                   = "failed to get google user"
+	FailedToInvalidateCacheUnknownCacheMsg     = "unknown cache"
+	FailedToGetDueTermsMsg                     = "failed to get due terms"
+	FailedToSubmitReviewMsg                    = "failed to submit review"
+	FailedToSubmitReviewTermNotFoundMsg        = "term not found"
+	FailedToGenerateQuizMsg                    = "failed to generate quiz"
+	FailedToImportTermsMsg                     = "failed to import terms"
+	FailedToImportTermsTooManyRowsMsg          = "too many rows to import"
+	FailedToExportCollectionMsg                = "failed to export collection"
+	FailedToAutoFillTranslationNotFoundMsg     = "translation not found"
+	FailedToAutoFillLanguageNotFoundMsg        = "language not found"
+	FailedToCloneCollectionMsg                 = "failed to clone collection"
+	FailedToGetCollectionSourceMsg             = "failed to get collection source"
+	FailedToSearchCollectionsMsg               = "failed to search collections"
+	FailedToSearchCollectionsInvalidCursorMsg  = "invalid cursor"
+	FailedToSearchCollectionsDisabledMsg       = "catalog is disabled"
+	FailedToBackfillCatalogDisabledMsg         = "catalog is disabled"
+	FailedToBackfillCatalogTooManyMsg          = "too many owners to backfill"
+	FailedToCheckCollectionAccessMsg           = "failed to check collection access"
+	FailedToCheckCollectionAccessRoleMsg       = "not enough rights for collection"
+	FailedToInviteCollaboratorMsg              = "failed to invite collaborator"
+	FailedToInviteCollaboratorInvalidRoleMsg   = "invalid role"
+	FailedToInviteCollaboratorOwnerMsg         = "owner can not be invited"
+	FailedToSendInviteEmailMsg                 = "failed to send invite email"
+	FailedToAcceptInviteMsg                    = "failed to accept invite"
+	FailedToGetInviteNotFoundMsg               = "invite not found"
+	FailedToAcceptInviteExpiredMsg             = "invite expired"
+	FailedToRevokeInviteMsg                    = "failed to revoke invite"
+	FailedToGetCollaboratorsMsg                = "failed to get collaborators"
+	FailedToGetInvitesMsg                      = "failed to get invites"
+	FailedToRemoveCollaboratorMsg              = "failed to remove collaborator"
+	FailedToRemoveCollaboratorNotFoundMsg      = "collaborator not found"
+	FailedToCreateShareLinkMsg                 = "failed to create share link"
+	FailedToRevokeShareLinkMsg                 = "failed to revoke share link"
+	FailedToGetShareLinkNotFoundMsg            = "share link not found"
+	FailedToGetShareLinkInvalidMsg             = "invalid share link"
+	FailedToGetShareLinkExpiredMsg             = "share link expired"
+	FailedToTransferTermsMsg                   = "failed to transfer terms"
+	FailedToTransferTermsTooManyMsg            = "too many terms to transfer"
+	FailedToMergeTermMsg                       = "failed to merge term"
+	FailedToGetLearningStatsMsg                = "failed to get learning stats"
+	FailedToChangeTermStatusesTooManyMsg       = "too many term statuses to change"
+	FailedToChangeTermStatusesInvalidMsg       = "invalid term status"
+	FailedToListTrashMsg                       = "failed to list trash"
+	FailedToRestoreCollectionMsg               = "failed to restore collection"
+	FailedToRestoreCollectionNotFoundMsg       = "collection not found in trash"
+	FailedToRestoreTermsMsg                    = "failed to restore terms"
+	FailedToEmptyTrashMsg                      = "failed to empty trash"
+	FailedToPurgeTrashMsg                      = "failed to purge trash"
+	FailedToExtractTermsTooLongMsg             = "text is too long"
+	FailedToExtractTermsLanguageNotFoundMsg    = "language not found"
+	FailedToExtractSubtitleTermsMsg            = "failed to extract subtitle terms"
+	FailedToExtractSubtitleTermsTooManyCuesMsg = "too many subtitle cues"
+	FailedToAcceptSubtitleTermsTooManyMsg      = "too many terms to accept"
 )
 
 // Authorization error messages
@@ -44,6 +97,7 @@ const (
 	FailedToGetAuthorizationToken = "failed to get authorization token"
 	AuthorizationTokenIsEmpty     = "authorization token is empty"
 	AuthorizationTokenIsInvalid   = "authorization token is invalid"
+	AdminTokenIsInvalid           = "admin token is invalid"
 )
 
 const (
@@ -165,11 +165,246 @@ var (
 		HttpStatusCode: http.StatusBadRequest,
 		GrpcStatusCode: codes.InvalidArgument,
 	}
+	FailedToInvalidateCacheUnknownCache = &outer.OuterError{
+		ErrorMessage:   FailedToInvalidateCacheUnknownCacheMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
 	FailedToSignInEmailNotConfirmed = &outer.OuterError{
 		ErrorMessage:   FailedToSignInEmailNotConfirmedMsg,
 		HttpStatusCode: http.StatusBadRequest,
 		GrpcStatusCode: codes.InvalidArgument,
 	}
+	FailedToGetDueTerms = &outer.OuterError{
+		ErrorMessage:   FailedToGetDueTermsMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToSubmitReview = &outer.OuterError{
+		ErrorMessage:   FailedToSubmitReviewMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToSubmitReviewTermNotFound = &outer.OuterError{
+		ErrorMessage:   FailedToSubmitReviewTermNotFoundMsg,
+		HttpStatusCode: http.StatusNotFound,
+		GrpcStatusCode: codes.NotFound,
+	}
+	FailedToGenerateQuiz = &outer.OuterError{
+		ErrorMessage:   FailedToGenerateQuizMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToImportTerms = &outer.OuterError{
+		ErrorMessage:   FailedToImportTermsMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToExportCollection = &outer.OuterError{
+		ErrorMessage:   FailedToExportCollectionMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToImportTermsTooManyRows = &outer.OuterError{
+		ErrorMessage:   FailedToImportTermsTooManyRowsMsg,
+		HttpStatusCode: http.StatusRequestEntityTooLarge,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToCloneCollection = &outer.OuterError{
+		ErrorMessage:   FailedToCloneCollectionMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToGetCollectionSource = &outer.OuterError{
+		ErrorMessage:   FailedToGetCollectionSourceMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToSearchCollections = &outer.OuterError{
+		ErrorMessage:   FailedToSearchCollectionsMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToSearchCollectionsInvalidCursor = &outer.OuterError{
+		ErrorMessage:   FailedToSearchCollectionsInvalidCursorMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToSearchCollectionsDisabled = &outer.OuterError{
+		ErrorMessage:   FailedToSearchCollectionsDisabledMsg,
+		HttpStatusCode: http.StatusServiceUnavailable,
+		GrpcStatusCode: codes.Unavailable,
+	}
+	FailedToBackfillCatalogDisabled = &outer.OuterError{
+		ErrorMessage:   FailedToBackfillCatalogDisabledMsg,
+		HttpStatusCode: http.StatusServiceUnavailable,
+		GrpcStatusCode: codes.Unavailable,
+	}
+	FailedToBackfillCatalogTooMany = &outer.OuterError{
+		ErrorMessage:   FailedToBackfillCatalogTooManyMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToCheckCollectionAccess = &outer.OuterError{
+		ErrorMessage:   FailedToCheckCollectionAccessMsg,
+		HttpStatusCode: http.StatusInternalServerError,
+		GrpcStatusCode: codes.Internal,
+	}
+	FailedToCheckCollectionAccessRole = &outer.OuterError{
+		ErrorMessage:   FailedToCheckCollectionAccessRoleMsg,
+		HttpStatusCode: http.StatusForbidden,
+		GrpcStatusCode: codes.PermissionDenied,
+	}
+	FailedToInviteCollaborator = &outer.OuterError{
+		ErrorMessage:   FailedToInviteCollaboratorMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToInviteCollaboratorInvalidRole = &outer.OuterError{
+		ErrorMessage:   FailedToInviteCollaboratorInvalidRoleMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToInviteCollaboratorOwner = &outer.OuterError{
+		ErrorMessage:   FailedToInviteCollaboratorOwnerMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToSendInviteEmail = &outer.OuterError{
+		ErrorMessage:   FailedToSendInviteEmailMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToAcceptInvite = &outer.OuterError{
+		ErrorMessage:   FailedToAcceptInviteMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToGetInviteNotFound = &outer.OuterError{
+		ErrorMessage:   FailedToGetInviteNotFoundMsg,
+		HttpStatusCode: http.StatusNotFound,
+		GrpcStatusCode: codes.NotFound,
+	}
+	FailedToAcceptInviteExpired = &outer.OuterError{
+		ErrorMessage:   FailedToAcceptInviteExpiredMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.FailedPrecondition,
+	}
+	FailedToRevokeInvite = &outer.OuterError{
+		ErrorMessage:   FailedToRevokeInviteMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToGetCollaborators = &outer.OuterError{
+		ErrorMessage:   FailedToGetCollaboratorsMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToGetInvites = &outer.OuterError{
+		ErrorMessage:   FailedToGetInvitesMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToRemoveCollaborator = &outer.OuterError{
+		ErrorMessage:   FailedToRemoveCollaboratorMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToRemoveCollaboratorNotFound = &outer.OuterError{
+		ErrorMessage:   FailedToRemoveCollaboratorNotFoundMsg,
+		HttpStatusCode: http.StatusNotFound,
+		GrpcStatusCode: codes.NotFound,
+	}
+	FailedToCreateShareLink = &outer.OuterError{
+		ErrorMessage:   FailedToCreateShareLinkMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToRevokeShareLink = &outer.OuterError{
+		ErrorMessage:   FailedToRevokeShareLinkMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToGetShareLinkNotFound = &outer.OuterError{
+		ErrorMessage:   FailedToGetShareLinkNotFoundMsg,
+		HttpStatusCode: http.StatusNotFound,
+		GrpcStatusCode: codes.NotFound,
+	}
+	FailedToGetShareLinkInvalid = &outer.OuterError{
+		ErrorMessage:   FailedToGetShareLinkInvalidMsg,
+		HttpStatusCode: http.StatusForbidden,
+		GrpcStatusCode: codes.PermissionDenied,
+	}
+	FailedToGetShareLinkExpired = &outer.OuterError{
+		ErrorMessage:   FailedToGetShareLinkExpiredMsg,
+		HttpStatusCode: http.StatusForbidden,
+		GrpcStatusCode: codes.PermissionDenied,
+	}
+	FailedToTransferTermsTooMany = &outer.OuterError{
+		ErrorMessage:   FailedToTransferTermsTooManyMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToGetLearningStats = &outer.OuterError{
+		ErrorMessage:   FailedToGetLearningStatsMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToChangeTermStatusesTooMany = &outer.OuterError{
+		ErrorMessage:   FailedToChangeTermStatusesTooManyMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToChangeTermStatusesInvalid = &outer.OuterError{
+		ErrorMessage:   FailedToChangeTermStatusesInvalidMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToListTrash = &outer.OuterError{
+		ErrorMessage:   FailedToListTrashMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToRestoreCollection = &outer.OuterError{
+		ErrorMessage:   FailedToRestoreCollectionMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToRestoreCollectionNotFound = &outer.OuterError{
+		ErrorMessage:   FailedToRestoreCollectionNotFoundMsg,
+		HttpStatusCode: http.StatusNotFound,
+		GrpcStatusCode: codes.NotFound,
+	}
+	FailedToRestoreTerms = &outer.OuterError{
+		ErrorMessage:   FailedToRestoreTermsMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToEmptyTrash = &outer.OuterError{
+		ErrorMessage:   FailedToEmptyTrashMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToExtractTermsTooLong = &outer.OuterError{
+		ErrorMessage:   FailedToExtractTermsTooLongMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToExtractTermsLanguageNotFound = &outer.OuterError{
+		ErrorMessage:   FailedToExtractTermsLanguageNotFoundMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToExtractSubtitleTermsTooManyCues = &outer.OuterError{
+		ErrorMessage:   FailedToExtractSubtitleTermsTooManyCuesMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
+	FailedToAcceptSubtitleTermsTooMany = &outer.OuterError{
+		ErrorMessage:   FailedToAcceptSubtitleTermsTooManyMsg,
+		HttpStatusCode: http.StatusBadRequest,
+		GrpcStatusCode: codes.InvalidArgument,
+	}
 )
 
 func BadRequestError(err error) *outer.OuterError {
@@ -0,0 +1,298 @@
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/sqlite_file"
+)
+
+// Deck is exported as the .apkg package: the legacy collection (schema 11) with one deck
+// and one basic note type, every note has one card.
+type Deck struct {
+	Name        string
+	Description string
+	// Fields of the note type, the first one is the sort field
+	Fields []string
+	// Front and Back are the templates of the card, e.g. "{{Term}}"
+	Front string
+	Back  string
+	CSS   string
+	Notes []*Note
+}
+
+type Note struct {
+	// Guid identifies the note, Anki updates the note with the same guid on the next import
+	Guid   string
+	Fields []string // HTML, in the order of Deck.Fields
+	Tags   []string
+}
+
+const (
+	defaultDeckId = 1
+	schemaVersion = 11
+	fieldSep      = "\x1f"
+)
+
+// WritePackage writes the deck as the .apkg zip
+func WritePackage(w io.Writer, deck *Deck, now time.Time) error {
+	db, err := buildCollection(deck, now)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	file, err := archive.Create("collection.anki2")
+	if err != nil {
+		return err
+	}
+	if _, err = db.WriteTo(file); err != nil {
+		return err
+	}
+
+	// The package has no media files
+	file, err = archive.Create("media")
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(file, "{}"); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func buildCollection(deck *Deck, now time.Time) (*sqlite_file.Database, error) {
+	nowMs := now.UnixMilli()
+	modelId := nowMs
+	deckId := nowMs + 1
+
+	conf, models, decks, dconf, err := collectionConfig(deck, now, modelId, deckId)
+	if err != nil {
+		return nil, err
+	}
+
+	db := sqlite_file.New()
+	col := db.CreateTable("col", colSQL)
+	notes := db.CreateTable("notes", notesSQL)
+	cards := db.CreateTable("cards", cardsSQL)
+	db.CreateTable("revlog", revlogSQL)
+	db.CreateTable("graves", gravesSQL)
+
+	err = col.Insert(1, nil,
+		now.Truncate(24*time.Hour).Unix(), // crt
+		nowMs,                             // mod
+		nowMs,                             // scm
+		int64(schemaVersion),              // ver
+		int64(0),                          // dty
+		int64(0),                          // usn
+		int64(0),                          // ls
+		conf, models, decks, dconf,
+		"{}", // tags
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ids of Anki are the creation times in milliseconds, they are shifted to stay unique
+	for i, note := range deck.Notes {
+		if len(note.Fields) != len(deck.Fields) {
+			return nil, fmt.Errorf("note %s has %d fields, the note type has %d", note.Guid, len(note.Fields), len(deck.Fields))
+		}
+
+		id := nowMs + int64(i)
+		sortField := stripHTML(note.Fields[0])
+		tags := ""
+		if len(note.Tags) > 0 {
+			tags = " " + strings.Join(note.Tags, " ") + " "
+		}
+
+		err = notes.Insert(id, nil,
+			note.Guid,
+			modelId,
+			now.Unix(), // mod
+			int64(-1),  // usn
+			tags,
+			strings.Join(note.Fields, fieldSep),
+			sortField,
+			checksum(sortField),
+			int64(0), // flags
+			"",       // data
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cards.Insert(id, nil,
+			id,                                                                             // nid
+			deckId,                                                                         // did
+			int64(0),                                                                       // ord
+			now.Unix(),                                                                     // mod
+			int64(-1),                                                                      // usn
+			int64(0),                                                                       // type: new
+			int64(0),                                                                       // queue: new
+			int64(i+1),                                                                     // due: the position of the new card
+			int64(0), int64(0), int64(0), int64(0), int64(0), int64(0), int64(0), int64(0), // ivl, factor, reps, lapses, left, odue, odid, flags
+			"", // data
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+func collectionConfig(deck *Deck, now time.Time, modelId int64, deckId int64) (conf, models, decks, dconf string, err error) {
+	fields := make([]map[string]any, len(deck.Fields))
+	for i, name := range deck.Fields {
+		fields[i] = map[string]any{
+			"name": name, "ord": i, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []any{},
+		}
+	}
+
+	values := []any{
+		map[string]any{
+			"nextPos": len(deck.Notes) + 1, "estTimes": true, "activeDecks": []int64{deckId}, "sortType": "noteFld",
+			"timeLim": 0, "sortBackwards": false, "addToCur": true, "curDeck": deckId, "newBury": true,
+			"newSpread": 0, "dueCounts": true, "curModel": strconv.FormatInt(modelId, 10), "collapseTime": 1200,
+		},
+		map[string]any{
+			strconv.FormatInt(modelId, 10): map[string]any{
+				"id": modelId, "name": deck.Name, "type": 0, "mod": now.Unix(), "usn": -1, "sortf": 0, "did": deckId,
+				"tmpls": []map[string]any{{
+					"name": "Card 1", "ord": 0, "qfmt": deck.Front, "afmt": deck.Back, "did": nil, "bqfmt": "", "bafmt": "",
+				}},
+				"flds": fields, "css": deck.CSS, "tags": []any{}, "vers": []any{},
+				"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+				"latexPost": "\\end{document}",
+				"req":       []any{[]any{0, "any", []int{0}}},
+			},
+		},
+		map[string]any{
+			strconv.Itoa(defaultDeckId):   newDeck(defaultDeckId, "Default", "", now),
+			strconv.FormatInt(deckId, 10): newDeck(deckId, deck.Name, deck.Description, now),
+		},
+		map[string]any{
+			strconv.Itoa(defaultDeckId): map[string]any{
+				"id": defaultDeckId, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true,
+				"timer": 0, "replayq": true, "dyn": false,
+				"new": map[string]any{
+					"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": 2500, "order": 1, "perDay": 20,
+					"bury": true, "separate": true,
+				},
+				"rev": map[string]any{
+					"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "ivlFct": 1, "maxIvl": 36500, "minSpace": 1, "bury": true,
+				},
+				"lapse": map[string]any{
+					"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0,
+				},
+			},
+		},
+	}
+
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		buf, err := json.Marshal(value)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		encoded[i] = string(buf)
+	}
+	return encoded[0], encoded[1], encoded[2], encoded[3], nil
+}
+
+func newDeck(id int64, name string, description string, now time.Time) map[string]any {
+	return map[string]any{
+		"id": id, "name": name, "desc": description, "mod": now.Unix(), "usn": -1, "conf": 1, "dyn": 0,
+		"collapsed": false, "browserCollapsed": false, "extendNew": 0, "extendRev": 0,
+		"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+	}
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+func stripHTML(s string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlTag.ReplaceAllString(s, "")))
+}
+
+// checksum is the first 8 hex digits of SHA1 of the sort field, Anki finds the duplicates by it
+func checksum(s string) int64 {
+	sum := sha1.Sum([]byte(s))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+// The tables of the collection, schema 11
+const (
+	colSQL = `CREATE TABLE col (
+    id              integer primary key,
+    crt             integer not null,
+    mod             integer not null,
+    scm             integer not null,
+    ver             integer not null,
+    dty             integer not null,
+    usn             integer not null,
+    ls              integer not null,
+    conf            text not null,
+    models          text not null,
+    decks           text not null,
+    dconf           text not null,
+    tags            text not null
+)`
+	notesSQL = `CREATE TABLE notes (
+    id              integer primary key,
+    guid            text not null,
+    mid             integer not null,
+    mod             integer not null,
+    usn             integer not null,
+    tags            text not null,
+    flds            text not null,
+    sfld            integer not null,
+    csum            integer not null,
+    flags           integer not null,
+    data            text not null
+)`
+	cardsSQL = `CREATE TABLE cards (
+    id              integer primary key,
+    nid             integer not null,
+    did             integer not null,
+    ord             integer not null,
+    mod             integer not null,
+    usn             integer not null,
+    type            integer not null,
+    queue           integer not null,
+    due             integer not null,
+    ivl             integer not null,
+    factor          integer not null,
+    reps            integer not null,
+    lapses          integer not null,
+    left            integer not null,
+    odue            integer not null,
+    odid            integer not null,
+    flags           integer not null,
+    data            text not null
+)`
+	revlogSQL = `CREATE TABLE revlog (
+    id              integer primary key,
+    cid             integer not null,
+    usn             integer not null,
+    ease            integer not null,
+    ivl             integer not null,
+    lastIvl         integer not null,
+    factor          integer not null,
+    time            integer not null,
+    type            integer not null
+)`
+	gravesSQL = `CREATE TABLE graves (
+    usn             integer not null,
+    oid             integer not null,
+    type            integer not null
+)`
+)
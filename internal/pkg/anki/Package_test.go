@@ -0,0 +1,156 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// openPackage writes the deck and opens the collection of the package with the SQLite driver,
+// the collection must pass the integrity check
+func openPackage(t *testing.T, deck *Deck, now time.Time) *sql.DB {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := WritePackage(&buf, deck, now); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name], err = io.ReadAll(r)
+		_ = r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if string(files["media"]) != "{}" {
+		t.Fatalf("got the media %q, want {}", files["media"])
+	}
+
+	path := filepath.Join(t.TempDir(), "collection.anki2")
+	if err = os.WriteFile(path, files["collection.anki2"], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	var result string
+	if err = conn.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		t.Fatal(err)
+	}
+	if result != "ok" {
+		t.Fatalf("integrity check: %s", result)
+	}
+	return conn
+}
+
+func TestWritePackage(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes int
+		back  func(i int) string
+	}{
+		{name: "empty deck"},
+		{name: "small deck", notes: 3, back: func(i int) string { return fmt.Sprintf("<b>back %d</b>", i) }},
+		// the notes overflow the cells and the tables get the interior pages
+		{name: "large deck", notes: 2000, back: func(i int) string { return strings.Repeat("example ", 10+i%1000) }},
+	}
+
+	now := time.Date(2024, 5, 20, 10, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deck := &Deck{
+				Name:   "Deck",
+				Fields: []string{"Term", "Translation"},
+				Front:  "{{Term}}",
+				Back:   "{{Translation}}",
+			}
+			for i := 0; i < tt.notes; i++ {
+				deck.Notes = append(deck.Notes, &Note{
+					Guid:   fmt.Sprintf("guid-%d", i),
+					Fields: []string{fmt.Sprintf("term &amp; %d", i), tt.back(i)},
+					Tags:   []string{"tag"},
+				})
+			}
+
+			conn := openPackage(t, deck, now)
+
+			var models, decks string
+			if err := conn.QueryRow("SELECT models, decks FROM col").Scan(&models, &decks); err != nil {
+				t.Fatal(err)
+			}
+			var parsedModels, parsedDecks map[string]any
+			if err := json.Unmarshal([]byte(models), &parsedModels); err != nil || len(parsedModels) != 1 {
+				t.Fatalf("got the models %s: %v", models, err)
+			}
+			if err := json.Unmarshal([]byte(decks), &parsedDecks); err != nil || len(parsedDecks) != 2 {
+				t.Fatalf("got the decks %s: %v", decks, err)
+			}
+
+			var cards int
+			if err := conn.QueryRow("SELECT count(*) FROM cards JOIN notes ON notes.id = cards.nid").Scan(&cards); err != nil {
+				t.Fatal(err)
+			}
+			if cards != tt.notes {
+				t.Fatalf("got %d cards, want %d", cards, tt.notes)
+			}
+
+			rows, err := conn.Query("SELECT guid, flds, sfld, csum, tags FROM notes ORDER BY id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			for i := 0; rows.Next(); i++ {
+				var guid, fields, sortField, tags string
+				var csum int64
+				if err = rows.Scan(&guid, &fields, &sortField, &csum, &tags); err != nil {
+					t.Fatal(err)
+				}
+				note := deck.Notes[i]
+				if guid != note.Guid || fields != strings.Join(note.Fields, fieldSep) || tags != " tag " {
+					t.Fatalf("note %d: got the guid %q, the tags %q and the fields of %d bytes", i, guid, tags, len(fields))
+				}
+				if want := fmt.Sprintf("term & %d", i); sortField != want || csum != checksum(want) {
+					t.Fatalf("note %d: got the sort field %q (%d), want %q", i, sortField, csum, want)
+				}
+			}
+			if err = rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestWritePackageFieldCount(t *testing.T) {
+	deck := &Deck{
+		Name:   "Deck",
+		Fields: []string{"Term", "Translation"},
+		Notes:  []*Note{{Guid: "guid", Fields: []string{"term"}}},
+	}
+	if err := WritePackage(io.Discard, deck, time.Now()); err == nil {
+		t.Fatal("got nil, want the field count error")
+	}
+}
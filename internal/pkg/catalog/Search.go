@@ -0,0 +1,225 @@
+package catalog
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sorting of the search results
+const (
+	SortPopular = "popular" // by the clones, then by the size
+	SortRecent  = "recent"  // by the last update
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+func IsSort(sort string) bool {
+	return sort == SortPopular || sort == SortRecent
+}
+
+// Query all the words of Text must be found in the name or the description, the last word matches as a prefix
+type Query struct {
+	Text       string
+	LanguageId uint64 // any language when 0
+	MinTerms   uint64
+	MaxTerms   uint64 // no limit when 0
+	Sort       string
+	Cursor     string // NextCursor of the previous page, the first page when empty
+	Limit      int
+}
+
+type Page struct {
+	Entries    []Entry
+	NextCursor string // empty on the last page
+}
+
+// sortKey orders the entries from the largest to the smallest key, the collection id breaks the ties,
+// so the cursor is the key of the last entry of the page and the next page starts right after it
+type sortKey struct {
+	primary      int64
+	secondary    int64
+	collectionId uint64
+}
+
+func (k sortKey) compare(other sortKey) int {
+	if c := cmp.Compare(other.primary, k.primary); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(other.secondary, k.secondary); c != 0 {
+		return c
+	}
+	return cmp.Compare(other.collectionId, k.collectionId)
+}
+
+func keyOf(entry *Entry, sort string) sortKey {
+	if sort == SortRecent {
+		return sortKey{primary: entry.UpdatedAt.UnixNano(), collectionId: entry.CollectionId}
+	}
+	return sortKey{primary: int64(entry.Clones), secondary: int64(entry.TotalTerms), collectionId: entry.CollectionId}
+}
+
+// Search returns the page of the matching collections. The cursor is stable while the catalog changes:
+// the collection moved before the cursor by its update is skipped, it is not repeated.
+// The keys of the cursor are in the shared catalog, so the cursor works on any instance.
+func (s *Store) Search(ctx context.Context, query Query) (*Page, error) {
+	var after *sortKey
+	if query.Cursor != "" {
+		key, err := decodeCursor(query.Cursor, query.Sort)
+		if err != nil {
+			return nil, err
+		}
+		after = &key
+	}
+
+	candidates, err := s.match(ctx, tokenize(query.Text))
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]Entry, 0, len(candidates))
+	keys := make(map[uint64]sortKey, len(candidates))
+	for _, entry := range candidates {
+		if query.LanguageId != 0 && entry.LanguageId != query.LanguageId {
+			continue
+		}
+		if entry.TotalTerms < query.MinTerms || (query.MaxTerms != 0 && entry.TotalTerms > query.MaxTerms) {
+			continue
+		}
+		key := keyOf(entry, query.Sort)
+		if after != nil && key.compare(*after) <= 0 {
+			continue
+		}
+		matched = append(matched, *entry)
+		keys[entry.CollectionId] = key
+	}
+
+	slices.SortFunc(matched, func(a, b Entry) int {
+		return keys[a.CollectionId].compare(keys[b.CollectionId])
+	})
+
+	page := &Page{}
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+		page.NextCursor = encodeCursor(keys[matched[len(matched)-1].CollectionId], query.Sort)
+	}
+	page.Entries = matched
+	return page, nil
+}
+
+// match returns the entries having all the tokens with their clones, every entry when there are no tokens
+func (s *Store) match(ctx context.Context, tokens []string) ([]*Entry, error) {
+	var (
+		members []string
+		err     error
+	)
+	if len(tokens) == 0 {
+		members, err = s.client.SMembers(ctx, s.prefix+"ids").Result()
+	} else {
+		members, err = s.matchTokens(ctx, tokens)
+	}
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+
+	entryKeys := make([]string, len(members))
+	for i, member := range members {
+		entryKeys[i] = s.prefix + "entry:" + member
+	}
+	values, err := s.client.MGet(ctx, entryKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	clones, err := s.client.HMGet(ctx, s.prefix+"clones", members...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(values))
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			// deleted after the match
+			continue
+		}
+		entry := new(Entry)
+		if err = json.Unmarshal([]byte(raw), entry); err != nil {
+			return nil, err
+		}
+		if count, ok := clones[i].(string); ok {
+			n, err := strconv.ParseUint(count, 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			entry.Clones = uint32(n)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// matchTokens returns the ids of the entries having all the tokens, the last token matches as a prefix
+func (s *Store) matchTokens(ctx context.Context, tokens []string) ([]string, error) {
+	last := tokens[len(tokens)-1]
+	// the last word may be not typed completely yet, the byte 0xff is above every byte of UTF-8
+	words, err := s.client.ZRangeByLex(ctx, s.prefix+"tokens", &redis.ZRangeBy{Min: "[" + last, Max: "[" + last + "\xff"}).Result()
+	if err != nil || len(words) == 0 {
+		return nil, err
+	}
+	wordKeys := make([]string, len(words))
+	for i, word := range words {
+		wordKeys[i] = s.tokenKey(word)
+	}
+	ids, err := s.client.SUnion(ctx, wordKeys...).Result()
+	if err != nil || len(tokens) == 1 || len(ids) == 0 {
+		return ids, err
+	}
+
+	tokenKeys := make([]string, len(tokens)-1)
+	for i, token := range tokens[:len(tokens)-1] {
+		tokenKeys[i] = s.tokenKey(token)
+	}
+	others, err := s.client.SInter(ctx, tokenKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]struct{}, len(others))
+	for _, id := range others {
+		found[id] = struct{}{}
+	}
+	return slices.DeleteFunc(ids, func(id string) bool {
+		_, ok := found[id]
+		return !ok
+	}), nil
+}
+
+func encodeCursor(key sortKey, sort string) string {
+	raw := fmt.Sprintf("%s:%d:%d:%d", sort, key.primary, key.secondary, key.collectionId)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor the cursor of one sorting can not be used with another one
+func decodeCursor(cursor string, sort string) (sortKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return sortKey{}, ErrInvalidCursor
+	}
+
+	cursorSort, values, ok := strings.Cut(string(raw), ":")
+	if !ok || cursorSort != sort {
+		return sortKey{}, ErrInvalidCursor
+	}
+
+	var key sortKey
+	if _, err = fmt.Sscanf(values, "%d:%d:%d", &key.primary, &key.secondary, &key.collectionId); err != nil {
+		return sortKey{}, ErrInvalidCursor
+	}
+	return key, nil
+}
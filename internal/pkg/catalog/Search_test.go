@@ -0,0 +1,257 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestClient(t *testing.T) redis.UniversalClient {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func newTestStore(t *testing.T) *Store {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0).UTC()
+	s := New(newTestClient(t), "test:catalog:")
+	entries := []struct {
+		entry  Entry
+		clones int
+	}{
+		{entry: Entry{CollectionId: 1, UserId: 10, LanguageId: 1, Name: "Café words", Description: "Food and drinks", TotalTerms: 50, UpdatedAt: now}, clones: 3},
+		{entry: Entry{CollectionId: 2, UserId: 10, LanguageId: 1, Name: "Travel", Description: "Words for the airport", TotalTerms: 20, UpdatedAt: now.Add(time.Hour)}, clones: 3},
+		{entry: Entry{CollectionId: 3, UserId: 20, LanguageId: 2, Name: "ＦＯＯＤ", Description: "", TotalTerms: 200, UpdatedAt: now.Add(-time.Hour)}, clones: 9},
+		{entry: Entry{CollectionId: 4, UserId: 20, LanguageId: 1, Name: "Verbs", Description: "Irregular verbs", TotalTerms: 20, UpdatedAt: now.Add(time.Hour)}, clones: 3},
+	}
+	for _, e := range entries {
+		if err := s.Put(ctx, e.entry); err != nil {
+			t.Fatal(err)
+		}
+		for range e.clones {
+			if err := s.AddClone(ctx, e.entry.CollectionId); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return s
+}
+
+func entryIds(entries []Entry) []uint64 {
+	ids := make([]uint64, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.CollectionId
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  []uint64
+	}{
+		{name: "popular", query: Query{Sort: SortPopular}, want: []uint64{3, 1, 4, 2}},
+		{name: "recent, the id breaks the ties", query: Query{Sort: SortRecent}, want: []uint64{4, 2, 1, 3}},
+		{name: "accents and full width", query: Query{Text: "cafe", Sort: SortPopular}, want: []uint64{1}},
+		{name: "all the words", query: Query{Text: "food WORDS", Sort: SortPopular}, want: []uint64{1}},
+		{name: "last word as prefix", query: Query{Text: "foo", Sort: SortPopular}, want: []uint64{3, 1}},
+		{name: "only the last word as prefix", query: Query{Text: "wor food", Sort: SortPopular}, want: []uint64{}},
+		{name: "language", query: Query{LanguageId: 2, Sort: SortPopular}, want: []uint64{3}},
+		{name: "terms range", query: Query{MinTerms: 20, MaxTerms: 50, Sort: SortPopular}, want: []uint64{1, 4, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Limit = 10
+			page, err := newTestStore(t).Search(context.Background(), tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := entryIds(page.Entries); !slices.Equal(got, tt.want) || page.NextCursor != "" {
+				t.Fatalf("Search() = %v, %q, want %v without the cursor", got, page.NextCursor, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		sort   string
+		change func(s *Store) error // between the pages
+		want   []uint64
+	}{
+		{name: "pages", sort: SortPopular, want: []uint64{3, 1, 4, 2}},
+		{name: "recent pages", sort: SortRecent, want: []uint64{4, 2, 1, 3}},
+		{
+			name:   "collection moved before the cursor is not repeated",
+			sort:   SortPopular,
+			change: func(s *Store) error { return s.AddClone(context.Background(), 4) },
+			want:   []uint64{3, 1, 2},
+		},
+		{
+			name: "collection added after the cursor is found",
+			sort: SortPopular,
+			change: func(s *Store) error {
+				return s.Put(context.Background(), Entry{CollectionId: 5, UserId: 30, LanguageId: 1, Name: "Colors"})
+			},
+			want: []uint64{3, 1, 4, 2, 5},
+		},
+		{
+			name:   "deleted collection",
+			sort:   SortPopular,
+			change: func(s *Store) error { return s.Delete(context.Background(), 4) },
+			want:   []uint64{3, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			// every page is read by another instance, sharing only Redis
+			other := New(s.client, s.prefix)
+			query := Query{Sort: tt.sort, Limit: 2}
+
+			var got []uint64
+			for pages := 0; ; pages++ {
+				if pages == 5 {
+					t.Fatal("Search() does not end")
+				}
+				store := s
+				if pages%2 == 1 {
+					store = other
+				}
+				page, err := store.Search(context.Background(), query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, entryIds(page.Entries)...)
+				if page.NextCursor == "" {
+					break
+				}
+				if pages == 0 && tt.change != nil {
+					if err = tt.change(s); err != nil {
+						t.Fatal(err)
+					}
+				}
+				query.Cursor = page.NextCursor
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Search() pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchInvalidCursor(t *testing.T) {
+	s := newTestStore(t)
+	page, err := s.Search(context.Background(), Query{Sort: SortPopular, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cursor := range []string{"not base64!", "eA", page.NextCursor} {
+		// the popular cursor is invalid for the recent sorting
+		if _, err = s.Search(context.Background(), Query{Sort: SortRecent, Cursor: cursor, Limit: 1}); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("Search(%q) error = %v, want %v", cursor, err, ErrInvalidCursor)
+		}
+	}
+}
+
+func TestPutOwner(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	if err := s.AddClone(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutOwner(ctx, 10, []Entry{{CollectionId: 2, UserId: 10, Name: "Travel"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := s.Search(ctx, Query{Sort: SortPopular, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entryIds(page.Entries); !slices.Equal(got, []uint64{3, 2, 4}) {
+		t.Fatalf("Search() after PutOwner = %v", got)
+	}
+	// the clones counted before are kept
+	if clones := page.Entries[1].Clones; clones != 4 {
+		t.Fatalf("Clones = %d, want 4", clones)
+	}
+	if page, _ = s.Search(ctx, Query{Text: "food drinks", Sort: SortPopular, Limit: 10}); len(page.Entries) != 0 {
+		t.Fatalf("deleted collection is found: %v", entryIds(page.Entries))
+	}
+}
+
+func TestPutOwnerWithoutEntries(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	if err := s.PutOwner(ctx, 20, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	owners, err := s.Owners(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size, err := s.Len(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(owners, []uint64{10}) || size != 2 {
+		t.Fatalf("Owners() = %v, Len() = %d, want [10] and 2", owners, size)
+	}
+}
+
+func TestAddCloneOutOfCatalog(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	if err := s.AddClone(ctx, 99); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, Entry{CollectionId: 99, UserId: 30, Name: "Later"}); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := s.Search(ctx, Query{Text: "later", Sort: SortPopular, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Entries) != 1 || page.Entries[0].Clones != 0 {
+		t.Fatalf("Search() = %+v, want the entry without the clones", page.Entries)
+	}
+}
+
+func TestClaim(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	other := New(s.client, s.prefix)
+
+	tests := []struct {
+		name       string
+		store      *Store
+		routingKey string
+		body       string
+		want       bool
+	}{
+		{name: "first instance", store: s, routingKey: "collection.updated", body: "event 1", want: true},
+		{name: "another instance", store: other, routingKey: "collection.updated", body: "event 1"},
+		{name: "another event", store: other, routingKey: "collection.updated", body: "event 2", want: true},
+		{name: "another routing key", store: other, routingKey: "collection.deleted", body: "event 1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimed, err := tt.store.Claim(ctx, tt.routingKey, []byte(tt.body), time.Hour)
+			if err != nil || claimed != tt.want {
+				t.Fatalf("Claim() = %v, %v, want %v", claimed, err, tt.want)
+			}
+		})
+	}
+}
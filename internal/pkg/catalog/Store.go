@@ -0,0 +1,258 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxWatchAttempts of the update of the entry changed concurrently by another instance
+const maxWatchAttempts = 5
+
+// Entry is the public collection in the catalog
+type Entry struct {
+	CollectionId uint64    `json:"collection_id"`
+	UserId       uint64    `json:"user_id"`
+	LanguageId   uint64    `json:"language_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	TotalTerms   uint64    `json:"total_terms"`
+	Clones       uint32    `json:"-"` // the popularity, counted by the clone events and kept apart from the entry
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Store keeps the public collections in Redis shared by the instances, so every instance returns the same
+// ranking and the cursor of one instance works on another. The keys under the prefix:
+//   - entry:{collection id} - the entry
+//   - ids - the ids of all the entries
+//   - clones - the hash of the clones by the collection id
+//   - owner:{user id}, owners - the ids of the entries of the user and the users having them
+//   - token:{word} - the ids of the entries having the word in the name or the description
+//   - tokens - the words in the sorted set, for the prefix match of the last word of the query
+//
+// The words are not removed from the tokens, the word of no entry matches nothing.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func New(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+// Put adds or replaces the collection, the clones counted before are kept
+func (s *Store) Put(ctx context.Context, entry Entry) error {
+	value, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	tokens := entryTokens(&entry)
+
+	return s.update(ctx, entry.CollectionId, func(pipe redis.Pipeliner, old *Entry) {
+		id := formatId(entry.CollectionId)
+		if old != nil {
+			s.unindex(ctx, pipe, old)
+		}
+		pipe.Set(ctx, s.entryKey(entry.CollectionId), value, 0)
+		pipe.SAdd(ctx, s.prefix+"ids", id)
+		pipe.SAdd(ctx, s.ownerKey(entry.UserId), id)
+		pipe.SAdd(ctx, s.prefix+"owners", formatId(entry.UserId))
+		for _, token := range tokens {
+			pipe.SAdd(ctx, s.tokenKey(token), id)
+			pipe.ZAdd(ctx, s.prefix+"tokens", redis.Z{Member: token})
+		}
+	})
+}
+
+// PutOwner replaces all the collections of the user, the collections missing in entries are deleted
+func (s *Store) PutOwner(ctx context.Context, userId uint64, entries []Entry) error {
+	keep := make(map[uint64]struct{}, len(entries))
+	for _, entry := range entries {
+		keep[entry.CollectionId] = struct{}{}
+		if err := s.Put(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	ids, err := s.client.SMembers(ctx, s.ownerKey(userId)).Result()
+	if err != nil {
+		return err
+	}
+	for _, member := range ids {
+		id, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			return err
+		}
+		if _, ok := keep[id]; !ok {
+			if err = s.Delete(ctx, id); err != nil {
+				return err
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return s.client.SRem(ctx, s.prefix+"owners", formatId(userId)).Err()
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, collectionId uint64) error {
+	return s.update(ctx, collectionId, func(pipe redis.Pipeliner, old *Entry) {
+		if old == nil {
+			return
+		}
+		s.unindex(ctx, pipe, old)
+		pipe.Del(ctx, s.entryKey(collectionId))
+		pipe.HDel(ctx, s.prefix+"clones", formatId(collectionId))
+	})
+}
+
+// AddClone counts the clone of the collection, the clone of a collection out of the catalog is ignored
+func (s *Store) AddClone(ctx context.Context, collectionId uint64) error {
+	return s.update(ctx, collectionId, func(pipe redis.Pipeliner, old *Entry) {
+		if old != nil {
+			pipe.HIncrBy(ctx, s.prefix+"clones", formatId(collectionId), 1)
+		}
+	})
+}
+
+// Claim returns true only to the first caller of the event, every instance consumes all the events,
+// so the event changing the catalog is applied once. The event is the routing key and the body,
+// the body has the time of the event, so the redelivered event is claimed too.
+func (s *Store) Claim(ctx context.Context, routingKey string, body []byte, ttl time.Duration) (bool, error) {
+	sum := sha256.Sum256(body)
+	key := s.prefix + "events:" + routingKey + ":" + hex.EncodeToString(sum[:16])
+	return s.client.SetNX(ctx, key, 1, ttl).Result()
+}
+
+// LockSync returns true when the instance takes the sync for the ttl, the other instances skip it meanwhile.
+// The lock is not released, it expires, so the catalog is synced once per ttl by one of the instances.
+func (s *Store) LockSync(ctx context.Context, now time.Time, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+"sync:lock", now.Unix(), ttl).Result()
+}
+
+// Owners returns the users having the collections in the catalog
+func (s *Store) Owners(ctx context.Context) ([]uint64, error) {
+	members, err := s.client.SMembers(ctx, s.prefix+"owners").Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseIds(members)
+}
+
+func (s *Store) Len(ctx context.Context) (int64, error) {
+	return s.client.SCard(ctx, s.prefix+"ids").Result()
+}
+
+// update runs fn in the transaction watching the entry, fn gets the entry before the update, nil when it is missing
+func (s *Store) update(ctx context.Context, collectionId uint64, fn func(pipe redis.Pipeliner, old *Entry)) error {
+	key := s.entryKey(collectionId)
+	for attempt := 0; attempt < maxWatchAttempts; attempt++ {
+		err := s.client.Watch(ctx, func(tx *redis.Tx) error {
+			old, err := getEntry(ctx, tx, key)
+			if err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				fn(pipe, old)
+				return nil
+			})
+			return err
+		}, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return redis.TxFailedErr
+}
+
+// unindex removes the entry from the ids, the owner and the words, the entry itself is kept
+func (s *Store) unindex(ctx context.Context, pipe redis.Pipeliner, entry *Entry) {
+	id := formatId(entry.CollectionId)
+	pipe.SRem(ctx, s.prefix+"ids", id)
+	pipe.SRem(ctx, s.ownerKey(entry.UserId), id)
+	for _, token := range entryTokens(entry) {
+		pipe.SRem(ctx, s.tokenKey(token), id)
+	}
+}
+
+func (s *Store) entryKey(collectionId uint64) string {
+	return s.prefix + "entry:" + formatId(collectionId)
+}
+
+func (s *Store) ownerKey(userId uint64) string {
+	return s.prefix + "owner:" + formatId(userId)
+}
+
+func (s *Store) tokenKey(token string) string {
+	return s.prefix + "token:" + token
+}
+
+func getEntry(ctx context.Context, client redis.Cmdable, key string) (*Entry, error) {
+	value, err := client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry := new(Entry)
+	if err = json.Unmarshal(value, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func formatId(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+func parseIds(members []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func entryTokens(entry *Entry) []string {
+	return tokenize(entry.Name + " " + entry.Description)
+}
+
+// tokenize splits the text into the lower case words without the accents. The compatibility forms
+// are decomposed too (NFKD), so e.g. "Café" matches "cafe" and the full width letters match the usual ones.
+func tokenize(text string) []string {
+	var folded strings.Builder
+	for _, r := range norm.NFKD.String(text) {
+		if !unicode.Is(unicode.Mn, r) {
+			folded.WriteRune(unicode.ToLower(r))
+		}
+	}
+	words := strings.FieldsFunc(folded.String(), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]struct{}, len(words))
+	tokens := words[:0]
+	for _, word := range words {
+		if _, ok := seen[word]; !ok {
+			seen[word] = struct{}{}
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
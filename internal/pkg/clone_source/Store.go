@@ -0,0 +1,64 @@
+package clone_source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Source is the collection the clone is copied from, it is kept for the attribution
+type Source struct {
+	CollectionId       uint64    `json:"collection_id"` // the clone
+	SourceCollectionId uint64    `json:"source_collection_id"`
+	SourceUserId       uint64    `json:"source_user_id"`
+	ClonedAt           time.Time `json:"cloned_at"`
+}
+
+// Store keeps the sources of the clones in Redis shared by the instances, vocabulary_api has no field for them.
+// The source is the key {prefix}{clone collection id}, it is deleted when the clone is purged.
+// The source stays after the source collection is deleted, the attribution does not depend on it.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func New(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+func (s *Store) Put(ctx context.Context, source *Source) error {
+	value, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.key(source.CollectionId), value, 0).Err()
+}
+
+// Get returns nil when the collection is not a clone
+func (s *Store) Get(ctx context.Context, collectionId uint64) (*Source, error) {
+	value, err := s.client.Get(ctx, s.key(collectionId)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	source := new(Source)
+	if err = json.Unmarshal(value, source); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func (s *Store) Delete(ctx context.Context, collectionId uint64) error {
+	return s.client.Del(ctx, s.key(collectionId)).Err()
+}
+
+func (s *Store) key(collectionId uint64) string {
+	return s.prefix + strconv.FormatUint(collectionId, 10)
+}
@@ -0,0 +1,50 @@
+package clone_source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	store := New(client, "test:")
+
+	source := &Source{CollectionId: 20, SourceCollectionId: 10, SourceUserId: 1, ClonedAt: time.Unix(1_700_000_000, 0).UTC()}
+	if err := store.Put(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		collectionId uint64
+		want         *Source
+	}{
+		{name: "clone", collectionId: 20, want: source},
+		{name: "not a clone", collectionId: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Get(ctx, tt.collectionId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+				t.Fatalf("Get() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if err := store.Delete(ctx, 20); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Get(ctx, 20); err != nil || got != nil {
+		t.Fatalf("Get() after Delete() = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,371 @@
+package collaborators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Roles of the collaborators, the owner of the collection is not a collaborator
+const (
+	RoleViewer = "viewer" // reads the collection even if it is private
+	RoleEditor = "editor" // creates, updates and deletes the terms
+)
+
+// expiredInviteRetention the expired invite is kept for a while, so Accept tells it is expired instead of not found
+const expiredInviteRetention = 24 * time.Hour
+
+var (
+	ErrInviteNotFound = errors.New("invite not found")
+	ErrInviteExpired  = errors.New("invite expired")
+	ErrMemberNotFound = errors.New("collaborator not found")
+)
+
+func IsRole(role string) bool {
+	return role == RoleViewer || role == RoleEditor
+}
+
+type Member struct {
+	CollectionId uint64    `json:"collection_id"`
+	UserId       uint64    `json:"user_id"`
+	Role         string    `json:"role"`
+	InvitedBy    uint64    `json:"invited_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Invite is the pending invite of the user, it becomes the Member when it is accepted
+type Invite struct {
+	InviteId     string    `json:"invite_id"`
+	CollectionId uint64    `json:"collection_id"`
+	UserId       uint64    `json:"user_id"`
+	Email        string    `json:"email"`
+	Role         string    `json:"role"`
+	InvitedBy    uint64    `json:"invited_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Store keeps the collaborators and the invites in Redis, so every instance sees the same roles:
+//
+//	{prefix}invite:{invite id}      - the Invite, it is deleted by Redis after the retention
+//	{prefix}collection:{id}:invites - the set of the invite ids of the collection
+//	{prefix}user:{id}:invites       - the set of the invite ids of the invited user
+//	{prefix}collection:{id}:members - the hash of the user id -> Member
+//	{prefix}user:{id}:roles         - the hash of the collection id -> role
+//
+// The ids of the invites deleted by Redis are removed from the sets when the sets are read.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func New(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+// PutInvite stores the invite, the previous pending invite of the user to the collection is replaced
+func (s *Store) PutInvite(ctx context.Context, invite *Invite) error {
+	if invite.InviteId == "" {
+		invite.InviteId = uuid.NewString()
+	}
+	value, err := json.Marshal(invite)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.Invites(ctx, invite.CollectionId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, other := range previous {
+			if other.UserId == invite.UserId {
+				s.deleteInvite(ctx, pipe, other)
+			}
+		}
+		pipe.Set(ctx, s.inviteKey(invite.InviteId), value, time.Until(invite.ExpiresAt.Add(expiredInviteRetention)))
+		pipe.SAdd(ctx, s.collectionInvitesKey(invite.CollectionId), invite.InviteId)
+		pipe.SAdd(ctx, s.userInvitesKey(invite.UserId), invite.InviteId)
+		return nil
+	})
+	return err
+}
+
+func (s *Store) GetInvite(ctx context.Context, inviteId string) (*Invite, error) {
+	return s.getInvite(ctx, s.client, inviteId)
+}
+
+// Invites returns the pending invites to the collection, the expired ones are included
+func (s *Store) Invites(ctx context.Context, collectionId uint64) ([]*Invite, error) {
+	return s.invites(ctx, s.collectionInvitesKey(collectionId))
+}
+
+// UserInvites returns the pending invites of the user, the expired ones are included
+func (s *Store) UserInvites(ctx context.Context, userId uint64) ([]*Invite, error) {
+	return s.invites(ctx, s.userInvitesKey(userId))
+}
+
+func (s *Store) DeleteInvite(ctx context.Context, inviteId string) error {
+	invite, err := s.GetInvite(ctx, inviteId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		s.deleteInvite(ctx, pipe, invite)
+		return nil
+	})
+	return err
+}
+
+// Accept makes the invited user the collaborator with the role of the invite and deletes the invite.
+// The invite of another user is not found, the expired invite is deleted. The invite accepted
+// or replaced concurrently is not found either.
+func (s *Store) Accept(ctx context.Context, inviteId string, userId uint64, now time.Time) (*Member, error) {
+	var member *Member
+	err := s.client.Watch(ctx, func(tx *redis.Tx) error {
+		invite, err := s.getInvite(ctx, tx, inviteId)
+		if err != nil {
+			return err
+		}
+		if invite.UserId != userId {
+			return ErrInviteNotFound
+		}
+
+		if now.After(invite.ExpiresAt) {
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				s.deleteInvite(ctx, pipe, invite)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return ErrInviteExpired
+		}
+
+		member = &Member{
+			CollectionId: invite.CollectionId,
+			UserId:       invite.UserId,
+			Role:         invite.Role,
+			InvitedBy:    invite.InvitedBy,
+			CreatedAt:    now,
+		}
+		value, err := json.Marshal(member)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			s.deleteInvite(ctx, pipe, invite)
+			pipe.HSet(ctx, s.membersKey(member.CollectionId), formatId(member.UserId), value)
+			pipe.HSet(ctx, s.rolesKey(member.UserId), formatId(member.CollectionId), member.Role)
+			return nil
+		})
+		return err
+	}, s.inviteKey(inviteId))
+	if errors.Is(err, redis.TxFailedErr) {
+		return nil, ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Role returns the role of the user in the collection, empty if the user is not the collaborator
+func (s *Store) Role(ctx context.Context, collectionId uint64, userId uint64) (string, error) {
+	role, err := s.client.HGet(ctx, s.rolesKey(userId), formatId(collectionId)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return role, err
+}
+
+// Members returns the collaborators of the collection ordered by the user id
+func (s *Store) Members(ctx context.Context, collectionId uint64) ([]*Member, error) {
+	values, err := s.client.HVals(ctx, s.membersKey(collectionId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*Member, 0, len(values))
+	for _, value := range values {
+		member := new(Member)
+		if err = json.Unmarshal([]byte(value), member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UserId < members[j].UserId
+	})
+	return members, nil
+}
+
+// Collections returns the ids of the collections, where the user has the role
+func (s *Store) Collections(ctx context.Context, userId uint64, role string) ([]uint64, error) {
+	roles, err := s.client.HGetAll(ctx, s.rolesKey(userId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var collectionIds []uint64
+	for field, value := range roles {
+		if value != role {
+			continue
+		}
+		collectionId, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		collectionIds = append(collectionIds, collectionId)
+	}
+	sort.Slice(collectionIds, func(i, j int) bool {
+		return collectionIds[i] < collectionIds[j]
+	})
+	return collectionIds, nil
+}
+
+func (s *Store) DeleteMember(ctx context.Context, collectionId uint64, userId uint64) error {
+	var deleted *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.HDel(ctx, s.membersKey(collectionId), formatId(userId))
+		pipe.HDel(ctx, s.rolesKey(userId), formatId(collectionId))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if deleted.Val() == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// DeleteCollection removes the collaborators and the invites of the deleted collection
+func (s *Store) DeleteCollection(ctx context.Context, collectionId uint64) error {
+	userIds, err := s.client.HKeys(ctx, s.membersKey(collectionId)).Result()
+	if err != nil {
+		return err
+	}
+	invites, err := s.Invites(ctx, collectionId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, userId := range userIds {
+			memberId, err := strconv.ParseUint(userId, 10, 64)
+			if err != nil {
+				return err
+			}
+			pipe.HDel(ctx, s.rolesKey(memberId), formatId(collectionId))
+		}
+		pipe.Del(ctx, s.membersKey(collectionId))
+		for _, invite := range invites {
+			s.deleteInvite(ctx, pipe, invite)
+		}
+		pipe.Del(ctx, s.collectionInvitesKey(collectionId))
+		return nil
+	})
+	return err
+}
+
+func (s *Store) getInvite(ctx context.Context, client redis.Cmdable, inviteId string) (*Invite, error) {
+	value, err := client.Get(ctx, s.inviteKey(inviteId)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	invite := new(Invite)
+	if err = json.Unmarshal(value, invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// invites loads the invites of the set ordered by the creation, the ids of the deleted invites are removed from the set
+func (s *Store) invites(ctx context.Context, setKey string) ([]*Invite, error) {
+	inviteIds, err := s.client.SMembers(ctx, setKey).Result()
+	if err != nil || len(inviteIds) == 0 {
+		return make([]*Invite, 0), err
+	}
+
+	keys := make([]string, len(inviteIds))
+	for i, inviteId := range inviteIds {
+		keys[i] = s.inviteKey(inviteId)
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	invites := make([]*Invite, 0, len(values))
+	var deleted []any
+	for i, value := range values {
+		encoded, ok := value.(string)
+		if !ok {
+			deleted = append(deleted, inviteIds[i])
+			continue
+		}
+		invite := new(Invite)
+		if err = json.Unmarshal([]byte(encoded), invite); err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+	if len(deleted) > 0 {
+		if err = s.client.SRem(ctx, setKey, deleted...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(invites, func(i, j int) bool {
+		if !invites[i].CreatedAt.Equal(invites[j].CreatedAt) {
+			return invites[i].CreatedAt.Before(invites[j].CreatedAt)
+		}
+		return invites[i].InviteId < invites[j].InviteId
+	})
+	return invites, nil
+}
+
+func (s *Store) deleteInvite(ctx context.Context, pipe redis.Pipeliner, invite *Invite) {
+	pipe.Del(ctx, s.inviteKey(invite.InviteId))
+	pipe.SRem(ctx, s.collectionInvitesKey(invite.CollectionId), invite.InviteId)
+	pipe.SRem(ctx, s.userInvitesKey(invite.UserId), invite.InviteId)
+}
+
+func (s *Store) inviteKey(inviteId string) string {
+	return s.prefix + "invite:" + inviteId
+}
+
+func (s *Store) collectionInvitesKey(collectionId uint64) string {
+	return fmt.Sprintf("%scollection:%d:invites", s.prefix, collectionId)
+}
+
+func (s *Store) userInvitesKey(userId uint64) string {
+	return fmt.Sprintf("%suser:%d:invites", s.prefix, userId)
+}
+
+func (s *Store) membersKey(collectionId uint64) string {
+	return fmt.Sprintf("%scollection:%d:members", s.prefix, collectionId)
+}
+
+func (s *Store) rolesKey(userId uint64) string {
+	return fmt.Sprintf("%suser:%d:roles", s.prefix, userId)
+}
+
+func formatId(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
@@ -0,0 +1,189 @@
+package collaborators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestStore(t *testing.T) (*Store, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return New(client, "test:"), server
+}
+
+func newInvite(collectionId, userId uint64, role string, now time.Time) *Invite {
+	return &Invite{
+		CollectionId: collectionId,
+		UserId:       userId,
+		Role:         role,
+		InvitedBy:    1,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(time.Hour),
+	}
+}
+
+func TestStoreAccept(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		userId  uint64
+		at      time.Time
+		wantErr error
+		role    string
+	}{
+		{name: "invited user", userId: 2, at: now, role: RoleEditor},
+		{name: "another user", userId: 3, at: now, wantErr: ErrInviteNotFound},
+		{name: "expired", userId: 2, at: now.Add(2 * time.Hour), wantErr: ErrInviteExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _ := newTestStore(t)
+			invite := newInvite(10, 2, RoleEditor, now)
+			if err := store.PutInvite(ctx, invite); err != nil {
+				t.Fatal(err)
+			}
+
+			member, err := store.Accept(ctx, invite.InviteId, tt.userId, tt.at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Accept() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if member.Role != tt.role || member.CollectionId != 10 {
+				t.Fatalf("Accept() = %+v", member)
+			}
+
+			role, err := store.Role(ctx, 10, tt.userId)
+			if err != nil || role != tt.role {
+				t.Fatalf("Role() = %q, %v, want %q", role, err, tt.role)
+			}
+			if _, err = store.GetInvite(ctx, invite.InviteId); !errors.Is(err, ErrInviteNotFound) {
+				t.Fatalf("GetInvite() of the accepted invite error = %v", err)
+			}
+			if _, err = store.Accept(ctx, invite.InviteId, tt.userId, tt.at); !errors.Is(err, ErrInviteNotFound) {
+				t.Fatalf("second Accept() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStorePutInviteReplaces(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+	now := time.Now()
+
+	first := newInvite(10, 2, RoleViewer, now)
+	second := newInvite(10, 2, RoleEditor, now.Add(time.Second))
+	other := newInvite(10, 3, RoleViewer, now)
+	for _, invite := range []*Invite{first, other, second} {
+		if err := store.PutInvite(ctx, invite); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	invites, err := store.Invites(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invites) != 2 || invites[0].InviteId != other.InviteId && invites[1].InviteId != other.InviteId {
+		t.Fatalf("Invites() = %+v", invites)
+	}
+	userInvites, err := store.UserInvites(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(userInvites) != 1 || userInvites[0].InviteId != second.InviteId {
+		t.Fatalf("UserInvites() = %+v, want the second invite", userInvites)
+	}
+	if _, err = store.GetInvite(ctx, first.InviteId); !errors.Is(err, ErrInviteNotFound) {
+		t.Fatalf("GetInvite() of the replaced invite error = %v", err)
+	}
+}
+
+func TestStoreInvitesDroppedByRedis(t *testing.T) {
+	ctx := context.Background()
+	store, server := newTestStore(t)
+	now := time.Now()
+
+	invite := newInvite(10, 2, RoleViewer, now)
+	if err := store.PutInvite(ctx, invite); err != nil {
+		t.Fatal(err)
+	}
+	server.FastForward(time.Hour + expiredInviteRetention + time.Minute)
+
+	for name, list := range map[string]func() ([]*Invite, error){
+		"Invites":     func() ([]*Invite, error) { return store.Invites(ctx, 10) },
+		"UserInvites": func() ([]*Invite, error) { return store.UserInvites(ctx, 2) },
+	} {
+		invites, err := list()
+		if err != nil || len(invites) != 0 {
+			t.Fatalf("%s() = %+v, %v, want none", name, invites, err)
+		}
+	}
+	if server.Exists("test:collection:10:invites") || server.Exists("test:user:2:invites") {
+		t.Fatal("the id of the dropped invite is left in the sets")
+	}
+}
+
+func TestStoreMembers(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+	now := time.Now()
+
+	for _, invite := range []*Invite{
+		newInvite(10, 3, RoleViewer, now),
+		newInvite(10, 2, RoleEditor, now),
+		newInvite(11, 2, RoleEditor, now),
+		newInvite(12, 2, RoleViewer, now),
+	} {
+		if err := store.PutInvite(ctx, invite); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Accept(ctx, invite.InviteId, invite.UserId, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	members, err := store.Members(ctx, 10)
+	if err != nil || len(members) != 2 || members[0].UserId != 2 || members[1].UserId != 3 {
+		t.Fatalf("Members() = %+v, %v", members, err)
+	}
+	collectionIds, err := store.Collections(ctx, 2, RoleEditor)
+	if err != nil || len(collectionIds) != 2 || collectionIds[0] != 10 || collectionIds[1] != 11 {
+		t.Fatalf("Collections() = %v, %v, want [10 11]", collectionIds, err)
+	}
+
+	if err = store.DeleteMember(ctx, 10, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.DeleteMember(ctx, 10, 3); !errors.Is(err, ErrMemberNotFound) {
+		t.Fatalf("second DeleteMember() error = %v", err)
+	}
+
+	pending := newInvite(10, 4, RoleViewer, now)
+	if err = store.PutInvite(ctx, pending); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.DeleteCollection(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	if role, _ := store.Role(ctx, 10, 2); role != "" {
+		t.Fatalf("Role() after DeleteCollection = %q", role)
+	}
+	if role, _ := store.Role(ctx, 11, 2); role != RoleEditor {
+		t.Fatalf("Role() of another collection = %q", role)
+	}
+	if invites, _ := store.UserInvites(ctx, 4); len(invites) != 0 {
+		t.Fatalf("UserInvites() after DeleteCollection = %+v", invites)
+	}
+}
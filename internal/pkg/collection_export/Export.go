@@ -0,0 +1,158 @@
+package collection_export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/anki"
+)
+
+// Formats of the export
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatAnki = "apkg"
+)
+
+type Collection struct {
+	CollectionId uint64 `json:"collection_id,string"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+}
+
+type Term struct {
+	TermId       uint64 `json:"term_id,string"`
+	Term         string `json:"term"`
+	Meaning      string `json:"meaning"`
+	Example      string `json:"example"`
+	ImageUrl     string `json:"image_url"`
+	Status       string `json:"status"`
+	VoiceoverUrl string `json:"voiceover_url"`
+}
+
+func IsFormat(format string) bool {
+	switch format {
+	case FormatCSV, FormatJSON, FormatAnki:
+		return true
+	}
+	return false
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSON:
+		return "application/json"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FileName is the name of the collection without the characters, which are not allowed in the file names
+func FileName(collection *Collection, format string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(collection.Name))
+	if name == "" {
+		name = strconv.FormatUint(collection.CollectionId, 10)
+	}
+	return name + "." + format
+}
+
+// Write writes the terms in the format. CSV and JSON are written term by term,
+// the Anki package is built in memory first: its database is written at once.
+func Write(w io.Writer, format string, collection *Collection, terms []*Term) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, terms)
+	case FormatJSON:
+		return writeJSON(w, collection, terms)
+	case FormatAnki:
+		return writeAnki(w, collection, terms)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// writeCSV uses the header names, which are recognized by the import
+func writeCSV(w io.Writer, terms []*Term) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"term", "meaning", "example", "image_url", "status", "voiceover_url"}); err != nil {
+		return err
+	}
+	for _, term := range terms {
+		err := writer.Write([]string{term.Term, term.Meaning, term.Example, term.ImageUrl, term.Status, term.VoiceoverUrl})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeJSON(w io.Writer, collection *Collection, terms []*Term) error {
+	buffered := bufio.NewWriter(w)
+
+	header, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+	buffered.WriteString(`{"collection":`)
+	buffered.Write(header)
+	buffered.WriteString(`,"terms":[`)
+
+	for i, term := range terms {
+		buf, err := json.Marshal(term)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buffered.WriteByte(',')
+		}
+		if _, err = buffered.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	buffered.WriteString("]}")
+	return buffered.Flush()
+}
+
+func writeAnki(w io.Writer, collection *Collection, terms []*Term) error {
+	deck := &anki.Deck{
+		Name:        collection.Name,
+		Description: collection.Description,
+		Fields:      []string{"Term", "Meaning", "Example", "Status", "Voiceover"},
+		Front:       "{{Term}}",
+		Back: `{{FrontSide}}<hr id="answer">{{Meaning}}` +
+			`{{#Example}}<br><i>{{Example}}</i>{{/Example}}` +
+			`{{#Voiceover}}<br><audio controls src="{{Voiceover}}"></audio>{{/Voiceover}}`,
+		CSS:   ".card { font-family: arial; font-size: 20px; text-align: center; color: black; background-color: white; }",
+		Notes: make([]*anki.Note, len(terms)),
+	}
+	for i, term := range terms {
+		deck.Notes[i] = &anki.Note{
+			// The same term is updated by the next import of the collection
+			Guid: "wbw" + strconv.FormatUint(term.TermId, 36),
+			Fields: []string{
+				html.EscapeString(term.Term),
+				html.EscapeString(term.Meaning),
+				html.EscapeString(term.Example),
+				html.EscapeString(term.Status),
+				html.EscapeString(term.VoiceoverUrl),
+			},
+		}
+	}
+	return anki.WritePackage(w, deck, time.Now())
+}
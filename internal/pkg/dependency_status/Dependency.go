@@ -0,0 +1,141 @@
+package dependency_status
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	StateUnknown = "unknown"
+	StateUp      = "up"
+	StateDown    = "down"
+)
+
+type CheckerFunc func(ctx context.Context) error
+
+type PoolStatsFunc func() (available int, capacity int)
+
+// Dependency keeps the last connection check result and the recent call outcomes of one downstream client
+type Dependency struct {
+	name    string
+	uri     string
+	checker CheckerFunc
+
+	mu               sync.RWMutex
+	poolStats        PoolStatsFunc
+	lastCheckAt      time.Time
+	lastCheckLatency time.Duration
+	lastCheckErr     error
+	window           *window
+}
+
+func newDependency(name string, uri string, checker CheckerFunc) *Dependency {
+	return &Dependency{
+		name:    name,
+		uri:     RedactURI(uri),
+		checker: checker,
+		window:  newWindow(),
+	}
+}
+
+func (d *Dependency) Name() string {
+	return d.name
+}
+
+// WithPoolStats attaches the connection pool of the client, so the pool usage is shown in the status
+func (d *Dependency) WithPoolStats(poolStats PoolStatsFunc) *Dependency {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.poolStats = poolStats
+	return d
+}
+
+// Check runs the connection check and remembers its latency and result
+func (d *Dependency) Check(ctx context.Context) error {
+	start := time.Now()
+	err := d.checker(ctx)
+	latency := time.Since(start)
+
+	d.mu.Lock()
+	d.lastCheckAt = start
+	d.lastCheckLatency = latency
+	d.lastCheckErr = err
+	d.mu.Unlock()
+
+	return err
+}
+
+// Observe records the outcome of a call made to the dependency
+func (d *Dependency) Observe(err error) {
+	d.window.observe(time.Now(), isDependencyError(err))
+}
+
+// UnaryClientInterceptor records the outcome of every gRPC call made through the client connection
+func (d *Dependency) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		d.Observe(err)
+		return err
+	}
+}
+
+func (d *Dependency) Snapshot() DependencyStatus {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	calls, errs := d.window.totals(time.Now())
+	resp := DependencyStatus{
+		Name:   d.name,
+		URI:    d.uri,
+		State:  StateUnknown,
+		Window: windowSize.String(),
+		Calls:  calls,
+		Errors: errs,
+	}
+	if calls > 0 {
+		resp.ErrorRate = float64(errs) / float64(calls)
+	}
+
+	if !d.lastCheckAt.IsZero() {
+		lastCheckAt := d.lastCheckAt
+		resp.LastCheckAt = &lastCheckAt
+		resp.LastCheckLatencyMs = float64(d.lastCheckLatency.Microseconds()) / 1000
+		resp.State = StateUp
+		if d.lastCheckErr != nil {
+			resp.State = StateDown
+			resp.LastCheckError = d.lastCheckErr.Error()
+		}
+	}
+
+	if d.poolStats != nil {
+		available, capacity := d.poolStats()
+		resp.Pool = &PoolStatus{
+			Available: available,
+			Capacity:  capacity,
+		}
+	}
+
+	return resp
+}
+
+// isDependencyError separates infrastructure failures from business errors (NotFound, AlreadyExists and so on),
+// which are regular answers of a healthy dependency
+func isDependencyError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errGrpc, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	switch errGrpc.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.ResourceExhausted, codes.DataLoss:
+		return true
+	}
+	return false
+}
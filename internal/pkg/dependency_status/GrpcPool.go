@@ -0,0 +1,11 @@
+package dependency_status
+
+import grpcpool "github.com/processout/grpc-go-pool"
+
+// GrpcPoolStats reports the pool of github.com/processout/grpc-go-pool, the clients keep it wrapped in _grpc.Pool of pkg,
+// so the stats do not depend on the wrapper
+func GrpcPoolStats(pool *grpcpool.Pool) PoolStatsFunc {
+	return func() (int, int) {
+		return pool.Available(), pool.Capacity()
+	}
+}
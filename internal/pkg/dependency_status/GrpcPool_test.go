@@ -0,0 +1,41 @@
+package dependency_status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grpcpool "github.com/processout/grpc-go-pool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestGrpcPoolStats(t *testing.T) {
+	// the connection is not dialed until the first call
+	factory := func() (*grpc.ClientConn, error) {
+		return grpc.NewClient("passthrough:///localhost:0", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	pool, err := grpcpool.New(factory, 2, 3, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	stats := GrpcPoolStats(pool)
+
+	if available, capacity := stats(); available != 3 || capacity != 3 {
+		t.Fatalf("stats() = %d, %d, want 3, 3", available, capacity)
+	}
+	conn, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if available, _ := stats(); available != 2 {
+		t.Fatalf("stats() available = %d with the taken connection, want 2", available)
+	}
+	if err = conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if available, _ := stats(); available != 3 {
+		t.Fatalf("stats() available = %d after the return, want 3", available)
+	}
+}
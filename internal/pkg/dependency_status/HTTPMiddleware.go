@@ -0,0 +1,14 @@
+package dependency_status
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func HttpMiddleware(f *InFlight) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f.Inc()
+		defer f.Dec()
+
+		c.Next()
+	}
+}
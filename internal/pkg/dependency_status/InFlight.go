@@ -0,0 +1,49 @@
+package dependency_status
+
+import (
+	"sync/atomic"
+)
+
+// InFlight counts the HTTP requests which are being processed right now
+type InFlight struct {
+	current  atomic.Int64
+	peak     atomic.Int64
+	capacity int64
+}
+
+func NewInFlight(capacity int) *InFlight {
+	return &InFlight{
+		capacity: int64(capacity),
+	}
+}
+
+func (f *InFlight) Inc() {
+	current := f.current.Add(1)
+	for {
+		peak := f.peak.Load()
+		if current <= peak || f.peak.CompareAndSwap(peak, current) {
+			return
+		}
+	}
+}
+
+func (f *InFlight) Dec() {
+	f.current.Add(-1)
+}
+
+// Saturation returns the share of the capacity in use, 1 means the gateway is full
+func (f *InFlight) Saturation() float64 {
+	if f.capacity <= 0 {
+		return 0
+	}
+	return float64(f.current.Load()) / float64(f.capacity)
+}
+
+func (f *InFlight) Snapshot() InFlightStatus {
+	return InFlightStatus{
+		Current:    f.current.Load(),
+		Peak:       f.peak.Load(),
+		Capacity:   f.capacity,
+		Saturation: f.Saturation(),
+	}
+}
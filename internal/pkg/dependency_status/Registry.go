@@ -0,0 +1,141 @@
+package dependency_status
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/constants"
+)
+
+type Registry struct {
+	cfg       *config.Config
+	startedAt time.Time
+	inFlight  *InFlight
+
+	mu           sync.RWMutex
+	dependencies []*Dependency
+}
+
+func NewRegistry(cfg *config.Config) *Registry {
+	return &Registry{
+		cfg:       cfg,
+		startedAt: time.Now(),
+		inFlight:  NewInFlight(cfg.HealthCheck.MaxInFlightRequests),
+	}
+}
+
+// Register adds a downstream client to the status page, checker is used to check its connection
+func (r *Registry) Register(name string, uri string, checker CheckerFunc) *Dependency {
+	dependency := newDependency(name, uri, checker)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dependencies = append(r.dependencies, dependency)
+
+	return dependency
+}
+
+func (r *Registry) Dependencies() []*Dependency {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dependencies := make([]*Dependency, len(r.dependencies))
+	copy(dependencies, r.dependencies)
+	return dependencies
+}
+
+func (r *Registry) InFlight() *InFlight {
+	return r.inFlight
+}
+
+// Status checks all the dependencies concurrently and returns the current state of the gateway
+func (r *Registry) Status(ctx context.Context) Status {
+	dependencies := r.Dependencies()
+
+	var wg sync.WaitGroup
+	wg.Add(len(dependencies))
+	for _, dependency := range dependencies {
+		go func(dependency *Dependency) {
+			defer wg.Done()
+			_ = dependency.Check(ctx)
+		}(dependency)
+	}
+	wg.Wait()
+
+	resp := Status{
+		Status:       StatusOk,
+		App:          constants.AppName,
+		Version:      r.cfg.Version,
+		Environment:  r.cfg.Environment,
+		Build:        buildInfo(),
+		StartedAt:    r.startedAt,
+		Uptime:       time.Since(r.startedAt).Round(time.Second).String(),
+		InFlight:     r.inFlight.Snapshot(),
+		Dependencies: make([]DependencyStatus, len(dependencies)),
+	}
+
+	for i, dependency := range dependencies {
+		resp.Dependencies[i] = dependency.Snapshot()
+		if resp.Dependencies[i].State == StateDown {
+			resp.Status = StatusDegraded
+		}
+	}
+	if resp.InFlight.Saturation*100 >= float64(r.cfg.HealthCheck.SaturationThreshold) {
+		resp.Status = StatusDegraded
+	}
+
+	return resp
+}
+
+func buildInfo() BuildInfo {
+	resp := BuildInfo{
+		GoVersion: runtime.Version(),
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return resp
+	}
+
+	resp.Path = info.Main.Path
+	resp.ModuleVersion = info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			resp.Revision = setting.Value
+		case "vcs.time":
+			resp.RevisionTime = setting.Value
+		case "vcs.modified":
+			resp.Modified = setting.Value == "true"
+		}
+	}
+
+	return resp
+}
+
+// RedactURI hides credentials and query params of the URI, plain host:port addresses are returned as is
+func RedactURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return uri
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.Redacted()
+}
+
+// TCPDialCheck dials the address, the dial is canceled with the context or after the timeout
+func TCPDialCheck(ctx context.Context, addr string, timeout time.Duration) error {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
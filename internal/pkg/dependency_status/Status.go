@@ -0,0 +1,55 @@
+package dependency_status
+
+import "time"
+
+const (
+	StatusOk       = "ok"
+	StatusDegraded = "degraded"
+)
+
+type Status struct {
+	Status       string             `json:"status"`
+	App          string             `json:"app"`
+	Version      string             `json:"version"`
+	Environment  string             `json:"environment"`
+	Build        BuildInfo          `json:"build"`
+	StartedAt    time.Time          `json:"started_at"`
+	Uptime       string             `json:"uptime"`
+	InFlight     InFlightStatus     `json:"in_flight"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+type BuildInfo struct {
+	GoVersion     string `json:"go_version"`
+	Path          string `json:"path,omitempty"`
+	ModuleVersion string `json:"module_version,omitempty"`
+	Revision      string `json:"revision,omitempty"`
+	RevisionTime  string `json:"revision_time,omitempty"`
+	Modified      bool   `json:"modified"`
+}
+
+type InFlightStatus struct {
+	Current    int64   `json:"current"`
+	Peak       int64   `json:"peak"`
+	Capacity   int64   `json:"capacity"`
+	Saturation float64 `json:"saturation"`
+}
+
+type DependencyStatus struct {
+	Name               string      `json:"name"`
+	URI                string      `json:"uri"`
+	State              string      `json:"state"`
+	LastCheckAt        *time.Time  `json:"last_check_at,omitempty"`
+	LastCheckLatencyMs float64     `json:"last_check_latency_ms"`
+	LastCheckError     string      `json:"last_check_error,omitempty"`
+	Window             string      `json:"window"`
+	Calls              uint64      `json:"calls"`
+	Errors             uint64      `json:"errors"`
+	ErrorRate          float64     `json:"error_rate"`
+	Pool               *PoolStatus `json:"pool,omitempty"`
+}
+
+type PoolStatus struct {
+	Available int `json:"available"`
+	Capacity  int `json:"capacity"`
+}
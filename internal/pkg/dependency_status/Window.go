@@ -0,0 +1,59 @@
+package dependency_status
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	bucketSize   = 10 * time.Second
+	bucketsCount = 30
+	windowSize   = bucketSize * bucketsCount
+)
+
+type bucket struct {
+	index  int64
+	calls  uint64
+	errors uint64
+}
+
+// window counts calls and errors for the last windowSize, split into buckets of bucketSize
+type window struct {
+	mu      sync.Mutex
+	buckets [bucketsCount]bucket
+}
+
+func newWindow() *window {
+	return &window{}
+}
+
+func (w *window) observe(now time.Time, failed bool) {
+	index := now.UnixNano() / int64(bucketSize)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	b := &w.buckets[index%bucketsCount]
+	if b.index != index {
+		*b = bucket{index: index}
+	}
+	b.calls++
+	if failed {
+		b.errors++
+	}
+}
+
+func (w *window) totals(now time.Time) (calls uint64, errors uint64) {
+	index := now.UnixNano() / int64(bucketSize)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, b := range w.buckets {
+		if b.index > index-bucketsCount {
+			calls += b.calls
+			errors += b.errors
+		}
+	}
+	return calls, errors
+}
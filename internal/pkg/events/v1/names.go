@@ -0,0 +1,17 @@
+package events
+
+// Names of the events, they are used as the routing keys
+const (
+	UserSignedUpName        = "user.signed_up"
+	UserSignedInName        = "user.signed_in"
+	CollectionCreatedName   = "collection.created"
+	CollectionDeletedName   = "collection.deleted"
+	CollectionClonedName    = "collection.cloned"
+	CollectionUpdatedName   = "collection.updated"
+	TermsCreatedName        = "terms.created"
+	TermStatusChangedName   = "term.status_changed"
+	CollaboratorInvitedName = "collaborator.invited"
+)
+
+// Version is sent in the event_version header, so the consumers can pick the schema
+const Version = "v1"
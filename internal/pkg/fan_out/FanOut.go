@@ -0,0 +1,54 @@
+package fan_out
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// BranchError tells which branch of the fan-out failed
+type BranchError struct {
+	Branch string
+	Err    error
+}
+
+func (e *BranchError) Error() string {
+	return fmt.Sprintf("branch %s failed: %s", e.Branch, e.Err)
+}
+
+func (e *BranchError) Unwrap() error {
+	return e.Err
+}
+
+// Group runs independent calls concurrently. The first failed branch cancels the context of the others,
+// Wait returns its error as *BranchError.
+type Group struct {
+	group *errgroup.Group
+	ctx   context.Context
+}
+
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	group, groupCtx := errgroup.WithContext(ctx)
+	return &Group{group: group, ctx: groupCtx}, groupCtx
+}
+
+// Go starts the branch, name is used in the error
+func (g *Group) Go(name string, f func(ctx context.Context) error) {
+	g.group.Go(func() error {
+		if err := f(g.ctx); err != nil {
+			return &BranchError{Branch: name, Err: err}
+		}
+		return nil
+	})
+}
+
+// SetLimit limits the number of the branches running at once, Go blocks while the limit is reached
+func (g *Group) SetLimit(n int) {
+	g.group.SetLimit(n)
+}
+
+// Wait waits for all the branches and returns the first error
+func (g *Group) Wait() error {
+	return g.group.Wait()
+}
@@ -0,0 +1,114 @@
+package lru_cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+type item[V any] struct {
+	Key   string
+	Value V
+}
+
+// Cache keeps up to size values, the least recently used value is evicted first
+type Cache[V any] struct {
+	size int
+
+	mu         sync.Mutex
+	items      map[string]*list.Element
+	order      *list.List // front is the most recently used
+	generation uint64     // is changed by Remove and Purge, see AddIfUnchanged
+}
+
+func NewCache[V any](size int) *Cache[V] {
+	return &Cache[V]{
+		size:  size,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+
+	return el.Value.(*item[V]).Value, true
+}
+
+func (c *Cache[V]) Add(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.add(key, value)
+}
+
+// Generation is taken before the value is loaded and passed to AddIfUnchanged
+func (c *Cache[V]) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// AddIfUnchanged adds the value unless the cache is invalidated since Generation returned generation,
+// so a value loaded across Remove or Purge is not stored
+func (c *Cache[V]) AddIfUnchanged(key string, value V, generation uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return false
+	}
+	c.add(key, value)
+	return true
+}
+
+func (c *Cache[V]) add(key string, value V) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*item[V]).Value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&item[V]{Key: key, Value: value})
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*item[V]).Key)
+	}
+}
+
+func (c *Cache[V]) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+	c.generation++
+}
+
+// Purge removes all the values
+func (c *Cache[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+	c.generation++
+}
+
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
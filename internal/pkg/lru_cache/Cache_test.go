@@ -0,0 +1,161 @@
+package lru_cache
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// keys returns the keys from the most to the least recently used
+func keys[V any](c *Cache[V]) []string {
+	var result []string
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		result = append(result, el.Value.(*item[V]).Key)
+	}
+	return result
+}
+
+func TestCacheEviction(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		ops  func(c *Cache[int])
+		want []string
+	}{
+		{
+			name: "the least recently added is evicted",
+			size: 2,
+			ops:  func(c *Cache[int]) { c.Add("a", 1); c.Add("b", 2); c.Add("c", 3) },
+			want: []string{"c", "b"},
+		},
+		{
+			name: "get makes the key recently used",
+			size: 2,
+			ops:  func(c *Cache[int]) { c.Add("a", 1); c.Add("b", 2); c.Get("a"); c.Add("c", 3) },
+			want: []string{"c", "a"},
+		},
+		{
+			name: "add of the existing key makes it recently used",
+			size: 2,
+			ops:  func(c *Cache[int]) { c.Add("a", 1); c.Add("b", 2); c.Add("a", 10); c.Add("c", 3) },
+			want: []string{"c", "a"},
+		},
+		{
+			name: "get of the missing key changes nothing",
+			size: 2,
+			ops:  func(c *Cache[int]) { c.Add("a", 1); c.Add("b", 2); c.Get("x"); c.Add("c", 3) },
+			want: []string{"c", "b"},
+		},
+		{
+			name: "removed key frees the place",
+			size: 2,
+			ops:  func(c *Cache[int]) { c.Add("a", 1); c.Add("b", 2); c.Remove("a"); c.Add("c", 3) },
+			want: []string{"c", "b"},
+		},
+		{
+			name: "no limit",
+			size: 0,
+			ops:  func(c *Cache[int]) { c.Add("a", 1); c.Add("b", 2); c.Add("c", 3) },
+			want: []string{"c", "b", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache[int](tt.size)
+			tt.ops(c)
+			if got := keys(c); !slices.Equal(got, tt.want) || c.Len() != len(tt.want) {
+				t.Fatalf("keys = %v, Len() = %d, want %v", got, c.Len(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	c := NewCache[[]string](10)
+	c.Add("hello", []string{"привет"})
+	c.Add("hello", []string{"здравствуй"})
+
+	if value, ok := c.Get("hello"); !ok || !slices.Equal(value, []string{"здравствуй"}) {
+		t.Fatalf("Get() = %v, %v, want the last value", value, ok)
+	}
+	if value, ok := c.Get("world"); ok || value != nil {
+		t.Fatalf("Get() of the missing key = %v, %v", value, ok)
+	}
+}
+
+func TestCacheAddIfUnchanged(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(c *Cache[int]) // while the value is loaded
+		wantAdded  bool
+	}{
+		{name: "unchanged", invalidate: func(c *Cache[int]) { c.Add("other", 1) }, wantAdded: true},
+		{name: "removed during the load", invalidate: func(c *Cache[int]) { c.Remove("key") }},
+		{name: "purged during the load", invalidate: func(c *Cache[int]) { c.Purge() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache[int](10)
+			generation := c.Generation()
+			tt.invalidate(c)
+
+			added := c.AddIfUnchanged("key", 1, generation)
+			if _, ok := c.Get("key"); added != tt.wantAdded || ok != tt.wantAdded {
+				t.Fatalf("AddIfUnchanged() = %v, stored %v, want %v", added, ok, tt.wantAdded)
+			}
+		})
+	}
+}
+
+func TestCacheSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "translations.gob")
+	if err := NewCache[[]string](3).Load(path); err != nil {
+		t.Fatalf("Load() of the missing file error = %v", err)
+	}
+
+	c := NewCache[[]string](3)
+	c.Add("a", []string{"1"})
+	c.Add("b", []string{"2"})
+	c.Add("c", []string{"3"})
+	c.Get("a")
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if matches, _ := filepath.Glob(path + ".*.tmp"); len(matches) != 0 {
+		t.Fatalf("temporary files are left: %v", matches)
+	}
+
+	tests := []struct {
+		name string
+		size int
+		want []string
+	}{
+		{name: "same order", size: 3, want: []string{"a", "c", "b"}},
+		{name: "smaller cache keeps the recently used", size: 2, want: []string{"a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded := NewCache[[]string](tt.size)
+			if err := loaded.Load(path); err != nil {
+				t.Fatal(err)
+			}
+			if got := keys(loaded); !slices.Equal(got, tt.want) {
+				t.Fatalf("keys after Load() = %v, want %v", got, tt.want)
+			}
+			if value, _ := loaded.Get("a"); !slices.Equal(value, []string{"1"}) {
+				t.Fatalf("Get() after Load() = %v", value)
+			}
+		})
+	}
+}
+
+func TestCacheLoadCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "translations.gob")
+	if err := os.WriteFile(path, []byte("not gob"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewCache[[]string](3).Load(path); err == nil {
+		t.Fatal("Load() of the corrupted file error = nil")
+	}
+}
@@ -0,0 +1,61 @@
+package lru_cache
+
+import (
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Save writes the cache to the file. The file is replaced atomically,
+// so a crash during the save does not corrupt the previous snapshot.
+func (c *Cache[V]) Save(path string) error {
+	c.mu.Lock()
+	// from the least to the most recently used, so Load restores the same order
+	items := make([]item[V], 0, c.order.Len())
+	for el := c.order.Back(); el != nil; el = el.Prev() {
+		items = append(items, *el.Value.(*item[V]))
+	}
+	c.mu.Unlock()
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err = gob.NewEncoder(f).Encode(items); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Load adds the values saved by Save to the cache, a missing file is not an error
+func (c *Cache[V]) Load(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var items []item[V]
+	if err = gob.NewDecoder(f).Decode(&items); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, it := range items {
+		c.add(it.Key, it.Value)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package outbox
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type PublishFunc func(ctx context.Context, msg *Message) error
+
+type DispatcherConfig struct {
+	Interval       time.Duration // how often the outbox is checked for due messages
+	BatchSize      int
+	PublishTimeout time.Duration
+	MinBackoff     time.Duration
+	MaxBackoff     time.Duration
+	MaxAttempts    int // the message is moved to the dead letters after the last failed attempt
+}
+
+// Dispatcher publishes the messages from the outbox in the background.
+// The failed messages are retried with the exponential backoff until they are published
+// or MaxAttempts fail, then they are kept in the dead letters of the outbox.
+type Dispatcher struct {
+	outbox  *Outbox
+	publish PublishFunc
+	cfg     DispatcherConfig
+	lgr     zerolog.Logger
+	onStats func(Stats)
+
+	notifyCh   chan struct{}
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewDispatcher creates the dispatcher, onStats receives the outbox stats after every dispatch
+func NewDispatcher(outbox *Outbox, publish PublishFunc, cfg DispatcherConfig, lgr zerolog.Logger, onStats func(Stats)) *Dispatcher {
+	return &Dispatcher{
+		outbox:     outbox,
+		publish:    publish,
+		cfg:        cfg,
+		lgr:        lgr,
+		onStats:    onStats,
+		notifyCh:   make(chan struct{}, 1),
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+func (d *Dispatcher) Start() {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			d.dispatch()
+
+			select {
+			case <-d.shutdownCh:
+				return
+			case <-ticker.C:
+			case <-d.notifyCh:
+			}
+		}
+	}()
+}
+
+// Notify wakes the dispatcher up without waiting for the next tick
+func (d *Dispatcher) Notify() {
+	select {
+	case d.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Stop waits for the current dispatch, the rest of the messages are published after the restart
+func (d *Dispatcher) Stop() {
+	close(d.shutdownCh)
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) dispatch() {
+	defer d.reportStats()
+
+	for {
+		messages, err := d.outbox.Due(time.Now(), d.cfg.BatchSize)
+		if err != nil {
+			d.lgr.Error().Err(err).Msg("failed to read the outbox")
+			return
+		}
+
+		for _, msg := range messages {
+			select {
+			case <-d.shutdownCh:
+				return
+			default:
+			}
+			d.dispatchMessage(msg)
+		}
+
+		if len(messages) < d.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchMessage(msg *Message) {
+	lgr := d.lgr.With().Str("message_id", msg.Id).Int("attempts", msg.Attempts).Logger()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.PublishTimeout)
+	defer cancel()
+
+	if err := d.publish(ctx, msg); err != nil {
+		if msg.Attempts+1 >= d.cfg.MaxAttempts {
+			lgr.Error().Err(err).Msg("failed to publish the message, moved to the dead letters")
+			if err = d.outbox.Bury(msg, err); err != nil {
+				lgr.Error().Err(err).Msg("failed to store the dead letter")
+			}
+			return
+		}
+
+		nextAttemptAt := time.Now().Add(d.backoff(msg.Attempts))
+		lgr.Warn().Err(err).Time("next_attempt_at", nextAttemptAt).Msg("failed to publish the message, will retry")
+		if err = d.outbox.Retry(msg, nextAttemptAt, err); err != nil {
+			lgr.Error().Err(err).Msg("failed to store the message attempt")
+		}
+		return
+	}
+
+	// the message is published, a failed delete leads to a duplicate, which the consumer skips by its id
+	if err := d.outbox.Delete(msg); err != nil {
+		lgr.Error().Err(err).Msg("failed to delete the published message")
+		return
+	}
+	lgr.Debug().Msg("message is published")
+}
+
+// backoff doubles the delay after every attempt, the jitter spreads the retries after an outage
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.MaxBackoff
+	if attempts < 32 {
+		delay = min(d.cfg.MinBackoff<<attempts, d.cfg.MaxBackoff)
+	}
+	if delay <= 0 {
+		return d.cfg.MinBackoff
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func (d *Dispatcher) reportStats() {
+	if d.onStats == nil {
+		return
+	}
+
+	stats, err := d.outbox.Stats()
+	if err != nil {
+		d.lgr.Error().Err(err).Msg("failed to get the outbox stats")
+		return
+	}
+	d.onStats(stats)
+}
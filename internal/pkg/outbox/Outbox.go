@@ -0,0 +1,244 @@
+package outbox
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	messagesBucket = []byte("messages")
+	scheduleBucket = []byte("schedule") // the next attempt time and the message key, so Due reads only the due messages
+	deadBucket     = []byte("dead")     // the messages, which failed to be published MaxAttempts times
+)
+
+// Message is a message waiting to be published
+type Message struct {
+	Id          string            `json:"id"` // is sent as the AMQP message id, so the consumers can skip the duplicates
+	Exchange    string            `json:"exchange"`
+	RoutingKeys []string          `json:"routing_keys"`
+	ContentType string            `json:"content_type"`
+	Type        string            `json:"type,omitempty"`
+	Headers     map[string]string `json:"headers"`
+	Body        []byte            `json:"body"`
+
+	CreatedAt     time.Time `json:"created_at"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+
+	key []byte
+}
+
+type Stats struct {
+	Depth           int
+	Dead            int
+	OldestCreatedAt time.Time // zero while the outbox is empty
+}
+
+// OldestAge returns zero while the outbox is empty
+func (s Stats) OldestAge() time.Duration {
+	if s.OldestCreatedAt.IsZero() {
+		return 0
+	}
+	return time.Since(s.OldestCreatedAt)
+}
+
+// Outbox is a durable queue of the messages stored in a local file.
+// Put returns after the message is synced to the disk.
+type Outbox struct {
+	db *bbolt.DB
+}
+
+func Open(path string) (*Outbox, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		messages, err := tx.CreateBucketIfNotExists(messagesBucket)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.CreateBucketIfNotExists(deadBucket); err != nil {
+			return err
+		}
+		if tx.Bucket(scheduleBucket) != nil {
+			return nil
+		}
+		// the outbox file is written before the schedule was added, the waiting messages are scheduled once
+		schedule, err := tx.CreateBucket(scheduleBucket)
+		if err != nil {
+			return err
+		}
+		return messages.ForEach(func(key, value []byte) error {
+			msg := new(Message)
+			if err := json.Unmarshal(value, msg); err != nil {
+				return err
+			}
+			return schedule.Put(scheduleKey(msg.NextAttemptAt, key), nil)
+		})
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Outbox{db: db}, nil
+}
+
+// Put stores the message, the messages are kept in the order they are put
+func (o *Outbox) Put(msg *Message) error {
+	if msg.Id == "" {
+		msg.Id = uuid.NewString()
+	}
+	now := time.Now()
+	msg.CreatedAt = now
+	msg.NextAttemptAt = now
+
+	return o.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(messagesBucket)
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		msg.key = binary.BigEndian.AppendUint64(nil, seq)
+
+		value, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if err = bucket.Put(msg.key, value); err != nil {
+			return err
+		}
+		return tx.Bucket(scheduleBucket).Put(scheduleKey(msg.NextAttemptAt, msg.key), nil)
+	})
+}
+
+// Due returns up to limit messages, which should be published now, in the order of their next attempt
+func (o *Outbox) Due(now time.Time, limit int) ([]*Message, error) {
+	var messages []*Message
+	err := o.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(messagesBucket)
+		cursor := tx.Bucket(scheduleBucket).Cursor()
+		for key, _ := cursor.First(); key != nil && len(messages) < limit; key, _ = cursor.Next() {
+			nextAttemptAt, msgKey := parseScheduleKey(key)
+			if nextAttemptAt.After(now) {
+				break
+			}
+			msg := new(Message)
+			if err := json.Unmarshal(bucket.Get(msgKey), msg); err != nil {
+				return err
+			}
+			msg.key = append([]byte(nil), msgKey...)
+			messages = append(messages, msg)
+		}
+		return nil
+	})
+
+	return messages, err
+}
+
+// Delete removes the published message
+func (o *Outbox) Delete(msg *Message) error {
+	return o.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(scheduleBucket).Delete(scheduleKey(msg.NextAttemptAt, msg.key)); err != nil {
+			return err
+		}
+		return tx.Bucket(messagesBucket).Delete(msg.key)
+	})
+}
+
+// Retry stores the failed attempt, the message is published again at nextAttemptAt
+func (o *Outbox) Retry(msg *Message, nextAttemptAt time.Time, cause error) error {
+	prevKey := scheduleKey(msg.NextAttemptAt, msg.key)
+	msg.Attempts++
+	msg.NextAttemptAt = nextAttemptAt
+	msg.LastError = cause.Error()
+
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return o.db.Update(func(tx *bbolt.Tx) error {
+		schedule := tx.Bucket(scheduleBucket)
+		if err := schedule.Delete(prevKey); err != nil {
+			return err
+		}
+		if err := schedule.Put(scheduleKey(msg.NextAttemptAt, msg.key), nil); err != nil {
+			return err
+		}
+		return tx.Bucket(messagesBucket).Put(msg.key, value)
+	})
+}
+
+// Bury moves the failed message to the dead letters, which are kept in the file for the manual replay
+func (o *Outbox) Bury(msg *Message, cause error) error {
+	prevKey := scheduleKey(msg.NextAttemptAt, msg.key)
+	msg.Attempts++
+	msg.LastError = cause.Error()
+
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return o.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(scheduleBucket).Delete(prevKey); err != nil {
+			return err
+		}
+		if err := tx.Bucket(messagesBucket).Delete(msg.key); err != nil {
+			return err
+		}
+		return tx.Bucket(deadBucket).Put(msg.key, value)
+	})
+}
+
+func (o *Outbox) Stats() (Stats, error) {
+	var stats Stats
+	err := o.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(messagesBucket)
+		stats.Depth = bucket.Stats().KeyN
+		stats.Dead = tx.Bucket(deadBucket).Stats().KeyN
+
+		// keys are sequential, so the first message is the oldest one
+		_, value := bucket.Cursor().First()
+		if value == nil {
+			return nil
+		}
+		msg := new(Message)
+		if err := json.Unmarshal(value, msg); err != nil {
+			return err
+		}
+		stats.OldestCreatedAt = msg.CreatedAt
+		return nil
+	})
+
+	return stats, err
+}
+
+// scheduleKey orders the messages by the next attempt, the message key breaks the ties
+func scheduleKey(nextAttemptAt time.Time, msgKey []byte) []byte {
+	key := binary.BigEndian.AppendUint64(nil, uint64(nextAttemptAt.UnixNano()))
+	return append(key, msgKey...)
+}
+
+func parseScheduleKey(key []byte) (time.Time, []byte) {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8]))), key[8:]
+}
+
+func (o *Outbox) Close() error {
+	return o.db.Close()
+}
@@ -0,0 +1,264 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func openTestOutbox(t *testing.T) *Outbox {
+	t.Helper()
+	o, err := Open(filepath.Join(t.TempDir(), "outbox", "outbox.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = o.Close() })
+	return o
+}
+
+func messageIds(messages []*Message) []string {
+	ids := make([]string, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.Id
+	}
+	return ids
+}
+
+func TestOutbox(t *testing.T) {
+	o := openTestOutbox(t)
+	for _, id := range []string{"a", "b", "c"} {
+		if err := o.Put(&Message{Id: id, Exchange: "events", RoutingKeys: []string{"term.created"}, Body: []byte(id)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	generated := &Message{Exchange: "events"}
+	if err := o.Put(generated); err != nil || generated.Id == "" {
+		t.Fatalf("Put() without the id = %q, %v", generated.Id, err)
+	}
+
+	now := time.Now()
+	due, err := o.Due(now, 2)
+	if err != nil || len(due) != 2 || due[0].Id != "a" || due[1].Id != "b" {
+		t.Fatalf("Due() = %v, %v, want the first two in order", messageIds(due), err)
+	}
+	if string(due[0].Body) != "a" || due[0].RoutingKeys[0] != "term.created" {
+		t.Fatalf("Due() message = %+v", due[0])
+	}
+
+	if err = o.Retry(due[0], now.Add(time.Minute), errors.New("unroutable")); err != nil {
+		t.Fatal(err)
+	}
+	if err = o.Delete(due[1]); err != nil {
+		t.Fatal(err)
+	}
+	if due, err = o.Due(now, 10); err != nil || len(due) != 2 || due[0].Id != "c" {
+		t.Fatalf("Due() after the retry = %v, %v, want the retried message skipped", messageIds(due), err)
+	}
+	if due, err = o.Due(now.Add(time.Minute), 10); err != nil || len(due) != 3 || due[2].Id != "a" {
+		t.Fatalf("Due() at the next attempt = %v, %v, want the retried message last", messageIds(due), err)
+	}
+	if due[2].Attempts != 1 || due[2].LastError != "unroutable" {
+		t.Fatalf("retried message = %+v", due[2])
+	}
+
+	stats, err := o.Stats()
+	if err != nil || stats.Depth != 3 || stats.OldestCreatedAt.IsZero() {
+		t.Fatalf("Stats() = %+v, %v", stats, err)
+	}
+	for _, msg := range due {
+		if err = o.Delete(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats, err = o.Stats(); err != nil || stats.Depth != 0 || stats.OldestAge() != 0 {
+		t.Fatalf("Stats() of the empty outbox = %+v, %v", stats, err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := &Dispatcher{cfg: DispatcherConfig{MinBackoff: time.Second, MaxBackoff: time.Minute}}
+
+	tests := []struct {
+		attempts int
+		max      time.Duration
+	}{
+		{attempts: 0, max: time.Second},
+		{attempts: 3, max: 8 * time.Second},
+		{attempts: 10, max: time.Minute},
+		{attempts: 100, max: time.Minute},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if got := d.backoff(tt.attempts); got < tt.max/2 || got > tt.max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", tt.attempts, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	o := openTestOutbox(t)
+	for _, id := range []string{"ok", "fails"} {
+		if err := o.Put(&Message{Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	var published []string
+	publish := func(ctx context.Context, msg *Message) error {
+		if msg.Id == "fails" {
+			return errors.New("channel is closed")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		published = append(published, msg.Id)
+		return nil
+	}
+	statsCh := make(chan Stats, 10)
+	cfg := DispatcherConfig{Interval: time.Hour, BatchSize: 1, PublishTimeout: time.Second, MinBackoff: time.Hour, MaxBackoff: time.Hour, MaxAttempts: 3}
+	d := NewDispatcher(o, publish, cfg, zerolog.Nop(), func(stats Stats) { statsCh <- stats })
+	d.Start()
+	defer d.Stop()
+
+	select {
+	case stats := <-statsCh:
+		if stats.Depth != 1 {
+			t.Fatalf("Depth after the dispatch = %d, want the failed message only", stats.Depth)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no dispatch")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(published) != 1 || published[0] != "ok" {
+		t.Fatalf("published = %v", published)
+	}
+	due, err := o.Due(time.Now().Add(2*time.Hour), 10)
+	if err != nil || len(due) != 1 || due[0].Attempts != 1 || due[0].LastError != "channel is closed" {
+		t.Fatalf("failed message = %v, %v", due, err)
+	}
+}
+
+func TestDueReadsOnlyDueMessages(t *testing.T) {
+	o := openTestOutbox(t)
+	for _, id := range []string{"a", "b", "c"} {
+		if err := o.Put(&Message{Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	due, err := o.Due(now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first message waits longer than the second one
+	if err = o.Retry(due[0], now.Add(2*time.Hour), errors.New("unroutable")); err != nil {
+		t.Fatal(err)
+	}
+	if err = o.Retry(due[1], now.Add(time.Hour), errors.New("unroutable")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{name: "now", now: now, want: []string{"c"}},
+		{name: "in an hour", now: now.Add(time.Hour), want: []string{"c", "b"}},
+		{name: "in two hours", now: now.Add(2 * time.Hour), want: []string{"c", "b", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, err := o.Due(tt.now, 10)
+			if got := messageIds(due); err != nil || !slices.Equal(got, tt.want) {
+				t.Fatalf("Due() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBury(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.db")
+	o, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b"} {
+		if err = o.Put(&Message{Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	due, err := o.Due(time.Now(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = o.Bury(due[0], errors.New("unroutable")); err != nil {
+		t.Fatal(err)
+	}
+	if err = o.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the dead letters are kept after the restart
+	if o, err = Open(path); err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	stats, err := o.Stats()
+	if err != nil || stats.Depth != 1 || stats.Dead != 1 {
+		t.Fatalf("Stats() = %+v, %v, want one waiting and one dead", stats, err)
+	}
+	if due, err = o.Due(time.Now().Add(time.Hour), 10); err != nil || !slices.Equal(messageIds(due), []string{"b"}) {
+		t.Fatalf("Due() = %v, %v, want the dead letter skipped", messageIds(due), err)
+	}
+}
+
+func TestDispatcherMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name        string
+		attempts    int // before the dispatch
+		maxAttempts int
+		wantDepth   int
+		wantDead    int
+	}{
+		{name: "retried", attempts: 0, maxAttempts: 2, wantDepth: 1},
+		{name: "last attempt", attempts: 1, maxAttempts: 2, wantDead: 1},
+		{name: "single attempt", attempts: 0, maxAttempts: 1, wantDead: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := openTestOutbox(t)
+			if err := o.Put(&Message{Id: "fails"}); err != nil {
+				t.Fatal(err)
+			}
+			due, err := o.Due(time.Now(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < tt.attempts; i++ {
+				if err = o.Retry(due[0], due[0].NextAttemptAt, errors.New("channel is closed")); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			publish := func(ctx context.Context, msg *Message) error { return errors.New("channel is closed") }
+			cfg := DispatcherConfig{BatchSize: 10, PublishTimeout: time.Second, MinBackoff: time.Hour, MaxBackoff: time.Hour, MaxAttempts: tt.maxAttempts}
+			var stats Stats
+			d := NewDispatcher(o, publish, cfg, zerolog.Nop(), func(s Stats) { stats = s })
+			d.dispatch()
+
+			if stats.Depth != tt.wantDepth || stats.Dead != tt.wantDead {
+				t.Fatalf("Stats() = %+v, want depth %d and dead %d", stats, tt.wantDepth, tt.wantDead)
+			}
+		})
+	}
+}
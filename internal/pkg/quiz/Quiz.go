@@ -0,0 +1,135 @@
+package quiz
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Modes of the quiz
+const (
+	ModeMultipleChoice        = "multiple_choice"         // the term is shown, the meaning is chosen
+	ModeReverseMultipleChoice = "reverse_multiple_choice" // the meaning is shown, the term is chosen
+	ModeTyping                = "typing"                  // the meaning is shown, the term is typed
+	ModeTrueFalse             = "true_false"              // the term and a meaning are shown, the user tells if they match
+)
+
+// Choices is the number of the options of the multiple choice question, when the collection has enough terms
+const Choices = 4
+
+type Card struct {
+	TermId            uint64
+	TermLanguageId    uint64
+	MeaningLanguageId uint64
+	Term              string
+	Meaning           string
+}
+
+type Question struct {
+	TermId uint64
+	Prompt string
+	// Options of the multiple choice, Answer is one of them
+	Options []string
+	// Statement is the meaning proposed by the true/false question, IsTrue tells if it is the meaning of the term
+	Statement string
+	IsTrue    bool
+	Answer    string
+}
+
+func IsMode(mode string) bool {
+	switch mode {
+	case ModeMultipleChoice, ModeReverseMultipleChoice, ModeTyping, ModeTrueFalse:
+		return true
+	}
+	return false
+}
+
+// Generate returns up to size questions. The same cards, mode and seed give the same questions.
+// The distractors are taken from the cards of the same language pair with the other answer.
+func Generate(cards []Card, mode string, size int, seed int64) ([]*Question, error) {
+	if !IsMode(mode) {
+		return nil, fmt.Errorf("unknown quiz mode %q", mode)
+	}
+
+	// The order of the cards must not depend on the source
+	cards = append([]Card(nil), cards...)
+	sort.Slice(cards, func(i, j int) bool { return cards[i].TermId < cards[j].TermId })
+
+	rnd := rand.New(rand.NewSource(seed))
+	rnd.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+
+	questions := make([]*Question, 0, size)
+	for _, card := range cards {
+		if len(questions) == size {
+			break
+		}
+		if question := newQuestion(rnd, cards, card, mode); question != nil {
+			questions = append(questions, question)
+		}
+	}
+	return questions, nil
+}
+
+// newQuestion returns nil, when the multiple choice question has no distractors
+func newQuestion(rnd *rand.Rand, cards []Card, card Card, mode string) *Question {
+	switch mode {
+	case ModeMultipleChoice, ModeReverseMultipleChoice:
+		prompt, answer := card.Term, card.Meaning
+		side := func(c Card) string { return c.Meaning }
+		if mode == ModeReverseMultipleChoice {
+			prompt, answer = card.Meaning, card.Term
+			side = func(c Card) string { return c.Term }
+		}
+
+		distractors := pickDistractors(rnd, cards, card, side, Choices-1)
+		if len(distractors) == 0 {
+			return nil
+		}
+		options := append(distractors, answer)
+		rnd.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+		return &Question{TermId: card.TermId, Prompt: prompt, Options: options, Answer: answer}
+
+	case ModeTyping:
+		return &Question{TermId: card.TermId, Prompt: card.Meaning, Answer: card.Term}
+
+	default:
+		question := &Question{TermId: card.TermId, Prompt: card.Term, Statement: card.Meaning, IsTrue: true, Answer: card.Meaning}
+		// The false statement needs a distractor, the question stays true without it
+		if rnd.Intn(2) == 0 {
+			if distractors := pickDistractors(rnd, cards, card, func(c Card) string { return c.Meaning }, 1); len(distractors) > 0 {
+				question.Statement = distractors[0]
+				question.IsTrue = false
+			}
+		}
+		return question
+	}
+}
+
+// pickDistractors returns up to n different values of the cards of the same language pair, which are not the answer
+func pickDistractors(rnd *rand.Rand, cards []Card, card Card, side func(Card) string, n int) []string {
+	seen := map[string]struct{}{normalize(side(card)): {}}
+	var candidates []string
+	for _, c := range cards {
+		if c.TermLanguageId != card.TermLanguageId || c.MeaningLanguageId != card.MeaningLanguageId {
+			continue
+		}
+		value := side(c)
+		key := normalize(value)
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		candidates = append(candidates, value)
+	}
+
+	rnd.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,185 @@
+package quiz
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// testCards are two language pairs: en-ru (1-5) and en-de (6-7)
+func testCards() []Card {
+	return []Card{
+		{TermId: 1, TermLanguageId: 1, MeaningLanguageId: 2, Term: "cat", Meaning: "кот"},
+		{TermId: 2, TermLanguageId: 1, MeaningLanguageId: 2, Term: "dog", Meaning: "собака"},
+		{TermId: 3, TermLanguageId: 1, MeaningLanguageId: 2, Term: "house", Meaning: "дом"},
+		{TermId: 4, TermLanguageId: 1, MeaningLanguageId: 2, Term: "tree", Meaning: "дерево"},
+		{TermId: 5, TermLanguageId: 1, MeaningLanguageId: 2, Term: "tomcat", Meaning: " Кот "},
+		{TermId: 6, TermLanguageId: 1, MeaningLanguageId: 3, Term: "cat", Meaning: "Katze"},
+		{TermId: 7, TermLanguageId: 1, MeaningLanguageId: 3, Term: "dog", Meaning: "Hund"},
+	}
+}
+
+func cardsById() map[uint64]Card {
+	cards := make(map[uint64]Card)
+	for _, card := range testCards() {
+		cards[card.TermId] = card
+	}
+	return cards
+}
+
+func TestGenerateSeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		seed     int64
+		reversed bool // the cards are passed in the other order
+		wantSame bool
+	}{
+		{name: "same seed", mode: ModeMultipleChoice, seed: 7, wantSame: true},
+		{name: "same seed, the order of the cards does not matter", mode: ModeMultipleChoice, seed: 7, reversed: true, wantSame: true},
+		{name: "same seed of true/false", mode: ModeTrueFalse, seed: 7, reversed: true, wantSame: true},
+		{name: "another seed", mode: ModeMultipleChoice, seed: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := Generate(testCards(), tt.mode, 7, 7)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cards := testCards()
+			if tt.reversed {
+				slices.Reverse(cards)
+			}
+			second, err := Generate(cards, tt.mode, 7, tt.seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if same := reflect.DeepEqual(first, second); same != tt.wantSame {
+				t.Fatalf("the questions are the same = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestGenerateMultipleChoice(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   string
+		answer func(Card) string
+		prompt func(Card) string
+	}{
+		{name: "multiple choice", mode: ModeMultipleChoice, answer: func(c Card) string { return c.Meaning }, prompt: func(c Card) string { return c.Term }},
+		{name: "reverse", mode: ModeReverseMultipleChoice, answer: func(c Card) string { return c.Term }, prompt: func(c Card) string { return c.Meaning }},
+	}
+	cards := cardsById()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := int64(0); seed < 20; seed++ {
+				questions, err := Generate(testCards(), tt.mode, 10, seed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, question := range questions {
+					card := cards[question.TermId]
+					if question.Prompt != tt.prompt(card) || question.Answer != tt.answer(card) {
+						t.Fatalf("question = %+v, want the card %+v", question, card)
+					}
+					if !slices.Contains(question.Options, question.Answer) || len(question.Options) > Choices {
+						t.Fatalf("options = %q, want up to %d with the answer %q", question.Options, Choices, question.Answer)
+					}
+					// the distractors are from the same language pair and differ from the answer
+					for _, option := range question.Options {
+						if option == question.Answer {
+							continue
+						}
+						if normalize(option) == normalize(question.Answer) {
+							t.Fatalf("distractor %q repeats the answer %q", option, question.Answer)
+						}
+						if !hasPairValue(card, option, tt.answer) {
+							t.Fatalf("distractor %q is not of the language pair of %+v", option, card)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateTrueFalse(t *testing.T) {
+	cards := cardsById()
+	var trueCount, falseCount int
+	for seed := int64(0); seed < 20; seed++ {
+		questions, err := Generate(testCards(), ModeTrueFalse, 10, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(questions) != len(cards) {
+			t.Fatalf("Generate() = %d questions, want one for every card", len(questions))
+		}
+		for _, question := range questions {
+			card := cards[question.TermId]
+			if question.Prompt != card.Term || question.Answer != card.Meaning {
+				t.Fatalf("question = %+v, want the card %+v", question, card)
+			}
+			if question.IsTrue {
+				trueCount++
+				if question.Statement != card.Meaning {
+					t.Fatalf("true statement %q, want %q", question.Statement, card.Meaning)
+				}
+				continue
+			}
+			falseCount++
+			if normalize(question.Statement) == normalize(card.Meaning) || !hasPairValue(card, question.Statement, func(c Card) string { return c.Meaning }) {
+				t.Fatalf("false statement %q of %+v", question.Statement, card)
+			}
+		}
+	}
+	if trueCount == 0 || falseCount == 0 {
+		t.Fatalf("true statements %d, false %d, want both", trueCount, falseCount)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	single := []Card{{TermId: 1, TermLanguageId: 1, MeaningLanguageId: 2, Term: "cat", Meaning: "кот"}}
+
+	tests := []struct {
+		name    string
+		cards   []Card
+		mode    string
+		size    int
+		want    int
+		wantErr bool
+	}{
+		{name: "size", cards: testCards(), mode: ModeTyping, size: 3, want: 3},
+		{name: "fewer cards than the size", cards: testCards(), mode: ModeTyping, size: 10, want: 7},
+		{name: "multiple choice needs a distractor", cards: single, mode: ModeMultipleChoice, size: 10, want: 0},
+		{name: "true/false stays true without a distractor", cards: single, mode: ModeTrueFalse, size: 10, want: 1},
+		{name: "unknown mode", cards: testCards(), mode: "matching", size: 10, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions, err := Generate(tt.cards, tt.mode, tt.size, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(questions) != tt.want {
+				t.Fatalf("Generate() = %d questions, want %d", len(questions), tt.want)
+			}
+			for _, question := range questions {
+				if tt.mode == ModeTrueFalse && !question.IsTrue {
+					t.Fatalf("question without a distractor = %+v, want true", question)
+				}
+			}
+		})
+	}
+}
+
+// hasPairValue tells if the value is on the side of a card of the language pair of the card
+func hasPairValue(card Card, value string, side func(Card) string) bool {
+	for _, c := range testCards() {
+		if c.TermLanguageId == card.TermLanguageId && c.MeaningLanguageId == card.MeaningLanguageId && side(c) == value {
+			return true
+		}
+	}
+	return false
+}
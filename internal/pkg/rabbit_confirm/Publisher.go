@@ -0,0 +1,169 @@
+package rabbit_confirm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog"
+)
+
+var ErrNotConnected = errors.New("publisher is not connected to the broker")
+
+// Publisher publishes the mandatory messages on its own confirm channel and reconnects when it is closed.
+// The returns and the confirms of the channel are read by one goroutine in the order of the broker,
+// so the return of the message is matched with its ack without waiting for it.
+type Publisher struct {
+	uri               string
+	reconnectInterval time.Duration
+	lgr               zerolog.Logger
+
+	mu         sync.Mutex // serializes the publishes, so the next delivery tag is known before the publish
+	connection *amqp091.Connection
+	channel    *amqp091.Channel // nil while disconnected
+	tracker    *tracker
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Confirmation is the pending result of the publish
+type Confirmation struct {
+	result <-chan error
+}
+
+// Wait returns nil when the message is acked and routed, ErrNacked, *ReturnedError or ErrChannelClosed otherwise
+func (c *Confirmation) Wait(ctx context.Context) error {
+	select {
+	case err := <-c.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewPublisher connects in the background, the publishes fail with ErrNotConnected until it is connected
+func NewPublisher(uri string, reconnectInterval time.Duration, lgr zerolog.Logger) *Publisher {
+	p := &Publisher{
+		uri:               uri,
+		reconnectInterval: reconnectInterval,
+		lgr:               lgr,
+		tracker:           newTracker(),
+		done:              make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+// Publish sends the mandatory message, the message id is required to match the return
+func (p *Publisher) Publish(ctx context.Context, exchange string, routingKey string, msg amqp091.Publishing) (*Confirmation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.channel == nil {
+		return nil, ErrNotConnected
+	}
+
+	deliveryTag := p.channel.GetNextPublishSeqNo()
+	result := p.tracker.add(deliveryTag, msg.MessageId, routingKey)
+	if _, err := p.channel.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, true, false, msg); err != nil {
+		p.tracker.remove(deliveryTag)
+		return nil, err
+	}
+	return &Confirmation{result: result}, nil
+}
+
+func (p *Publisher) Close() {
+	close(p.done)
+
+	p.mu.Lock()
+	if p.connection != nil {
+		_ = p.connection.Close()
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *Publisher) run() {
+	defer p.wg.Done()
+
+	for {
+		if err := p.serve(); err != nil {
+			p.lgr.Error().Err(err).Msg("AMQP connection error")
+		}
+
+		select {
+		case <-p.done:
+			return
+		case <-time.After(p.reconnectInterval):
+		}
+	}
+}
+
+// serve connects and reads the returns and the confirms until the channel is closed
+func (p *Publisher) serve() error {
+	connection, err := amqp091.Dial(p.uri)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = connection.Close() }()
+
+	channel, err := connection.Channel()
+	if err != nil {
+		return err
+	}
+	if err = channel.Confirm(false); err != nil {
+		return err
+	}
+
+	// the unbuffered returns are read before the broker ack is passed to the confirms
+	returns := channel.NotifyReturn(make(chan amqp091.Return))
+	confirms := channel.NotifyPublish(make(chan amqp091.Confirmation))
+	closed := channel.NotifyClose(make(chan *amqp091.Error, 1))
+
+	p.mu.Lock()
+	select {
+	case <-p.done:
+		p.mu.Unlock()
+		return nil
+	default:
+	}
+	p.connection = connection
+	p.channel = channel
+	p.mu.Unlock()
+	p.lgr.Info().Msg("AMQP publisher is connected")
+
+	defer func() {
+		p.mu.Lock()
+		p.connection = nil
+		p.channel = nil
+		p.mu.Unlock()
+		p.tracker.closed()
+	}()
+
+	for {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				returns = nil // closed with the channel, the confirms tell it
+				continue
+			}
+			p.tracker.returned(ret)
+		case confirmation, ok := <-confirms:
+			if !ok {
+				return ErrChannelClosed
+			}
+			p.tracker.confirmed(confirmation)
+		case closeErr, ok := <-closed:
+			if ok && closeErr != nil {
+				return closeErr
+			}
+			return ErrChannelClosed
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package rabbit_confirm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	ErrNacked        = errors.New("message is nacked by the broker")
+	ErrChannelClosed = errors.New("channel is closed before the confirm")
+)
+
+// ReturnedError is the result of the mandatory message, which is not routed to any queue
+type ReturnedError struct {
+	Return amqp091.Return
+}
+
+func (e *ReturnedError) Error() string {
+	return fmt.Sprintf("message is returned by the broker: %d %s (routing key %q)",
+		e.Return.ReplyCode, e.Return.ReplyText, e.Return.RoutingKey)
+}
+
+// returnKey identifies the publish in the return, the return has no delivery tag
+type returnKey struct {
+	messageId  string
+	routingKey string
+}
+
+type pending struct {
+	key      returnKey
+	returned *amqp091.Return
+	result   chan error
+}
+
+// tracker matches the returns and the confirms of the publishes. The broker sends the return of the message
+// before its ack, so the result is known as soon as the ack is tracked.
+type tracker struct {
+	mu      sync.Mutex
+	pending map[uint64]*pending // by the delivery tag
+	byKey   map[returnKey]*pending
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		pending: make(map[uint64]*pending),
+		byKey:   make(map[returnKey]*pending),
+	}
+}
+
+// add registers the publish before it is sent, the result gets nil, ErrNacked, *ReturnedError or ErrChannelClosed
+func (t *tracker) add(deliveryTag uint64, messageId string, routingKey string) <-chan error {
+	p := &pending{
+		key:    returnKey{messageId: messageId, routingKey: routingKey},
+		result: make(chan error, 1),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[deliveryTag] = p
+	t.byKey[p.key] = p
+	return p.result
+}
+
+// remove forgets the publish, which is not sent
+func (t *tracker) remove(deliveryTag uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if p, ok := t.pending[deliveryTag]; ok {
+		delete(t.pending, deliveryTag)
+		delete(t.byKey, p.key)
+	}
+}
+
+func (t *tracker) returned(ret amqp091.Return) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if p, ok := t.byKey[returnKey{messageId: ret.MessageId, routingKey: ret.RoutingKey}]; ok {
+		p.returned = &ret
+	}
+}
+
+func (t *tracker) confirmed(confirmation amqp091.Confirmation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.pending[confirmation.DeliveryTag]
+	if !ok {
+		return
+	}
+	delete(t.pending, confirmation.DeliveryTag)
+	delete(t.byKey, p.key)
+
+	switch {
+	case !confirmation.Ack:
+		p.result <- ErrNacked
+	case p.returned != nil:
+		p.result <- &ReturnedError{Return: *p.returned}
+	default:
+		p.result <- nil
+	}
+}
+
+// closed fails all the pending publishes, the delivery tags start again on the next channel
+func (t *tracker) closed() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for deliveryTag, p := range t.pending {
+		p.result <- ErrChannelClosed
+		delete(t.pending, deliveryTag)
+	}
+	clear(t.byKey)
+}
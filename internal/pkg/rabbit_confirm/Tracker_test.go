@@ -0,0 +1,102 @@
+package rabbit_confirm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+func TestTracker(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  func(tr *tracker)
+		wantErr error // nil, ErrNacked, ErrChannelClosed or the *ReturnedError type
+	}{
+		{
+			name: "acked",
+			events: func(tr *tracker) {
+				tr.confirmed(amqp091.Confirmation{DeliveryTag: 1, Ack: true})
+			},
+		},
+		{
+			name: "nacked",
+			events: func(tr *tracker) {
+				tr.confirmed(amqp091.Confirmation{DeliveryTag: 1, Ack: false})
+			},
+			wantErr: ErrNacked,
+		},
+		{
+			name: "returned before the ack",
+			events: func(tr *tracker) {
+				tr.returned(amqp091.Return{MessageId: "m1", RoutingKey: "emails", ReplyCode: 312, ReplyText: "NO_ROUTE"})
+				tr.confirmed(amqp091.Confirmation{DeliveryTag: 1, Ack: true})
+			},
+			wantErr: &ReturnedError{},
+		},
+		{
+			name: "return of another routing key",
+			events: func(tr *tracker) {
+				tr.returned(amqp091.Return{MessageId: "m1", RoutingKey: "other", ReplyCode: 312})
+				tr.confirmed(amqp091.Confirmation{DeliveryTag: 1, Ack: true})
+			},
+		},
+		{
+			name: "ack of another publish",
+			events: func(tr *tracker) {
+				tr.confirmed(amqp091.Confirmation{DeliveryTag: 2, Ack: true})
+				tr.closed()
+			},
+			wantErr: ErrChannelClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newTracker()
+			result := tr.add(1, "m1", "emails")
+			tt.events(tr)
+
+			var err error
+			select {
+			case err = <-result:
+			default:
+				t.Fatal("no result")
+			}
+
+			var returned *ReturnedError
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("got %v, want nil", err)
+				}
+			case *ReturnedError:
+				if !errors.As(err, &returned) || returned.Return.ReplyCode != 312 {
+					t.Fatalf("got %v, want the returned error", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("got %v, want %v", err, want)
+				}
+			}
+
+			if len(tr.pending) != 0 || len(tr.byKey) != 0 {
+				t.Fatalf("the publish is still tracked")
+			}
+		})
+	}
+}
+
+func TestTrackerRemove(t *testing.T) {
+	tr := newTracker()
+	tr.add(1, "m1", "emails")
+	tr.remove(1)
+
+	// the tag of the failed publish is used by the next one
+	result := tr.add(1, "m2", "emails")
+	tr.returned(amqp091.Return{MessageId: "m1", RoutingKey: "emails"})
+	tr.confirmed(amqp091.Confirmation{DeliveryTag: 1, Ack: true})
+	if err := <-result; err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+}
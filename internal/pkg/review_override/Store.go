@@ -0,0 +1,92 @@
+package review_override
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Override is the time of the last review of the term copied by the transfer. vocabulary_api can not create
+// a term with RepeatedAt, the copy is repeated at the time of the transfer, so the time of the source is kept here.
+type Override struct {
+	TermId        uint64     `json:"term_id"`     // the copy
+	RepeatedAt    *time.Time `json:"repeated_at"` // of the source, nil when it was never repeated
+	TransferredAt time.Time  `json:"transferred_at"`
+}
+
+// Applies tells if the override replaces RepeatedAt of the term. The copy is repeated by the transfer
+// up to TransferredAt, the later time is the review of the copy and wins over the override.
+// The clocks of the gateway and vocabulary_api are expected to be in sync.
+func (o *Override) Applies(repeatedAt *time.Time) bool {
+	return repeatedAt == nil || !repeatedAt.After(o.TransferredAt)
+}
+
+// Store keeps the overrides in Redis shared by the instances. The overrides of the collection are
+// the hash {prefix}{collection id} by the term id, so the terms of the collection are overridden by one read.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func New(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+func (s *Store) Put(ctx context.Context, collectionId uint64, overrides []*Override) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	values := make([]any, 0, 2*len(overrides))
+	for _, override := range overrides {
+		value, err := json.Marshal(override)
+		if err != nil {
+			return err
+		}
+		values = append(values, formatId(override.TermId), value)
+	}
+	return s.client.HSet(ctx, s.key(collectionId), values...).Err()
+}
+
+// Collection returns the overrides of the terms of the collection by the term id
+func (s *Store) Collection(ctx context.Context, collectionId uint64) (map[uint64]*Override, error) {
+	values, err := s.client.HGetAll(ctx, s.key(collectionId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[uint64]*Override, len(values))
+	for _, value := range values {
+		override := new(Override)
+		if err = json.Unmarshal([]byte(value), override); err != nil {
+			return nil, err
+		}
+		overrides[override.TermId] = override
+	}
+	return overrides, nil
+}
+
+func (s *Store) Delete(ctx context.Context, collectionId uint64, termIds ...uint64) error {
+	if len(termIds) == 0 {
+		return nil
+	}
+	fields := make([]string, len(termIds))
+	for i, termId := range termIds {
+		fields[i] = formatId(termId)
+	}
+	return s.client.HDel(ctx, s.key(collectionId), fields...).Err()
+}
+
+func (s *Store) DeleteCollection(ctx context.Context, collectionId uint64) error {
+	return s.client.Del(ctx, s.key(collectionId)).Err()
+}
+
+func (s *Store) key(collectionId uint64) string {
+	return s.prefix + formatId(collectionId)
+}
+
+func formatId(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
@@ -0,0 +1,70 @@
+package review_override
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	store := New(client, "test:")
+
+	repeatedAt := time.Unix(1_700_000_000, 0).UTC()
+	transferredAt := repeatedAt.Add(48 * time.Hour)
+	err := store.Put(ctx, 10, []*Override{
+		{TermId: 1, RepeatedAt: &repeatedAt, TransferredAt: transferredAt},
+		{TermId: 2, TransferredAt: transferredAt},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Delete(ctx, 10, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	overrides, err := store.Collection(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overrides) != 1 || !overrides[1].RepeatedAt.Equal(repeatedAt) || !overrides[1].TransferredAt.Equal(transferredAt) {
+		t.Fatalf("Collection() = %+v, want the override of the term 1", overrides)
+	}
+
+	if err = store.DeleteCollection(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	if overrides, err = store.Collection(ctx, 10); err != nil || len(overrides) != 0 {
+		t.Fatalf("Collection() after DeleteCollection = %+v, %v", overrides, err)
+	}
+}
+
+func TestApplies(t *testing.T) {
+	transferredAt := time.Unix(1_700_000_000, 0)
+	before, after := transferredAt.Add(-time.Second), transferredAt.Add(time.Second)
+
+	tests := []struct {
+		name       string
+		repeatedAt *time.Time
+		want       bool
+	}{
+		{name: "copy is not repeated", want: true},
+		{name: "repeated by the transfer", repeatedAt: &before, want: true},
+		{name: "repeated at the transfer", repeatedAt: &transferredAt, want: true},
+		{name: "reviewed after the transfer", repeatedAt: &after},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			override := &Override{TermId: 1, TransferredAt: transferredAt}
+			if got := override.Applies(tt.repeatedAt); got != tt.want {
+				t.Fatalf("Applies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrRetrierIsFull    = errors.New("too many deferred saga steps")
+	ErrRetrierIsStopped = errors.New("saga retrier is stopped")
+)
+
+type RetrierConfig struct {
+	MaxPending  int
+	MaxAttempts int
+	StepTimeout time.Duration
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Retrier retries the deferred saga steps in the background with the exponential backoff.
+// The steps are kept in memory: the steps pending on shutdown are logged as abandoned.
+type Retrier struct {
+	cfg     RetrierConfig
+	lgr     zerolog.Logger
+	pending chan struct{}
+
+	mu         sync.Mutex
+	stopped    bool
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+func NewRetrier(cfg RetrierConfig, lgr zerolog.Logger) *Retrier {
+	return &Retrier{
+		cfg:        cfg,
+		lgr:        lgr,
+		pending:    make(chan struct{}, cfg.MaxPending),
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// Defer starts the background retries of the step
+func (r *Retrier) Defer(ctx context.Context, s *Saga, step Step) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return ErrRetrierIsStopped
+	}
+	select {
+	case r.pending <- struct{}{}:
+	default:
+		return ErrRetrierIsFull
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.pending }()
+
+		r.retry(ctx, s, step)
+	}()
+
+	return nil
+}
+
+func (r *Retrier) retry(ctx context.Context, s *Saga, step Step) {
+	lgr := s.lgr.With().Str("step", step.Name).Logger()
+
+	for attempt := 1; attempt <= r.cfg.MaxAttempts; attempt++ {
+		select {
+		case <-r.shutdownCh:
+			lgr.Error().Int("attempt", attempt).Msg("saga step is abandoned on shutdown")
+			s.observe(step.Name, ResultAbandoned)
+			return
+		case <-time.After(r.backoff(attempt)):
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, r.cfg.StepTimeout)
+		err := step.Action(attemptCtx)
+		cancel()
+		if err == nil {
+			lgr.Info().Int("attempt", attempt).Msg("deferred saga step is done")
+			s.observe(step.Name, ResultRetryDone)
+			return
+		}
+		lgr.Warn().Err(err).Int("attempt", attempt).Msg("deferred saga step is failed")
+	}
+
+	lgr.Error().Int("attempts", r.cfg.MaxAttempts).Msg("deferred saga step is failed, no attempts left")
+	s.observe(step.Name, ResultRetryFailed)
+}
+
+func (r *Retrier) backoff(attempt int) time.Duration {
+	delay := r.cfg.MaxBackoff
+	if attempt < 32 {
+		delay = min(r.cfg.MinBackoff<<(attempt-1), r.cfg.MaxBackoff)
+	}
+	if delay <= 0 {
+		return r.cfg.MinBackoff
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Stop abandons the pending retries and waits for the running attempts
+func (r *Retrier) Stop() {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+
+	close(r.shutdownCh)
+	r.wg.Wait()
+}
@@ -0,0 +1,215 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+)
+
+// Results of the steps, they are logged and counted in the saga_step_count metric
+const (
+	ResultDone               = "done"
+	ResultFailed             = "failed"
+	ResultDeferred           = "deferred"
+	ResultCompensated        = "compensated"
+	ResultCompensationFailed = "compensation_failed"
+	ResultRetryDone          = "retry_done"
+	ResultRetryFailed        = "retry_failed"
+	ResultAbandoned          = "abandoned"
+)
+
+type Step struct {
+	Name   string
+	Action func(ctx context.Context) error
+	// Compensate undoes the done step when a later step fails, nil when the step can not be undone
+	Compensate func(ctx context.Context) error
+	// Deferrable step does not fail the saga, it is retried in the background instead.
+	// Its Action must be safe to repeat.
+	Deferrable bool
+	// RetryCompensation passes the failed Compensate to the retrier, so the undo is not left half-done.
+	// Compensate must be safe to repeat.
+	RetryCompensation bool
+}
+
+// compensationSuffix is added to the name of the step, which compensation is retried in the background
+const compensationSuffix = "_compensation"
+
+// StepError is returned by Run, when the saga is failed and the done steps are compensated
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("saga step %s failed: %s", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Saga runs the stages in order, the steps of one stage run concurrently.
+// When a step fails, the done steps are compensated in the reverse order.
+type Saga struct {
+	name    string
+	stages  [][]Step
+	retrier *Retrier
+	lgr     zerolog.Logger
+	prom    *prometheus.Exporter
+}
+
+func New(name string, retrier *Retrier, lgr zerolog.Logger, prom *prometheus.Exporter) *Saga {
+	return &Saga{
+		name:    name,
+		retrier: retrier,
+		lgr:     lgr.With().Str("saga", name).Logger(),
+		prom:    prom,
+	}
+}
+
+func (s *Saga) AddStep(step Step) *Saga {
+	s.stages = append(s.stages, []Step{step})
+	return s
+}
+
+// AddParallelSteps adds the stage of independent steps, they run concurrently.
+// The first failed step cancels the context of the others.
+func (s *Saga) AddParallelSteps(steps ...Step) *Saga {
+	s.stages = append(s.stages, steps)
+	return s
+}
+
+// Run executes the saga. detached is the context for the background retries,
+// it must not depend on the request, which is finished by the time they run.
+func (s *Saga) Run(ctx context.Context, detached context.Context) error {
+	var done []Step
+	for _, stage := range s.stages {
+		var err error
+		if len(stage) == 1 {
+			err = s.runStep(ctx, detached, stage[0], &done)
+		} else {
+			err = s.runStage(ctx, detached, stage, &done)
+		}
+		if err != nil {
+			s.compensate(detached, done)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runStep runs the step, the failed deferrable step is passed to the retrier
+func (s *Saga) runStep(ctx context.Context, detached context.Context, step Step, done *[]Step) error {
+	err := step.Action(ctx)
+	if err == nil {
+		s.observe(step.Name, ResultDone)
+		*done = append(*done, step)
+		return nil
+	}
+
+	if step.Deferrable && s.deferStep(detached, step, err) {
+		return nil
+	}
+
+	s.lgr.Error().Err(err).Str("step", step.Name).Msg("saga step is failed, compensating the done steps")
+	s.observe(step.Name, ResultFailed)
+	return &StepError{Step: step.Name, Err: err}
+}
+
+// runStage runs the steps concurrently. The failed deferrable steps are deferred only when the whole stage succeeds,
+// otherwise they may be retried after the saga is compensated.
+func (s *Saga) runStage(ctx context.Context, detached context.Context, stage []Step, done *[]Step) error {
+	var mu sync.Mutex
+	var failed []Step
+	var failedErrs []error
+
+	group, groupCtx := fan_out.WithContext(ctx)
+	for _, step := range stage {
+		step := step
+		group.Go(step.Name, func(_ context.Context) error {
+			err := step.Action(groupCtx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				s.observe(step.Name, ResultDone)
+				*done = append(*done, step)
+			case step.Deferrable:
+				failed = append(failed, step)
+				failedErrs = append(failedErrs, err)
+			default:
+				return err
+			}
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		var branchErr *fan_out.BranchError
+		if !errors.As(err, &branchErr) {
+			branchErr = &fan_out.BranchError{Err: err}
+		}
+
+		s.lgr.Error().Err(branchErr.Err).Str("step", branchErr.Branch).Msg("saga step is failed, compensating the done steps")
+		s.observe(branchErr.Branch, ResultFailed)
+		return &StepError{Step: branchErr.Branch, Err: branchErr.Err}
+	}
+
+	for i, step := range failed {
+		if !s.deferStep(detached, step, failedErrs[i]) {
+			s.lgr.Error().Err(failedErrs[i]).Str("step", step.Name).Msg("saga step is failed, compensating the done steps")
+			s.observe(step.Name, ResultFailed)
+			return &StepError{Step: step.Name, Err: failedErrs[i]}
+		}
+	}
+
+	return nil
+}
+
+// deferStep passes the failed step to the retrier, false when the retrier does not accept it
+func (s *Saga) deferStep(detached context.Context, step Step, err error) bool {
+	lgr := s.lgr.With().Str("step", step.Name).Logger()
+
+	if deferErr := s.retrier.Defer(detached, s, step); deferErr != nil {
+		lgr.Error().Err(deferErr).Msg("failed to defer the saga step")
+		return false
+	}
+
+	lgr.Warn().Err(err).Msg("saga step is failed, it is deferred for the background retry")
+	s.observe(step.Name, ResultDeferred)
+	return true
+}
+
+// compensate runs with the detached context: the request may be cancelled, but the undo must still happen
+func (s *Saga) compensate(ctx context.Context, done []Step) {
+	for i := len(done) - 1; i >= 0; i-- {
+		step := done[i]
+		if step.Compensate == nil {
+			continue
+		}
+
+		lgr := s.lgr.With().Str("step", step.Name).Logger()
+		if err := step.Compensate(ctx); err != nil {
+			compensation := Step{Name: step.Name + compensationSuffix, Action: step.Compensate}
+			if step.RetryCompensation && s.deferStep(ctx, compensation, err) {
+				continue
+			}
+			lgr.Error().Err(err).Msg("failed to compensate the saga step")
+			s.observe(step.Name, ResultCompensationFailed)
+			continue
+		}
+		lgr.Info().Msg("saga step is compensated")
+		s.observe(step.Name, ResultCompensated)
+	}
+}
+
+func (s *Saga) observe(step string, result string) {
+	s.prom.SagaStepCount.WithLabelValues(s.name, step, result).Inc()
+}
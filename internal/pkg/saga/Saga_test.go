@@ -0,0 +1,78 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+)
+
+// the exporter registers its metrics globally, so it is created once for all the tests
+var testProm = prometheus.NewExporter("saga_test")
+
+func TestSagaRetryCompensation(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name              string
+		retryCompensation bool
+		failures          int32 // the failed compensations before the successful one
+		wantCompensations int32
+	}{
+		{name: "compensated at once", wantCompensations: 1},
+		{name: "compensation is not retried", failures: 1, wantCompensations: 1},
+		{name: "compensation is retried", retryCompensation: true, failures: 2, wantCompensations: 3},
+		{name: "retries are exhausted", retryCompensation: true, failures: 10, wantCompensations: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retrier := NewRetrier(RetrierConfig{
+				MaxPending:  1,
+				MaxAttempts: 3,
+				StepTimeout: time.Second,
+				MinBackoff:  time.Millisecond,
+				MaxBackoff:  time.Millisecond,
+			}, zerolog.Nop())
+
+			var compensations atomic.Int32
+			err := New("test", retrier, zerolog.Nop(), testProm).
+				AddStep(Step{
+					Name:   "create",
+					Action: func(context.Context) error { return nil },
+					Compensate: func(context.Context) error {
+						if compensations.Add(1) <= tt.failures {
+							return errFailed
+						}
+						return nil
+					},
+					RetryCompensation: tt.retryCompensation,
+				}).
+				AddStep(Step{
+					Name:   "fill",
+					Action: func(context.Context) error { return errFailed },
+				}).
+				Run(context.Background(), context.Background())
+
+			var stepErr *StepError
+			if !errors.As(err, &stepErr) || stepErr.Step != "fill" {
+				t.Fatalf("got %v, want the error of the fill step", err)
+			}
+
+			// Stop waits for the running retries, it abandons only the ones waiting for the backoff
+			deadline := time.Now().Add(time.Second)
+			for compensations.Load() < tt.wantCompensations && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			retrier.Stop()
+
+			if got := compensations.Load(); got != tt.wantCompensations {
+				t.Fatalf("got %d compensations, want %d", got, tt.wantCompensations)
+			}
+		})
+	}
+}
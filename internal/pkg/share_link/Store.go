@@ -0,0 +1,75 @@
+package share_link
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrLinkNotFound = errors.New("share link not found")
+
+// Link is the active share link, it is deleted when revoked
+type Link struct {
+	LinkId       string    `json:"link_id"`
+	CollectionId uint64    `json:"collection_id"`
+	UserId       uint64    `json:"user_id"` // the user created the link
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"` // zero when the link does not expire
+}
+
+// Store keeps the active share links in Redis shared by the instances, the link is the key {prefix}{link id}.
+// The key of the expiring link expires with it, the tokens of the expired links are rejected anyway.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func New(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+func (s *Store) Put(ctx context.Context, link *Link) error {
+	value, err := json.Marshal(link)
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if !link.ExpiresAt.IsZero() {
+		ttl = time.Until(link.ExpiresAt)
+		if ttl <= 0 {
+			return nil
+		}
+	}
+	return s.client.Set(ctx, s.prefix+link.LinkId, value, ttl).Err()
+}
+
+func (s *Store) Get(ctx context.Context, linkId string) (*Link, error) {
+	value, err := s.client.Get(ctx, s.prefix+linkId).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	link := new(Link)
+	if err = json.Unmarshal(value, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *Store) Delete(ctx context.Context, linkId string) error {
+	deleted, err := s.client.Del(ctx, s.prefix+linkId).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package share_link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		after     time.Duration
+		wantErr   error
+	}{
+		{name: "without expiration", after: 365 * 24 * time.Hour},
+		{name: "before expiration", expiresAt: now.Add(time.Hour), after: 30 * time.Minute},
+		{name: "after expiration", expiresAt: now.Add(time.Hour), after: 2 * time.Hour, wantErr: ErrLinkNotFound},
+		{name: "already expired", expiresAt: now.Add(-time.Second), wantErr: ErrLinkNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			t.Cleanup(func() { _ = client.Close() })
+			store := New(client, "test:")
+
+			linkId, err := NewLinkId()
+			if err != nil {
+				t.Fatal(err)
+			}
+			link := &Link{LinkId: linkId, CollectionId: 10, UserId: 1, CreatedAt: now, ExpiresAt: tt.expiresAt}
+			if err = store.Put(ctx, link); err != nil {
+				t.Fatal(err)
+			}
+			server.FastForward(tt.after)
+
+			got, err := store.Get(ctx, linkId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.CollectionId != link.CollectionId || got.UserId != link.UserId {
+				t.Fatalf("Get() = %+v, want %+v", got, link)
+			}
+
+			if err = store.Delete(ctx, linkId); err != nil {
+				t.Fatal(err)
+			}
+			if err = store.Delete(ctx, linkId); !errors.Is(err, ErrLinkNotFound) {
+				t.Fatalf("second Delete() error = %v", err)
+			}
+		})
+	}
+}
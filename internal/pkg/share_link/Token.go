@@ -0,0 +1,92 @@
+package share_link
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid share link token")
+	ErrExpiredToken = errors.New("share link token expired")
+)
+
+const linkIdSize = 16
+
+// Claims are the content of the token, they are not encrypted, only signed
+type Claims struct {
+	LinkId       string // random, the link is revoked by its id
+	CollectionId uint64
+	ExpiresAt    time.Time // the link does not expire while it is zero
+}
+
+// NewLinkId returns the random id. Only the stored links are valid, so a token forged with a leaked secret needs a real id too.
+func NewLinkId() (string, error) {
+	id := make([]byte, linkIdSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// Sign returns the token "payload.signature", both parts are base64url.
+// The payload is the link id, the collection id and the expiration in the unix seconds (0 for none).
+func Sign(secret []byte, claims Claims) (string, error) {
+	linkId, err := hex.DecodeString(claims.LinkId)
+	if err != nil || len(linkId) != linkIdSize {
+		return "", ErrInvalidToken
+	}
+
+	var expiresAt int64
+	if !claims.ExpiresAt.IsZero() {
+		expiresAt = claims.ExpiresAt.Unix()
+	}
+
+	payload := make([]byte, 0, linkIdSize+16)
+	payload = append(payload, linkId...)
+	payload = binary.BigEndian.AppendUint64(payload, claims.CollectionId)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(expiresAt))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signature(secret, payload)), nil
+}
+
+// Verify checks the signature and the expiration of the token, the revocation is checked by the caller
+func Verify(secret []byte, token string, now time.Time) (*Claims, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != linkIdSize+16 {
+		return nil, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(sig, signature(secret, payload)) {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{
+		LinkId:       hex.EncodeToString(payload[:linkIdSize]),
+		CollectionId: binary.BigEndian.Uint64(payload[linkIdSize:]),
+	}
+	if expiresAt := int64(binary.BigEndian.Uint64(payload[linkIdSize+8:])); expiresAt != 0 {
+		claims.ExpiresAt = time.Unix(expiresAt, 0)
+		if !now.Before(claims.ExpiresAt) {
+			return nil, ErrExpiredToken
+		}
+	}
+	return claims, nil
+}
+
+func signature(secret []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
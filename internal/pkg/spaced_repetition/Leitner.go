@@ -0,0 +1,30 @@
+package spaced_repetition
+
+import (
+	"fmt"
+	"time"
+)
+
+// Leitner moves the recalled term to the next box and the forgotten one back to the first box.
+// Every box has its own fixed interval.
+type Leitner struct {
+	intervals []time.Duration
+}
+
+func NewLeitner(levels int, intervals []time.Duration) (*Leitner, error) {
+	if len(intervals) != levels {
+		return nil, fmt.Errorf("leitner needs %d intervals, got %d", levels, len(intervals))
+	}
+	return &Leitner{intervals: intervals}, nil
+}
+
+func (l *Leitner) Next(level int, grade int) int {
+	if grade < PassGrade {
+		return 0
+	}
+	return clampLevel(level+1, len(l.intervals))
+}
+
+func (l *Leitner) Interval(level int) time.Duration {
+	return l.intervals[clampLevel(level, len(l.intervals))]
+}
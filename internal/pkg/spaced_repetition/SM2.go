@@ -0,0 +1,44 @@
+package spaced_repetition
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// SM2 is SuperMemo 2 with the level as the number of the successful repetitions.
+// The ease factor is not stored by vocabulary_api, so the configured one is used for every term.
+type SM2 struct {
+	levels int
+	ease   float64
+}
+
+func NewSM2(levels int, ease float64) (*SM2, error) {
+	if ease < 1.3 {
+		return nil, fmt.Errorf("sm2 ease must be at least 1.3, got %v", ease)
+	}
+	return &SM2{levels: levels, ease: ease}, nil
+}
+
+// Next resets the repetitions of the forgotten term, as SM-2 does for the grades below 3
+func (s *SM2) Next(level int, grade int) int {
+	if grade < PassGrade {
+		return 0
+	}
+	return clampLevel(level+1, s.levels)
+}
+
+// Interval is I(1) = 1 day, I(2) = 6 days, I(n) = I(n-1) * ease; the new term is due at once
+func (s *SM2) Interval(level int) time.Duration {
+	level = clampLevel(level, s.levels)
+	switch level {
+	case 0:
+		return 0
+	case 1:
+		return day
+	default:
+		return time.Duration(float64(6*day) * math.Pow(s.ease, float64(level-2)))
+	}
+}
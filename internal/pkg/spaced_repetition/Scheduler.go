@@ -0,0 +1,57 @@
+package spaced_repetition
+
+import (
+	"fmt"
+	"time"
+)
+
+// Names of the scheduling algorithms
+const (
+	AlgorithmLeitner = "leitner"
+	AlgorithmSM2     = "sm2"
+)
+
+// Grades of the recall, the scale of SM-2: 0 - complete blackout, 5 - perfect response
+const (
+	MinGrade = 0
+	MaxGrade = 5
+
+	// PassGrade the lowest grade, which counts as the recalled term
+	PassGrade = 3
+)
+
+// Scheduler decides when the term is studied next. The term is described by its level:
+// 0 is the new term, levels-1 is the known one.
+type Scheduler interface {
+	// Next returns the level of the term after the review with the grade
+	Next(level int, grade int) int
+	// Interval returns how long the term of the level stays not due after the review
+	Interval(level int) time.Duration
+}
+
+// NewScheduler returns the scheduler of the algorithm for the levels
+func NewScheduler(algorithm string, levels int, leitnerIntervals []time.Duration, sm2Ease float64) (Scheduler, error) {
+	switch algorithm {
+	case AlgorithmLeitner:
+		return NewLeitner(levels, leitnerIntervals)
+	case AlgorithmSM2:
+		return NewSM2(levels, sm2Ease)
+	default:
+		return nil, fmt.Errorf("unknown scheduling algorithm %q", algorithm)
+	}
+}
+
+// DueAt returns the time, when the term reviewed at repeatedAt is due again
+func DueAt(s Scheduler, level int, repeatedAt time.Time) time.Time {
+	return repeatedAt.Add(s.Interval(level))
+}
+
+func clampLevel(level int, levels int) int {
+	if level < 0 {
+		return 0
+	}
+	if level >= levels {
+		return levels - 1
+	}
+	return level
+}
@@ -0,0 +1,116 @@
+package spaced_repetition
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	leitner, err := NewLeitner(5, []time.Duration{0, day, 3 * day, 7 * day, 30 * day})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm2, err := NewSM2(5, 2.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		level int
+		grade int
+		want  int
+	}{
+		{name: "new term recalled", level: 0, grade: PassGrade, want: 1},
+		{name: "perfect response", level: 2, grade: MaxGrade, want: 3},
+		{name: "below the pass grade resets", level: 3, grade: PassGrade - 1, want: 0},
+		{name: "blackout resets", level: 4, grade: MinGrade, want: 0},
+		{name: "known term stays at the last level", level: 4, grade: MaxGrade, want: 4},
+		{name: "level above the last is clamped", level: 10, grade: MaxGrade, want: 4},
+		{name: "negative level is clamped", level: -1, grade: MaxGrade, want: 0},
+	}
+	for _, scheduler := range []struct {
+		name string
+		Scheduler
+	}{{name: AlgorithmLeitner, Scheduler: leitner}, {name: AlgorithmSM2, Scheduler: sm2}} {
+		for _, tt := range tests {
+			t.Run(scheduler.name+"/"+tt.name, func(t *testing.T) {
+				if got := scheduler.Next(tt.level, tt.grade); got != tt.want {
+					t.Fatalf("Next(%d, %d) = %d, want %d", tt.level, tt.grade, got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestInterval(t *testing.T) {
+	leitner, err := NewLeitner(5, []time.Duration{0, day, 3 * day, 7 * day, 30 * day})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm2, err := NewSM2(5, 2.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		level     int
+		want      time.Duration
+	}{
+		{name: "leitner new term is due at once", scheduler: leitner, level: 0, want: 0},
+		{name: "leitner box interval", scheduler: leitner, level: 2, want: 3 * day},
+		{name: "leitner last box", scheduler: leitner, level: 4, want: 30 * day},
+		{name: "leitner level above the last is clamped", scheduler: leitner, level: 7, want: 30 * day},
+		{name: "leitner negative level is clamped", scheduler: leitner, level: -3, want: 0},
+		{name: "sm2 new term is due at once", scheduler: sm2, level: 0, want: 0},
+		{name: "sm2 I(1) is 1 day", scheduler: sm2, level: 1, want: day},
+		{name: "sm2 I(2) is 6 days", scheduler: sm2, level: 2, want: 6 * day},
+		{name: "sm2 I(3) is I(2) * ease", scheduler: sm2, level: 3, want: 15 * day},
+		{name: "sm2 I(4) is I(3) * ease", scheduler: sm2, level: 4, want: 37*day + 12*time.Hour},
+		{name: "sm2 level above the last is clamped", scheduler: sm2, level: 9, want: 37*day + 12*time.Hour},
+		{name: "sm2 negative level is clamped", scheduler: sm2, level: -1, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scheduler.Interval(tt.level); got != tt.want {
+				t.Fatalf("Interval(%d) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewScheduler(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		intervals []time.Duration
+		ease      float64
+		wantErr   bool
+	}{
+		{name: "leitner", algorithm: AlgorithmLeitner, intervals: []time.Duration{0, day, 3 * day}},
+		{name: "leitner without an interval of a level", algorithm: AlgorithmLeitner, intervals: []time.Duration{0, day}, wantErr: true},
+		{name: "sm2", algorithm: AlgorithmSM2, ease: 1.3},
+		{name: "sm2 ease below 1.3", algorithm: AlgorithmSM2, ease: 1.2, wantErr: true},
+		{name: "unknown algorithm", algorithm: "anki", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewScheduler(tt.algorithm, 3, tt.intervals, tt.ease); (err != nil) != tt.wantErr {
+				t.Fatalf("NewScheduler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDueAt(t *testing.T) {
+	sm2, err := NewSM2(5, 2.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repeatedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	if got, want := DueAt(sm2, 2, repeatedAt), repeatedAt.Add(6*day); !got.Equal(want) {
+		t.Fatalf("DueAt() = %v, want %v", got, want)
+	}
+}
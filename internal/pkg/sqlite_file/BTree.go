@@ -0,0 +1,208 @@
+package sqlite_file
+
+import "encoding/binary"
+
+// Types of the b-tree pages
+const (
+	pageTableInterior = 0x05
+	pageTableLeaf     = 0x0D
+)
+
+// The payload limits of the table leaf cell, see "Cell Payload Overflow Pages" of the file format
+const (
+	maxLocal = PageSize - 35
+	minLocal = (PageSize-12)*32/255 - 23
+)
+
+type pager struct {
+	pages [][]byte
+}
+
+// add appends the page and returns its number, the pages are numbered from 1
+func (p *pager) add(page []byte) int {
+	p.pages = append(p.pages, page)
+	return len(p.pages)
+}
+
+type child struct {
+	page   int
+	maxKey int64
+}
+
+// buildTree writes the b-tree of the rows and returns its root page
+func (p *pager) buildTree(rows []row, offset int) int {
+	leaves := p.packLeaves(rows, offset)
+
+	var children []child
+	start := 0
+	for _, leaf := range leaves {
+		count := int(binary.BigEndian.Uint16(leaf[3:]))
+		children = append(children, child{maxKey: lastKey(rows, start, count)})
+		start += count
+	}
+	for i, leaf := range leaves {
+		children[i].page = p.add(leaf)
+	}
+
+	for len(children) > 1 {
+		children = p.packInterior(children)
+	}
+	return children[0].page
+}
+
+func lastKey(rows []row, start int, count int) int64 {
+	if count == 0 {
+		return 0
+	}
+	return rows[start+count-1].rowid
+}
+
+// packLeaves puts the rows into the leaf pages, offset is the space before the page header
+func (p *pager) packLeaves(rows []row, offset int) [][]byte {
+	var leaves [][]byte
+	builder := newPageBuilder(pageTableLeaf, offset)
+	for _, r := range rows {
+		cell := p.leafCell(r)
+		if !builder.fits(cell) {
+			leaves = append(leaves, builder.finish(0))
+			builder = newPageBuilder(pageTableLeaf, 0)
+		}
+		builder.add(cell)
+	}
+	return append(leaves, builder.finish(0))
+}
+
+// packInterior returns the next level of the tree. The page of k children has k-1 cells,
+// the last child is its right-most pointer.
+func (p *pager) packInterior(children []child) []child {
+	var groups [][]child
+	var group []child
+	size := 0
+	for _, c := range children {
+		if len(group) > 0 {
+			// The previous last child gets the cell, when the next child is added
+			cell := len(interiorCell(group[len(group)-1]))
+			if 12+2*len(group)+size+cell > PageSize {
+				groups = append(groups, group)
+				group, size = nil, 0
+			} else {
+				size += cell
+			}
+		}
+		group = append(group, c)
+	}
+	groups = append(groups, group)
+
+	// The page with the only child has no cells, one child is moved from the previous page
+	if n := len(groups); n > 1 && len(groups[n-1]) == 1 {
+		prev := groups[n-2]
+		groups[n-1] = append([]child{prev[len(prev)-1]}, groups[n-1]...)
+		groups[n-2] = prev[:len(prev)-1]
+	}
+
+	parents := make([]child, len(groups))
+	for i, group := range groups {
+		builder := newPageBuilder(pageTableInterior, 0)
+		for _, c := range group[:len(group)-1] {
+			builder.add(interiorCell(c))
+		}
+		last := group[len(group)-1]
+		parents[i] = child{page: p.add(builder.finish(uint32(last.page))), maxKey: last.maxKey}
+	}
+	return parents
+}
+
+func interiorCell(c child) []byte {
+	cell := make([]byte, 4, 13)
+	binary.BigEndian.PutUint32(cell, uint32(c.page))
+	return appendVarint(cell, uint64(c.maxKey))
+}
+
+// leafCell returns the cell of the row, the rest of the large payload is written to the overflow pages
+func (p *pager) leafCell(r row) []byte {
+	payload := r.record
+	cell := appendVarint(nil, uint64(len(payload)))
+	cell = appendVarint(cell, uint64(r.rowid))
+	if len(payload) <= maxLocal {
+		return append(cell, payload...)
+	}
+
+	local := minLocal + (len(payload)-minLocal)%(PageSize-4)
+	if local > maxLocal {
+		local = minLocal
+	}
+	cell = append(cell, payload[:local]...)
+	return binary.BigEndian.AppendUint32(cell, uint32(p.writeOverflow(payload[local:])))
+}
+
+// writeOverflow writes the chain of the overflow pages and returns the first one
+func (p *pager) writeOverflow(data []byte) int {
+	var chunks [][]byte
+	for len(data) > 0 {
+		n := min(len(data), PageSize-4)
+		chunks = append(chunks, data[:n])
+		data = data[n:]
+	}
+
+	// The pages are numbered before they are added, so every page knows the next one
+	first := len(p.pages) + 1
+	for i, chunk := range chunks {
+		page := make([]byte, PageSize)
+		if i < len(chunks)-1 {
+			binary.BigEndian.PutUint32(page, uint32(first+i+1))
+		}
+		copy(page[4:], chunk)
+		p.add(page)
+	}
+	return first
+}
+
+type pageBuilder struct {
+	kind   byte
+	offset int
+	cells  [][]byte
+	size   int
+}
+
+func newPageBuilder(kind byte, offset int) *pageBuilder {
+	return &pageBuilder{kind: kind, offset: offset}
+}
+
+func (b *pageBuilder) headerSize() int {
+	if b.kind == pageTableInterior {
+		return 12
+	}
+	return 8
+}
+
+func (b *pageBuilder) fits(cell []byte) bool {
+	used := b.offset + b.headerSize() + 2*(len(b.cells)+1) + b.size + len(cell)
+	return used <= PageSize
+}
+
+func (b *pageBuilder) add(cell []byte) {
+	b.cells = append(b.cells, cell)
+	b.size += len(cell)
+}
+
+// finish returns the page, rightMost is the right-most pointer of the interior page
+func (b *pageBuilder) finish(rightMost uint32) []byte {
+	page := make([]byte, PageSize)
+	header := page[b.offset:]
+	header[0] = b.kind
+	binary.BigEndian.PutUint16(header[3:], uint16(len(b.cells)))
+	if b.kind == pageTableInterior {
+		binary.BigEndian.PutUint32(header[8:], rightMost)
+	}
+
+	// The cells are written from the end of the page, the pointers follow the header
+	content := PageSize
+	pointers := header[b.headerSize():]
+	for i, cell := range b.cells {
+		content -= len(cell)
+		copy(page[content:], cell)
+		binary.BigEndian.PutUint16(pointers[2*i:], uint16(content))
+	}
+	binary.BigEndian.PutUint16(header[5:], uint16(content))
+	return page
+}
@@ -0,0 +1,113 @@
+package sqlite_file
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// PageSize of the written database
+const PageSize = 4096
+
+// sqliteVersion is written to the header as the version of the library, which wrote the file last
+const sqliteVersion = 3045000
+
+// Database is built in memory and written as the SQLite 3 file. It supports the tables only:
+// no indexes, no updates, the rows are kept in the order of their rowid.
+type Database struct {
+	tables []*Table
+}
+
+type Table struct {
+	name string
+	sql  string
+	rows []row
+}
+
+type row struct {
+	rowid  int64
+	record []byte
+}
+
+func New() *Database {
+	return &Database{}
+}
+
+// CreateTable adds the table, sql is the CREATE TABLE statement stored in sqlite_master
+func (db *Database) CreateTable(name string, sql string) *Table {
+	table := &Table{name: name, sql: sql}
+	db.tables = append(db.tables, table)
+	return table
+}
+
+// Insert adds the row. The values are nil, int, int64, float64, string or []byte.
+// The INTEGER PRIMARY KEY column is the alias of rowid, its value must be nil.
+func (t *Table) Insert(rowid int64, values ...any) error {
+	record, err := encodeRecord(values)
+	if err != nil {
+		return fmt.Errorf("table %s, rowid %d: %w", t.name, rowid, err)
+	}
+	t.rows = append(t.rows, row{rowid: rowid, record: record})
+	return nil
+}
+
+// WriteTo writes the database file
+func (db *Database) WriteTo(w io.Writer) (int64, error) {
+	// Page 1 is the root of sqlite_master, the tables follow it
+	pages := &pager{pages: [][]byte{nil}}
+
+	master := &Table{name: "sqlite_master"}
+	for i, table := range db.tables {
+		sort.Slice(table.rows, func(a, b int) bool { return table.rows[a].rowid < table.rows[b].rowid })
+		for j := 1; j < len(table.rows); j++ {
+			if table.rows[j].rowid == table.rows[j-1].rowid {
+				return 0, fmt.Errorf("table %s: duplicated rowid %d", table.name, table.rows[j].rowid)
+			}
+		}
+
+		root := pages.buildTree(table.rows, 0)
+		if err := master.Insert(int64(i+1), "table", table.name, table.name, int64(root), table.sql); err != nil {
+			return 0, err
+		}
+	}
+
+	leaves := pages.packLeaves(master.rows, headerSize)
+	if len(leaves) != 1 {
+		return 0, errors.New("sqlite_master does not fit in the first page")
+	}
+	pages.pages[0] = leaves[0]
+
+	writeHeader(pages.pages[0], len(pages.pages))
+
+	var written int64
+	for _, page := range pages.pages {
+		n, err := w.Write(page)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+const headerSize = 100
+
+func writeHeader(page []byte, pageCount int) {
+	copy(page, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(page[16:], PageSize)
+	page[18] = 1                             // file format write version: legacy
+	page[19] = 1                             // file format read version: legacy
+	page[20] = 0                             // reserved space per page
+	page[21] = 64                            // maximum embedded payload fraction
+	page[22] = 32                            // minimum embedded payload fraction
+	page[23] = 32                            // leaf payload fraction
+	binary.BigEndian.PutUint32(page[24:], 1) // file change counter
+	binary.BigEndian.PutUint32(page[28:], uint32(pageCount))
+	binary.BigEndian.PutUint32(page[40:], 1) // schema cookie
+	binary.BigEndian.PutUint32(page[44:], 4) // schema format number
+	binary.BigEndian.PutUint32(page[56:], 1) // text encoding: UTF-8
+	binary.BigEndian.PutUint32(page[92:], 1) // version-valid-for, equals the change counter
+	binary.BigEndian.PutUint32(page[96:], sqliteVersion)
+}
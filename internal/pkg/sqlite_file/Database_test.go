@@ -0,0 +1,234 @@
+package sqlite_file
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// openWritten writes the database to the file and opens it with the SQLite driver,
+// the file must pass the integrity check
+func openWritten(t *testing.T, db *Database) (*sql.DB, []byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if _, err := db.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	integrityCheck(t, conn)
+	return conn, buf.Bytes()
+}
+
+// integrityCheck fails the test unless PRAGMA integrity_check reports ok
+func integrityCheck(t *testing.T, conn *sql.DB) {
+	t.Helper()
+
+	rows, err := conn.Query("PRAGMA integrity_check")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var problem string
+		if err = rows.Scan(&problem); err != nil {
+			t.Fatal(err)
+		}
+		problems = append(problems, problem)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0] != "ok" {
+		t.Fatalf("integrity check: %s", strings.Join(problems, "; "))
+	}
+}
+
+// treeDepth returns the levels of the interior pages above the leaves of the table
+func treeDepth(t *testing.T, file []byte, root int) int {
+	t.Helper()
+
+	depth := 0
+	page := root
+	for {
+		start := (page - 1) * PageSize
+		if page == 1 {
+			start += headerSize
+		}
+		switch file[start] {
+		case pageTableLeaf:
+			return depth
+		case pageTableInterior:
+			depth++
+			page = int(binary.BigEndian.Uint32(file[start+8:])) // the right-most child
+		default:
+			t.Fatalf("page %d has the type %#x", page, file[start])
+		}
+	}
+}
+
+func TestDatabaseRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      int
+		value     func(i int) string
+		wantDepth int // the interior levels, at least
+	}{
+		{
+			name:  "one leaf",
+			rows:  10,
+			value: func(i int) string { return strings.Repeat("a", i) },
+		},
+		{
+			name: "payload on the limit of the cell",
+			rows: 3,
+			// the record adds the header of 3 bytes to the string of 2 bytes per serial type
+			value: func(i int) string { return strings.Repeat("b", maxLocal-4+i) },
+		},
+		{
+			name:  "overflow pages",
+			rows:  4,
+			value: func(i int) string { return strings.Repeat(string(rune('c'+i)), 5000*(i+1)) },
+		},
+		{
+			name:  "large overflow chain",
+			rows:  2,
+			value: func(i int) string { return strings.Repeat("é", 100_000+i) },
+		},
+		{
+			name:      "one interior level",
+			rows:      500,
+			value:     func(i int) string { return strings.Repeat("d", 100+i%50) },
+			wantDepth: 1,
+		},
+		{
+			// 4 rows per leaf, more leaves than the cells of one interior page
+			name:      "two interior levels",
+			rows:      3000,
+			value:     func(i int) string { return strings.Repeat("e", 900+i%10) },
+			wantDepth: 2,
+		},
+		{
+			name:      "interior level of the overflowing rows",
+			rows:      1500,
+			value:     func(i int) string { return strings.Repeat("f", 4500+i%7) },
+			wantDepth: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := New()
+			table := db.CreateTable("t", "CREATE TABLE t (id integer primary key, value text not null, n integer)")
+			// inserted out of the order, WriteTo sorts the rows by rowid
+			for i := tt.rows - 1; i >= 0; i-- {
+				if err := table.Insert(int64(i+1), nil, tt.value(i), int64(i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			conn, file := openWritten(t, db)
+
+			var root int
+			if err := conn.QueryRow("SELECT rootpage FROM sqlite_master WHERE name = 't'").Scan(&root); err != nil {
+				t.Fatal(err)
+			}
+			if depth := treeDepth(t, file, root); depth < tt.wantDepth {
+				t.Fatalf("got %d interior levels, want at least %d", depth, tt.wantDepth)
+			}
+
+			rows, err := conn.Query("SELECT id, value, n FROM t ORDER BY id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			count := 0
+			for rows.Next() {
+				var id, n int64
+				var value string
+				if err = rows.Scan(&id, &value, &n); err != nil {
+					t.Fatal(err)
+				}
+				i := int(id - 1)
+				if i != count || n != int64(i) || value != tt.value(i) {
+					t.Fatalf("row %d: got id %d, n %d and the value of %d bytes", count, id, n, len(value))
+				}
+				count++
+			}
+			if err = rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if count != tt.rows {
+				t.Fatalf("got %d rows, want %d", count, tt.rows)
+			}
+		})
+	}
+}
+
+func TestDatabaseValueTypes(t *testing.T) {
+	values := []any{
+		nil, 0, 1, int64(-1), int64(127), int64(-128), int64(32767), int64(-32769), int64(1 << 23), int64(-1<<23 - 1),
+		int64(1 << 31), int64(-1 << 40), int64(1 << 47), int64(-1 << 62), 3.25, "", "текст", []byte{0, 1, 2}, []byte{},
+	}
+
+	db := New()
+	table := db.CreateTable("v", "CREATE TABLE v (id integer primary key, value)")
+	for i, value := range values {
+		if err := table.Insert(int64(i+1), nil, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conn, _ := openWritten(t, db)
+
+	for i, want := range values {
+		var got any
+		if err := conn.QueryRow("SELECT value FROM v WHERE id = ?", i+1).Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+
+		switch want := want.(type) {
+		case int:
+			if got != int64(want) {
+				t.Fatalf("row %d: got %#v, want %d", i+1, got, want)
+			}
+		case []byte:
+			if b, ok := got.([]byte); !ok || !bytes.Equal(b, want) {
+				t.Fatalf("row %d: got %#v, want %v", i+1, got, want)
+			}
+		default:
+			if got != want {
+				t.Fatalf("row %d: got %#v, want %#v", i+1, got, want)
+			}
+		}
+	}
+}
+
+func TestDatabaseDuplicatedRowid(t *testing.T) {
+	db := New()
+	table := db.CreateTable("t", "CREATE TABLE t (id integer primary key)")
+	_ = table.Insert(1, nil)
+	_ = table.Insert(1, nil)
+
+	if _, err := db.WriteTo(&bytes.Buffer{}); err == nil {
+		t.Fatal("got nil, want the duplicated rowid error")
+	}
+}
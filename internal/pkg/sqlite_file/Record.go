@@ -0,0 +1,91 @@
+package sqlite_file
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// encodeRecord returns the record of the values in the SQLite record format
+func encodeRecord(values []any) ([]byte, error) {
+	var types []byte
+	var body []byte
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			types = appendVarint(types, 0)
+		case int:
+			types, body = appendInt(types, body, int64(v))
+		case int64:
+			types, body = appendInt(types, body, v)
+		case float64:
+			types = appendVarint(types, 7)
+			body = binary.BigEndian.AppendUint64(body, math.Float64bits(v))
+		case string:
+			types = appendVarint(types, uint64(2*len(v)+13))
+			body = append(body, v...)
+		case []byte:
+			types = appendVarint(types, uint64(2*len(v)+12))
+			body = append(body, v...)
+		default:
+			return nil, fmt.Errorf("unsupported value type %T", value)
+		}
+	}
+
+	// The header size includes its own varint
+	headerSize := len(types) + 1
+	for len(appendVarint(nil, uint64(headerSize)))+len(types) != headerSize {
+		headerSize++
+	}
+
+	record := appendVarint(nil, uint64(headerSize))
+	record = append(record, types...)
+	return append(record, body...), nil
+}
+
+// appendInt uses the smallest serial type of the integer
+func appendInt(types []byte, body []byte, v int64) ([]byte, []byte) {
+	switch {
+	case v == 0:
+		return appendVarint(types, 8), body
+	case v == 1:
+		return appendVarint(types, 9), body
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return appendVarint(types, 1), append(body, byte(v))
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return appendVarint(types, 2), binary.BigEndian.AppendUint16(body, uint16(v))
+	case v >= -1<<23 && v < 1<<23:
+		return appendVarint(types, 3), append(body, byte(v>>16), byte(v>>8), byte(v))
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return appendVarint(types, 4), binary.BigEndian.AppendUint32(body, uint32(v))
+	case v >= -1<<47 && v < 1<<47:
+		return appendVarint(types, 5), append(body, byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return appendVarint(types, 6), binary.BigEndian.AppendUint64(body, uint64(v))
+	}
+}
+
+// appendVarint writes the SQLite varint: big-endian 7 bits per byte, the 9th byte keeps all 8 bits
+func appendVarint(b []byte, v uint64) []byte {
+	if v > 0x00ffffffffffffff {
+		var buf [9]byte
+		buf[8] = byte(v)
+		v >>= 8
+		for i := 7; i >= 0; i-- {
+			buf[i] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return append(b, buf[:]...)
+	}
+
+	var buf [8]byte
+	i := len(buf) - 1
+	buf[i] = byte(v & 0x7f)
+	v >>= 7
+	for v > 0 {
+		i--
+		buf[i] = byte(v&0x7f) | 0x80
+		v >>= 7
+	}
+	return append(b, buf[i:]...)
+}
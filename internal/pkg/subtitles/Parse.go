@@ -0,0 +1,169 @@
+package subtitles
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of the subtitle file
+const (
+	FormatAuto = "auto"
+	FormatSRT  = "srt"
+	FormatVTT  = "vtt" // WebVTT
+)
+
+var ErrNoCues = errors.New("no cues in the file")
+
+// Cue is the subtitle shown on the screen, the text is without the formatting
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string // the lines of the dialogue are separated by the empty line
+}
+
+func IsFormat(format string) bool {
+	switch format {
+	case FormatAuto, FormatSRT, FormatVTT:
+		return true
+	}
+	return false
+}
+
+var (
+	// the timings are "hh:mm:ss,ttt" in SRT and "[hh:]mm:ss.ttt" in WebVTT, the cue settings may follow
+	timingRe = regexp.MustCompile(`^\s*((?:\d+:)?\d{1,2}:\d{1,2}[.,]\d{1,3})\s*-->\s*((?:\d+:)?\d{1,2}:\d{1,2}[.,]\d{1,3})`)
+	// the ruby text is the pronunciation above the characters, it is not the text itself
+	rubyTextRe = regexp.MustCompile(`(?is)<rt>.*?</rt>`)
+	// <i>, <font color="...">, <c.yellow>, <v Speaker>, <00:00:01.000> and the closing tags
+	tagRe = regexp.MustCompile(`<[^<>]*>`)
+	// the override tags of ASS kept in SRT: {\an8}, {\i1}
+	assTagRe = regexp.MustCompile(`\{\\[^{}]*\}`)
+	// the descriptions of the sounds for the hearing impaired: [door slams]
+	soundRe = regexp.MustCompile(`\[[^\[\]]*\]`)
+	// the name of the speaker at the start of the line: "JOHN:"
+	speakerRe = regexp.MustCompile(`^\p{Lu}[\p{Lu}\s.'-]*:\s*`)
+	// the blocks are separated by the empty lines
+	blockSeparatorRe = regexp.MustCompile(`\n[ \t]*\n`)
+)
+
+// Parse returns the cues of the SRT or WebVTT file in the order of the file, the cues without the text are skipped.
+// The format is detected by the WEBVTT header when it is auto.
+func Parse(text string, format string) ([]*Cue, string, error) {
+	text = strings.TrimPrefix(strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n"), "\ufeff")
+	isVTT := strings.HasPrefix(text, "WEBVTT")
+	switch format {
+	case FormatAuto:
+		format = FormatSRT
+		if isVTT {
+			format = FormatVTT
+		}
+	case FormatVTT:
+		if !isVTT {
+			return nil, "", errors.New("the WEBVTT header is missing")
+		}
+	}
+
+	var cues []*Cue
+	// the header, NOTE, STYLE and REGION blocks of WebVTT have no timing
+	for _, block := range blockSeparatorRe.Split(text, -1) {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		timing := -1
+		for i, line := range lines {
+			if timingRe.MatchString(line) {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue
+		}
+
+		match := timingRe.FindStringSubmatch(lines[timing])
+		start, err := parseTimestamp(match[1])
+		if err != nil {
+			return nil, format, fmt.Errorf("line %q: %w", lines[timing], err)
+		}
+		end, err := parseTimestamp(match[2])
+		if err != nil {
+			return nil, format, fmt.Errorf("line %q: %w", lines[timing], err)
+		}
+
+		if cueText := clean(lines[timing+1:]); cueText != "" {
+			cues = append(cues, &Cue{Start: start, End: end, Text: cueText})
+		}
+	}
+
+	if len(cues) == 0 {
+		return nil, format, ErrNoCues
+	}
+	return cues, format, nil
+}
+
+// lineTrim the notes of the songs, the spaces and the direction marks around the line
+const lineTrim = "♪♫ \t\u200e\u200f"
+
+// clean removes the formatting and joins the lines of the cue. The lines of one speaker are joined by the space,
+// the lines starting with the dash are the different speakers.
+func clean(lines []string) string {
+	text := strings.Join(lines, "\n")
+	text = rubyTextRe.ReplaceAllString(text, "")
+	text = tagRe.ReplaceAllString(text, "")
+	text = assTagRe.ReplaceAllString(text, "")
+	text = soundRe.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	var speakers []string
+	for _, line := range strings.Split(text, "\n") {
+		line, dialogue := strings.CutPrefix(strings.TrimSpace(strings.Trim(line, lineTrim)), "-")
+		// the song is marked after the dash too: "- ♪ Yesterday ♪"
+		line = strings.Join(strings.Fields(speakerRe.ReplaceAllString(strings.TrimSpace(strings.Trim(line, lineTrim)), "")), " ")
+		if line == "" {
+			continue
+		}
+
+		if dialogue || len(speakers) == 0 {
+			speakers = append(speakers, line)
+			continue
+		}
+		speakers[len(speakers)-1] += " " + line
+	}
+	return strings.Join(speakers, "\n\n")
+}
+
+// parseTimestamp parses "[hh:]mm:ss.ttt", the comma of SRT is accepted as well
+func parseTimestamp(value string) (time.Duration, error) {
+	clock, fraction, _ := strings.Cut(strings.Replace(value, ",", ".", 1), ".")
+	parts := strings.Split(clock, ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+
+	var result time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0, err
+		}
+		if i > 0 && n >= 60 {
+			return 0, fmt.Errorf("invalid timestamp %q", value)
+		}
+		result += time.Duration(n) * unit
+	}
+
+	milliseconds, err := strconv.Atoi((fraction + "00")[:3])
+	if err != nil {
+		return 0, err
+	}
+	return result + time.Duration(milliseconds)*time.Millisecond, nil
+}
+
+// Timestamp formats the time of the cue as "hh:mm:ss"
+func Timestamp(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
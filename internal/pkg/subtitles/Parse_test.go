@@ -0,0 +1,125 @@
+package subtitles
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		format     string
+		wantFormat string
+		want       []Cue
+		wantErr    bool
+	}{
+		{
+			name:       "srt",
+			text:       "1\r\n00:00:01,500 --> 00:00:03,000\r\nHello there.\r\n\r\n2\r\n01:02:03,04 --> 01:02:05,999\r\nGeneral Kenobi!\r\n",
+			format:     FormatAuto,
+			wantFormat: FormatSRT,
+			want: []Cue{
+				{Start: 1500 * time.Millisecond, End: 3 * time.Second, Text: "Hello there."},
+				{Start: time.Hour + 2*time.Minute + 3*time.Second + 40*time.Millisecond, End: time.Hour + 2*time.Minute + 5999*time.Millisecond, Text: "General Kenobi!"},
+			},
+		},
+		{
+			name:       "vtt without hours and with settings",
+			text:       "\ufeffWEBVTT\n\nNOTE the comment\n\ncue-1\n00:01.000 --> 00:02.500 align:start line:0\n<v John>Hi</v>\n",
+			format:     FormatAuto,
+			wantFormat: FormatVTT,
+			want:       []Cue{{Start: time.Second, End: 2500 * time.Millisecond, Text: "Hi"}},
+		},
+		{
+			name:       "tags",
+			text:       "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}<i>Tom &amp; <font color=\"red\">Jerry</font></i> [laughs]\n",
+			format:     FormatSRT,
+			wantFormat: FormatSRT,
+			want:       []Cue{{Start: time.Second, End: 2 * time.Second, Text: "Tom & Jerry"}},
+		},
+		{
+			name:       "ruby text",
+			text:       "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\n<ruby>漢<rt>kan</rt>字<rt>ji</rt></ruby>\n",
+			format:     FormatVTT,
+			wantFormat: FormatVTT,
+			want:       []Cue{{Start: time.Second, End: 2 * time.Second, Text: "漢字"}},
+		},
+		{
+			name:       "multi-line cue of one speaker",
+			text:       "1\n00:00:01,000 --> 00:00:02,000\nJOHN: I was going\nto the store\n",
+			format:     FormatSRT,
+			wantFormat: FormatSRT,
+			want:       []Cue{{Start: time.Second, End: 2 * time.Second, Text: "I was going to the store"}},
+		},
+		{
+			name:       "dialogue",
+			text:       "1\n00:00:01,000 --> 00:00:02,000\n- Who?\n- ♪ Me ♪\n",
+			format:     FormatSRT,
+			wantFormat: FormatSRT,
+			want:       []Cue{{Start: time.Second, End: 2 * time.Second, Text: "Who?\n\nMe"}},
+		},
+		{
+			name:       "cue without the text is skipped",
+			text:       "1\n00:00:01,000 --> 00:00:02,000\n[music]\n\n2\n00:00:03,000 --> 00:00:04,000\nYes\n",
+			format:     FormatSRT,
+			wantFormat: FormatSRT,
+			want:       []Cue{{Start: 3 * time.Second, End: 4 * time.Second, Text: "Yes"}},
+		},
+		{
+			name:    "vtt without the header",
+			text:    "00:00:01.000 --> 00:00:02.000\nHi\n",
+			format:  FormatVTT,
+			wantErr: true,
+		},
+		{
+			name:       "invalid minutes",
+			text:       "1\n00:61:01,000 --> 00:61:02,000\nHi\n",
+			format:     FormatSRT,
+			wantFormat: FormatSRT,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cues, format, err := Parse(tt.text, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if format != tt.wantFormat {
+				t.Fatalf("Parse() format = %q, want %q", format, tt.wantFormat)
+			}
+			if len(cues) != len(tt.want) {
+				t.Fatalf("Parse() = %d cues, want %d", len(cues), len(tt.want))
+			}
+			for i, cue := range cues {
+				if *cue != tt.want[i] {
+					t.Fatalf("cue %d = %+v, want %+v", i, *cue, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseNoCues(t *testing.T) {
+	if _, _, err := Parse("WEBVTT\n\nNOTE nothing\n", FormatAuto); !errors.Is(err, ErrNoCues) {
+		t.Fatalf("Parse() error = %v, want %v", err, ErrNoCues)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "00:00:00"},
+		{d: 59*time.Second + 999*time.Millisecond, want: "00:00:59"},
+		{d: 25*time.Hour + 3*time.Minute + 4*time.Second, want: "25:03:04"},
+	}
+	for _, tt := range tests {
+		if got := Timestamp(tt.d); got != tt.want {
+			t.Fatalf("Timestamp(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package term_import
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Encodings, which can be used when the file is not UTF-8 and has no BOM
+var fallbackEncodings = map[string]encoding.Encoding{
+	"windows-1251": charmap.Windows1251,
+	"windows-1252": charmap.Windows1252,
+	"koi8-r":       charmap.KOI8R,
+	"iso-8859-1":   charmap.ISO8859_1,
+}
+
+func IsFallbackEncoding(name string) bool {
+	_, ok := fallbackEncodings[name]
+	return ok
+}
+
+// Decode converts the file to UTF-8. The encoding is detected by BOM, the file without BOM
+// is UTF-8 when it is valid, otherwise it is decoded with the fallback encoding.
+func Decode(data []byte, fallback string) (string, string, error) {
+	var enc encoding.Encoding
+	var name string
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:]), "utf-8", nil
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		enc, name = unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), "utf-16le"
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		enc, name = unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), "utf-16be"
+	case utf8.Valid(data):
+		return string(data), "utf-8", nil
+	default:
+		var ok bool
+		if enc, ok = fallbackEncodings[fallback]; !ok {
+			return "", "", fmt.Errorf("unknown fallback encoding %q", fallback)
+		}
+		name = fallback
+	}
+
+	decoded, _, err := transform.Bytes(enc.NewDecoder(), data)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return string(decoded), name, nil
+}
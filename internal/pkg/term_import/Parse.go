@@ -0,0 +1,237 @@
+package term_import
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Formats of the imported file
+const (
+	FormatAuto    = "auto"
+	FormatCSV     = "csv"
+	FormatTSV     = "tsv"
+	FormatQuizlet = "quizlet" // the export of Quizlet: "term<TAB>definition" per line, without quoting
+)
+
+// MaxTextLength the longest term, meaning or example in runes
+const MaxTextLength = 1024
+
+// headerNames are the recognized names of the header columns
+var headerNames = map[string]string{
+	"term":        columnTerm,
+	"word":        columnTerm,
+	"front":       columnTerm,
+	"meaning":     columnMeaning,
+	"definition":  columnMeaning,
+	"translation": columnMeaning,
+	"back":        columnMeaning,
+	"example":     columnExample,
+	"image":       columnImageUrl,
+	"image_url":   columnImageUrl,
+	"imageurl":    columnImageUrl,
+}
+
+const (
+	columnTerm     = "term"
+	columnMeaning  = "meaning"
+	columnExample  = "example"
+	columnImageUrl = "image_url"
+)
+
+// The columns of the file without the header
+var defaultColumns = map[string]int{columnTerm: 0, columnMeaning: 1, columnExample: 2, columnImageUrl: 3}
+
+type Row struct {
+	Line     int // the line of the file, from 1
+	Term     string
+	Meaning  string
+	Example  string
+	ImageUrl string
+}
+
+func IsFormat(format string) bool {
+	switch format {
+	case FormatAuto, FormatCSV, FormatTSV, FormatQuizlet:
+		return true
+	}
+	return false
+}
+
+// Parse returns the rows of the file and its format, which is detected for FormatAuto.
+// The first line is the header, when it names the term and meaning columns.
+func Parse(text string, format string) ([]*Row, string, error) {
+	if format == FormatAuto {
+		format = detectFormat(text)
+	}
+
+	var records [][]string
+	var lines []int
+	var err error
+	switch format {
+	case FormatQuizlet:
+		records, lines = splitQuizlet(text)
+	case FormatCSV:
+		records, lines, err = readDelimited(text, detectDelimiter(text, []rune{',', ';'}))
+	case FormatTSV:
+		records, lines, err = readDelimited(text, '\t')
+	default:
+		return nil, "", fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return nil, format, err
+	}
+
+	columns := defaultColumns
+	if len(records) > 0 {
+		if header, ok := parseHeader(records[0]); ok {
+			columns = header
+			records, lines = records[1:], lines[1:]
+		}
+	}
+
+	rows := make([]*Row, 0, len(records))
+	for i, record := range records {
+		rows = append(rows, &Row{
+			Line:     lines[i],
+			Term:     field(record, columns, columnTerm),
+			Meaning:  field(record, columns, columnMeaning),
+			Example:  field(record, columns, columnExample),
+			ImageUrl: field(record, columns, columnImageUrl),
+		})
+	}
+	return rows, format, nil
+}
+
+func (r *Row) Validate() error {
+	if r.Term == "" {
+		return errors.New("term is empty")
+	}
+	if r.Meaning == "" {
+		return errors.New("meaning is empty")
+	}
+	for name, value := range map[string]string{columnTerm: r.Term, columnMeaning: r.Meaning, columnExample: r.Example} {
+		if utf8.RuneCountInString(value) > MaxTextLength {
+			return fmt.Errorf("%s is longer than %d characters", name, MaxTextLength)
+		}
+	}
+	if r.ImageUrl != "" {
+		u, err := url.Parse(r.ImageUrl)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("image_url is not a http(s) url")
+		}
+	}
+	return nil
+}
+
+// detectFormat takes the delimiter, which splits the first lines into the same number of columns
+func detectFormat(text string) string {
+	if detectDelimiter(text, []rune{'\t', ',', ';'}) == '\t' {
+		return FormatTSV
+	}
+	return FormatCSV
+}
+
+func detectDelimiter(text string, candidates []rune) rune {
+	var sample []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sample = append(sample, line)
+		}
+		if len(sample) == 10 {
+			break
+		}
+	}
+
+	for _, delimiter := range candidates {
+		consistent := len(sample) > 0
+		count := -1
+		for _, line := range sample {
+			n := strings.Count(line, string(delimiter))
+			if n == 0 || (count != -1 && n != count) {
+				consistent = false
+				break
+			}
+			count = n
+		}
+		if consistent {
+			return delimiter
+		}
+	}
+
+	// The quoted values may contain the delimiter, the most frequent one is taken then
+	best, bestCount := candidates[0], 0
+	for _, delimiter := range candidates {
+		if n := strings.Count(strings.Join(sample, "\n"), string(delimiter)); n > bestCount {
+			best, bestCount = delimiter, n
+		}
+	}
+	return best
+}
+
+func readDelimited(text string, delimiter rune) ([][]string, []int, error) {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	return records, lines, nil
+}
+
+func splitQuizlet(text string) ([][]string, []int) {
+	var records [][]string
+	var lines []int
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		records = append(records, strings.SplitN(line, "\t", 2))
+		lines = append(lines, i+1)
+	}
+	return records, lines
+}
+
+func parseHeader(record []string) (map[string]int, bool) {
+	columns := map[string]int{}
+	for i, name := range record {
+		column, ok := headerNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			continue
+		}
+		if _, ok = columns[column]; !ok {
+			columns[column] = i
+		}
+	}
+
+	_, hasTerm := columns[columnTerm]
+	_, hasMeaning := columns[columnMeaning]
+	return columns, hasTerm && hasMeaning
+}
+
+func field(record []string, columns map[string]int, column string) string {
+	i, ok := columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
@@ -0,0 +1,158 @@
+package term_import
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		format     string
+		wantFormat string
+		want       []Row
+	}{
+		{
+			name:       "csv without the header",
+			text:       "cat,кот\ndog,собака,\"Good dog, sit\"\n",
+			format:     FormatAuto,
+			wantFormat: FormatCSV,
+			want: []Row{
+				{Line: 1, Term: "cat", Meaning: "кот"},
+				{Line: 2, Term: "dog", Meaning: "собака", Example: "Good dog, sit"},
+			},
+		},
+		{
+			name:       "semicolon csv",
+			text:       "cat;кот;a, b\ndog;собака;c\n",
+			format:     FormatCSV,
+			wantFormat: FormatCSV,
+			want: []Row{
+				{Line: 1, Term: "cat", Meaning: "кот", Example: "a, b"},
+				{Line: 2, Term: "dog", Meaning: "собака", Example: "c"},
+			},
+		},
+		{
+			name:       "tsv with the header",
+			text:       "Example\tBack\tFront\nA cat.\tкот\tcat\n",
+			format:     FormatAuto,
+			wantFormat: FormatTSV,
+			want:       []Row{{Line: 2, Term: "cat", Meaning: "кот", Example: "A cat."}},
+		},
+		{
+			name:       "quoted value with the line break",
+			text:       "term,meaning\n\"two\nlines\",два\nend,конец\n",
+			format:     FormatAuto,
+			wantFormat: FormatCSV,
+			want: []Row{
+				{Line: 2, Term: "two\nlines", Meaning: "два"},
+				{Line: 4, Term: "end", Meaning: "конец"},
+			},
+		},
+		{
+			name:       "quizlet keeps the tabs and the quotes of the meaning",
+			text:       "cat\t\"кот\"\tкошка\r\n\r\ndog\tсобака\r\n",
+			format:     FormatQuizlet,
+			wantFormat: FormatQuizlet,
+			want: []Row{
+				{Line: 1, Term: "cat", Meaning: "\"кот\"\tкошка"},
+				{Line: 3, Term: "dog", Meaning: "собака"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, format, err := Parse(tt.text, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tt.wantFormat {
+				t.Fatalf("Parse() format = %q, want %q", format, tt.wantFormat)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("Parse() = %d rows, want %d", len(rows), len(tt.want))
+			}
+			for i, row := range rows {
+				if *row != tt.want[i] {
+					t.Fatalf("row %d = %+v, want %+v", i, *row, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDetectDelimiter(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want rune
+	}{
+		{name: "consistent comma", text: "a,b\nc,d\n", want: ','},
+		{name: "consistent semicolon", text: "a;b,c\nd;e\n", want: ';'},
+		{name: "inconsistent, the most frequent", text: "a;b;c\nd;e\n\"f,g\";h\n", want: ';'},
+		{name: "no delimiter, the first candidate", text: "a\nb\n", want: ','},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectDelimiter(tt.text, []rune{',', ';'}); got != tt.want {
+				t.Fatalf("detectDelimiter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRowValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     Row
+		wantErr bool
+	}{
+		{name: "valid", row: Row{Term: "cat", Meaning: "кот", ImageUrl: "https://example.com/cat.png"}},
+		{name: "no term", row: Row{Meaning: "кот"}, wantErr: true},
+		{name: "no meaning", row: Row{Term: "cat"}, wantErr: true},
+		{name: "not a http url", row: Row{Term: "cat", Meaning: "кот", ImageUrl: "file:///etc/passwd"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.row.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	cp1251, err := charmap.Windows1251.NewEncoder().String("кот")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		data         []byte
+		fallback     string
+		want         string
+		wantEncoding string
+		wantErr      bool
+	}{
+		{name: "utf-8", data: []byte("кот"), want: "кот", wantEncoding: "utf-8"},
+		{name: "utf-8 bom", data: []byte("\xEF\xBB\xBFкот"), want: "кот", wantEncoding: "utf-8"},
+		{name: "utf-16le bom", data: []byte{0xFF, 0xFE, 'c', 0, 'a', 0, 't', 0}, want: "cat", wantEncoding: "utf-16le"},
+		{name: "utf-16be bom", data: []byte{0xFE, 0xFF, 0, 'c', 0, 'a', 0, 't'}, want: "cat", wantEncoding: "utf-16be"},
+		{name: "fallback", data: []byte(cp1251), fallback: "windows-1251", want: "кот", wantEncoding: "windows-1251"},
+		{name: "unknown fallback", data: []byte(cp1251), fallback: "utf-7", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, encoding, err := Decode(tt.data, tt.fallback)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || encoding != tt.wantEncoding {
+				t.Fatalf("Decode() = %q, %q, want %q, %q", got, encoding, tt.want, tt.wantEncoding)
+			}
+		})
+	}
+}
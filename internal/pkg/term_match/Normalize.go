@@ -0,0 +1,73 @@
+package term_match
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/kljensen/snowball/english"
+	"github.com/kljensen/snowball/french"
+	"github.com/kljensen/snowball/hungarian"
+	"github.com/kljensen/snowball/norwegian"
+	"github.com/kljensen/snowball/russian"
+	"github.com/kljensen/snowball/spanish"
+	"github.com/kljensen/snowball/swedish"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Options of the comparison, the defaults compare the terms by NFKC, case folding and whitespace only
+type Options struct {
+	IgnoreDiacritics bool // "café" matches "cafe", off by default as the marks are meaningful in many languages
+	Stemming         bool // "running" matches "run" in the languages with the stemmer, see Stemmable
+}
+
+// stemmers by the ISO 639-1 code of the language
+var stemmers = map[string]func(word string, stemStopWords bool) string{
+	"en": english.Stem,
+	"es": spanish.Stem,
+	"fr": french.Stem,
+	"hu": hungarian.Stem,
+	"nb": norwegian.Stem,
+	"nn": norwegian.Stem,
+	"no": norwegian.Stem,
+	"ru": russian.Stem,
+	"sv": swedish.Stem,
+}
+
+var folder = cases.Fold()
+
+// Stemmable reports whether the terms of the language are stemmed
+func Stemmable(language string) bool {
+	_, ok := stemmers[strings.ToLower(language)]
+	return ok
+}
+
+// Key returns the form of the term, which is equal for the duplicates.
+// The language is the ISO 639-1 code, the terms of the language without the stemmer are not stemmed.
+// The punctuation and the spaces between the words are ignored, the term of the punctuation only is kept as it is.
+func Key(term string, language string, options Options) string {
+	text := folder.String(norm.NFKC.String(term))
+	if options.IgnoreDiacritics {
+		var b strings.Builder
+		for _, r := range norm.NFD.String(text) {
+			if !unicode.Is(unicode.Mn, r) {
+				b.WriteRune(r)
+			}
+		}
+		text = norm.NFC.String(b.String())
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r)
+	})
+	if len(words) == 0 {
+		return strings.Join(strings.Fields(text), " ")
+	}
+
+	if stem, ok := stemmers[strings.ToLower(language)]; ok && options.Stemming {
+		for i, word := range words {
+			words[i] = stem(word, true)
+		}
+	}
+	return strings.Join(words, " ")
+}
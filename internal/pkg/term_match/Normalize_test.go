@@ -0,0 +1,44 @@
+package term_match
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		language string
+		options  Options
+		equal    bool
+	}{
+		{name: "case and spaces", a: "  Ice   Cream ", b: "ice cream", language: "en", equal: true},
+		{name: "punctuation", a: "don't!", b: "don t", language: "en", equal: true},
+		{name: "full width by NFKC", a: "ＡＢＣ", b: "abc", language: "en", equal: true},
+		{name: "ligature by NFKC", a: "ﬁne", b: "fine", language: "en", equal: true},
+		{name: "composed and decomposed", a: "café", b: "café", language: "fr", equal: true},
+		{name: "german sharp s folds", a: "Straße", b: "STRASSE", language: "de", equal: true},
+		{name: "diacritics are kept", a: "café", b: "cafe", language: "fr", equal: false},
+		{name: "diacritics are ignored", a: "café", b: "cafe", language: "fr", options: Options{IgnoreDiacritics: true}, equal: true},
+		{name: "no stemming by default", a: "running", b: "run", language: "en", equal: false},
+		{name: "stemming", a: "running", b: "run", language: "en", options: Options{Stemming: true}, equal: true},
+		{name: "stemming of the phrase", a: "Running Dogs", b: "run dog", language: "EN", options: Options{Stemming: true}, equal: true},
+		{name: "stemming of russian", a: "кошки", b: "кошка", language: "ru", options: Options{Stemming: true}, equal: true},
+		{name: "no stemmer of the language", a: "Häuser", b: "Haus", language: "de", options: Options{Stemming: true}, equal: false},
+		{name: "punctuation only", a: "?!", b: "?!", language: "en", equal: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Key(tt.a, tt.language, tt.options), Key(tt.b, tt.language, tt.options)
+			if (a == b) != tt.equal {
+				t.Fatalf("Key(%q) = %q, Key(%q) = %q, want equal %v", tt.a, a, tt.b, b, tt.equal)
+			}
+		})
+	}
+}
+
+func TestStemmable(t *testing.T) {
+	for language, want := range map[string]bool{"en": true, "RU": true, "de": false, "": false} {
+		if got := Stemmable(language); got != want {
+			t.Fatalf("Stemmable(%q) = %v, want %v", language, got, want)
+		}
+	}
+}
@@ -0,0 +1,493 @@
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrNotFound = errors.New("not found in the trash")
+
+// Collection is the deleted collection, vocabulary_api keeps it with all its terms until the purge
+type Collection struct {
+	CollectionId uint64    `json:"collection_id"`
+	UserId       uint64    `json:"user_id"` // the owner, it is the trash of the owner
+	LanguageId   uint64    `json:"language_id"`
+	Name         string    `json:"name"`
+	TotalTerms   uint64    `json:"total_terms"`
+	DeletedBy    uint64    `json:"deleted_by"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	PurgeAt      time.Time `json:"purge_at"`
+}
+
+// Term is the deleted term of the collection, which is not deleted itself
+type Term struct {
+	TermId       uint64    `json:"term_id"`
+	CollectionId uint64    `json:"collection_id"`
+	UserId       uint64    `json:"user_id"` // the owner of the collection
+	Term         string    `json:"term"`
+	Meaning      string    `json:"meaning"`
+	Status       int32     `json:"status"` // the status of vocabulary_api
+	DeletedBy    uint64    `json:"deleted_by"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	PurgeAt      time.Time `json:"purge_at"`
+}
+
+// Store keeps the trash in Redis shared by the instances, so a deleted item is hidden by all of them
+// and survives the restarts:
+//
+//	{prefix}collection:{id}        - the Collection
+//	{prefix}collection:{id}:terms  - the hash of the term id -> Term
+//	{prefix}term:{id}              - the collection id of the trashed term
+//	{prefix}user:{id}:collections  - the set of the collection ids in the trash of the owner
+//	{prefix}user:{id}:terms        - the hash of "collection id:term id" -> Term of the owner
+//	{prefix}purge:collections      - the sorted set of the collection ids by PurgeAt
+//	{prefix}purge:terms            - the sorted set of "collection id:term id" by PurgeAt
+//	{prefix}purge:lock             - taken by the instance, which purges the trash
+//
+// Every item is written and removed in one transaction with its indexes.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func New(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+func (s *Store) PutCollection(ctx context.Context, collection *Collection) error {
+	value, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.collectionKey(collection.CollectionId), value, 0)
+		pipe.SAdd(ctx, s.userCollectionsKey(collection.UserId), collection.CollectionId)
+		pipe.ZAdd(ctx, s.prefix+"purge:collections", redis.Z{
+			Score:  float64(collection.PurgeAt.Unix()),
+			Member: collection.CollectionId,
+		})
+		return nil
+	})
+	return err
+}
+
+func (s *Store) PutTerms(ctx context.Context, terms []*Term) error {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, term := range terms {
+			value, err := json.Marshal(term)
+			if err != nil {
+				return err
+			}
+			pipe.HSet(ctx, s.collectionTermsKey(term.CollectionId), formatId(term.TermId), value)
+			pipe.HSet(ctx, s.userTermsKey(term.UserId), termMember(term.CollectionId, term.TermId), value)
+			pipe.Set(ctx, s.termKey(term.TermId), term.CollectionId, 0)
+			pipe.ZAdd(ctx, s.prefix+"purge:terms", redis.Z{
+				Score:  float64(term.PurgeAt.Unix()),
+				Member: termMember(term.CollectionId, term.TermId),
+			})
+		}
+		return nil
+	})
+	return err
+}
+
+// Collection returns nil when the collection is not in the trash
+func (s *Store) Collection(ctx context.Context, collectionId uint64) (*Collection, error) {
+	collections, err := s.Collections(ctx, []uint64{collectionId})
+	if err != nil {
+		return nil, err
+	}
+	return collections[collectionId], nil
+}
+
+// Collections returns the collections in the trash by their ids, the other ids are skipped
+func (s *Store) Collections(ctx context.Context, collectionIds []uint64) (map[uint64]*Collection, error) {
+	collections := make(map[uint64]*Collection)
+	if len(collectionIds) == 0 {
+		return collections, nil
+	}
+
+	keys := make([]string, len(collectionIds))
+	for i, collectionId := range collectionIds {
+		keys[i] = s.collectionKey(collectionId)
+	}
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		encoded, ok := value.(string)
+		if !ok {
+			continue
+		}
+		collection := new(Collection)
+		if err = json.Unmarshal([]byte(encoded), collection); err != nil {
+			return nil, err
+		}
+		collections[collection.CollectionId] = collection
+	}
+	return collections, nil
+}
+
+// Term returns nil when the term is not in the trash
+func (s *Store) Term(ctx context.Context, termId uint64) (*Term, error) {
+	collectionId, err := s.client.Get(ctx, s.termKey(termId)).Uint64()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := s.client.HGet(ctx, s.collectionTermsKey(collectionId), formatId(termId)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	term := new(Term)
+	if err = json.Unmarshal(value, term); err != nil {
+		return nil, err
+	}
+	return term, nil
+}
+
+// CollectionTerms returns the trashed terms of the collection by their ids
+func (s *Store) CollectionTerms(ctx context.Context, collectionId uint64) (map[uint64]*Term, error) {
+	byCollection, err := s.CollectionsTerms(ctx, []uint64{collectionId})
+	if err != nil {
+		return nil, err
+	}
+	return byCollection[collectionId], nil
+}
+
+// CollectionsTerms returns the trashed terms of the collections by the collection id and the term id,
+// the collections without the trashed terms are skipped
+func (s *Store) CollectionsTerms(ctx context.Context, collectionIds []uint64) (map[uint64]map[uint64]*Term, error) {
+	byCollection := make(map[uint64]map[uint64]*Term)
+	if len(collectionIds) == 0 {
+		return byCollection, nil
+	}
+
+	cmds := make([]*redis.StringSliceCmd, len(collectionIds))
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, collectionId := range collectionIds {
+			cmds[i] = pipe.HVals(ctx, s.collectionTermsKey(collectionId))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, cmd := range cmds {
+		terms, err := decodeTerms(cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+		if len(terms) == 0 {
+			continue
+		}
+		byId := make(map[uint64]*Term, len(terms))
+		for _, term := range terms {
+			byId[term.TermId] = term
+		}
+		byCollection[collectionIds[i]] = byId
+	}
+	return byCollection, nil
+}
+
+// List returns the trash of the user, the last deleted first
+func (s *Store) List(ctx context.Context, userId uint64) ([]*Collection, []*Term, error) {
+	var collectionIds *redis.StringSliceCmd
+	var termValues *redis.StringSliceCmd
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		collectionIds = pipe.SMembers(ctx, s.userCollectionsKey(userId))
+		termValues = pipe.HVals(ctx, s.userTermsKey(userId))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids, err := parseIds(collectionIds.Val())
+	if err != nil {
+		return nil, nil, err
+	}
+	byId, err := s.Collections(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	collections := make([]*Collection, 0, len(byId))
+	for _, collection := range byId {
+		collections = append(collections, collection)
+	}
+	terms, err := decodeTerms(termValues.Val())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		if !collections[i].DeletedAt.Equal(collections[j].DeletedAt) {
+			return collections[i].DeletedAt.After(collections[j].DeletedAt)
+		}
+		return collections[i].CollectionId < collections[j].CollectionId
+	})
+	SortTerms(terms)
+	return collections, terms, nil
+}
+
+// Expired returns the items to purge. The terms of the expired collections are not returned,
+// they are purged together with the collection.
+func (s *Store) Expired(ctx context.Context, now time.Time) ([]*Collection, []*Term, error) {
+	until := &redis.ZRangeBy{Min: "-inf", Max: strconv.FormatInt(now.Unix(), 10)}
+	var collectionIds *redis.StringSliceCmd
+	var termMembers *redis.StringSliceCmd
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		collectionIds = pipe.ZRangeByScore(ctx, s.prefix+"purge:collections", until)
+		termMembers = pipe.ZRangeByScore(ctx, s.prefix+"purge:terms", until)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids, err := parseIds(collectionIds.Val())
+	if err != nil {
+		return nil, nil, err
+	}
+	byId, err := s.Collections(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	collections := make([]*Collection, 0, len(byId))
+	for _, collection := range byId {
+		collections = append(collections, collection)
+	}
+
+	termIds := make(map[uint64][]uint64)
+	var termCollectionIds []uint64
+	for _, member := range termMembers.Val() {
+		collectionId, termId, err := parseTermMember(member)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := byId[collectionId]; ok {
+			continue
+		}
+		if _, ok := termIds[collectionId]; !ok {
+			termCollectionIds = append(termCollectionIds, collectionId)
+		}
+		termIds[collectionId] = append(termIds[collectionId], termId)
+	}
+	byCollection, err := s.CollectionsTerms(ctx, termCollectionIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var terms []*Term
+	for _, collectionId := range termCollectionIds {
+		for _, termId := range termIds[collectionId] {
+			if term, ok := byCollection[collectionId][termId]; ok {
+				terms = append(terms, term)
+			}
+		}
+	}
+	return collections, terms, nil
+}
+
+// LockPurge returns true when the instance takes the purge for the ttl, the other instances skip it meanwhile.
+// The lock is not released, it expires, so the trash is purged once per ttl by one of the instances.
+func (s *Store) LockPurge(ctx context.Context, now time.Time, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+"purge:lock", now.Unix(), ttl).Result()
+}
+
+// RestoreCollection takes the collection out of the trash, its terms deleted before it stay in the trash
+func (s *Store) RestoreCollection(ctx context.Context, collectionId uint64) (*Collection, error) {
+	collection, err := s.Collection(ctx, collectionId)
+	if err != nil {
+		return nil, err
+	}
+	if collection == nil {
+		return nil, ErrNotFound
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		s.removeCollection(ctx, pipe, collection)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// RemoveCollection removes the purged collection and its terms
+func (s *Store) RemoveCollection(ctx context.Context, collectionId uint64) error {
+	collection, err := s.Collection(ctx, collectionId)
+	if err != nil || collection == nil {
+		return err
+	}
+	terms, err := s.CollectionTerms(ctx, collectionId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		s.removeCollection(ctx, pipe, collection)
+		for _, term := range terms {
+			s.removeTerm(ctx, pipe, term)
+		}
+		return nil
+	})
+	return err
+}
+
+// RemoveTerms takes the terms of the collection out of the trash, when they are restored or purged.
+// The removed terms are returned, the ids not in the trash are skipped.
+func (s *Store) RemoveTerms(ctx context.Context, collectionId uint64, termIds []uint64) ([]*Term, error) {
+	removed := make([]*Term, 0, len(termIds))
+	if len(termIds) == 0 {
+		return removed, nil
+	}
+
+	fields := make([]string, len(termIds))
+	for i, termId := range termIds {
+		fields[i] = formatId(termId)
+	}
+	values, err := s.client.HMGet(ctx, s.collectionTermsKey(collectionId), fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		encoded, ok := value.(string)
+		if !ok {
+			continue
+		}
+		term := new(Term)
+		if err = json.Unmarshal([]byte(encoded), term); err != nil {
+			return nil, err
+		}
+		removed = append(removed, term)
+	}
+	if len(removed) == 0 {
+		return removed, nil
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, term := range removed {
+			s.removeTerm(ctx, pipe, term)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// SortTerms orders the terms by the deletion, the last deleted first
+func SortTerms(terms []*Term) {
+	sort.Slice(terms, func(i, j int) bool {
+		if !terms[i].DeletedAt.Equal(terms[j].DeletedAt) {
+			return terms[i].DeletedAt.After(terms[j].DeletedAt)
+		}
+		return terms[i].TermId < terms[j].TermId
+	})
+}
+
+func (s *Store) removeCollection(ctx context.Context, pipe redis.Pipeliner, collection *Collection) {
+	pipe.Del(ctx, s.collectionKey(collection.CollectionId))
+	pipe.SRem(ctx, s.userCollectionsKey(collection.UserId), collection.CollectionId)
+	pipe.ZRem(ctx, s.prefix+"purge:collections", collection.CollectionId)
+}
+
+func (s *Store) removeTerm(ctx context.Context, pipe redis.Pipeliner, term *Term) {
+	pipe.HDel(ctx, s.collectionTermsKey(term.CollectionId), formatId(term.TermId))
+	pipe.HDel(ctx, s.userTermsKey(term.UserId), termMember(term.CollectionId, term.TermId))
+	pipe.Del(ctx, s.termKey(term.TermId))
+	pipe.ZRem(ctx, s.prefix+"purge:terms", termMember(term.CollectionId, term.TermId))
+}
+
+func (s *Store) collectionKey(collectionId uint64) string {
+	return fmt.Sprintf("%scollection:%d", s.prefix, collectionId)
+}
+
+func (s *Store) collectionTermsKey(collectionId uint64) string {
+	return fmt.Sprintf("%scollection:%d:terms", s.prefix, collectionId)
+}
+
+func (s *Store) termKey(termId uint64) string {
+	return fmt.Sprintf("%sterm:%d", s.prefix, termId)
+}
+
+func (s *Store) userCollectionsKey(userId uint64) string {
+	return fmt.Sprintf("%suser:%d:collections", s.prefix, userId)
+}
+
+func (s *Store) userTermsKey(userId uint64) string {
+	return fmt.Sprintf("%suser:%d:terms", s.prefix, userId)
+}
+
+func termMember(collectionId uint64, termId uint64) string {
+	return formatId(collectionId) + ":" + formatId(termId)
+}
+
+func parseTermMember(member string) (uint64, uint64, error) {
+	collection, term, ok := strings.Cut(member, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid trashed term %q", member)
+	}
+	collectionId, err := strconv.ParseUint(collection, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	termId, err := strconv.ParseUint(term, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return collectionId, termId, nil
+}
+
+func parseIds(values []string) ([]uint64, error) {
+	ids := make([]uint64, len(values))
+	for i, value := range values {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func decodeTerms(values []string) ([]*Term, error) {
+	terms := make([]*Term, 0, len(values))
+	for _, value := range values {
+		term := new(Term)
+		if err := json.Unmarshal([]byte(value), term); err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+func formatId(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
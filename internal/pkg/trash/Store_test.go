@@ -0,0 +1,173 @@
+package trash
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return New(client, "test:")
+}
+
+func collectionIds(collections []*Collection) []uint64 {
+	result := make([]uint64, 0, len(collections))
+	for _, collection := range collections {
+		result = append(result, collection.CollectionId)
+	}
+	slices.Sort(result)
+	return result
+}
+
+func termIds(terms []*Term) []uint64 {
+	result := make([]uint64, 0, len(terms))
+	for _, term := range terms {
+		result = append(result, term.TermId)
+	}
+	slices.Sort(result)
+	return result
+}
+
+func TestStoreExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name            string
+		collections     []*Collection
+		terms           []*Term
+		wantCollections []uint64
+		wantTerms       []uint64
+	}{
+		{
+			name:            "nothing expired",
+			collections:     []*Collection{{CollectionId: 1, UserId: 7, PurgeAt: now.Add(time.Hour)}},
+			terms:           []*Term{{TermId: 10, CollectionId: 2, UserId: 7, PurgeAt: now.Add(time.Hour)}},
+			wantCollections: []uint64{},
+			wantTerms:       []uint64{},
+		},
+		{
+			name:            "expired collection takes its terms",
+			collections:     []*Collection{{CollectionId: 1, UserId: 7, PurgeAt: now}},
+			terms:           []*Term{{TermId: 10, CollectionId: 1, UserId: 7, PurgeAt: now.Add(-time.Hour)}},
+			wantCollections: []uint64{1},
+			wantTerms:       []uint64{},
+		},
+		{
+			name:        "expired terms",
+			collections: []*Collection{{CollectionId: 1, UserId: 7, PurgeAt: now.Add(time.Hour)}},
+			terms: []*Term{
+				{TermId: 10, CollectionId: 2, UserId: 7, PurgeAt: now.Add(-time.Minute)},
+				{TermId: 11, CollectionId: 2, UserId: 7, PurgeAt: now.Add(time.Minute)},
+				{TermId: 12, CollectionId: 1, UserId: 7, PurgeAt: now.Add(-time.Minute)},
+			},
+			wantCollections: []uint64{},
+			wantTerms:       []uint64{10, 12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			for _, collection := range tt.collections {
+				if err := store.PutCollection(ctx, collection); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := store.PutTerms(ctx, tt.terms); err != nil {
+				t.Fatal(err)
+			}
+
+			collections, terms, err := store.Expired(ctx, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := collectionIds(collections); !slices.Equal(got, tt.wantCollections) {
+				t.Fatalf("Expired() collections = %v, want %v", got, tt.wantCollections)
+			}
+			if got := termIds(terms); !slices.Equal(got, tt.wantTerms) {
+				t.Fatalf("Expired() terms = %v, want %v", got, tt.wantTerms)
+			}
+		})
+	}
+}
+
+func TestStoreRemove(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Now()
+
+	err := store.PutCollection(ctx, &Collection{CollectionId: 1, UserId: 7, DeletedAt: now, PurgeAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.PutTerms(ctx, []*Term{
+		{TermId: 10, CollectionId: 1, UserId: 7, DeletedAt: now.Add(-time.Minute), PurgeAt: now.Add(time.Hour)},
+		{TermId: 20, CollectionId: 2, UserId: 7, DeletedAt: now, PurgeAt: now.Add(time.Hour)},
+		{TermId: 21, CollectionId: 2, UserId: 7, DeletedAt: now.Add(-time.Second), PurgeAt: now.Add(time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collections, terms, err := store.List(ctx, 7)
+	if err != nil || len(collections) != 1 || len(terms) != 3 || terms[0].TermId != 20 || terms[2].TermId != 10 {
+		t.Fatalf("List() = %v, %v, %v, want the last deleted first", collections, terms, err)
+	}
+	if term, err := store.Term(ctx, 21); err != nil || term == nil || term.CollectionId != 2 {
+		t.Fatalf("Term() = %+v, %v", term, err)
+	}
+
+	removed, err := store.RemoveTerms(ctx, 2, []uint64{21, 99})
+	if err != nil || len(removed) != 1 || removed[0].TermId != 21 {
+		t.Fatalf("RemoveTerms() = %v, %v, want the term 21 only", removed, err)
+	}
+	if term, err := store.Term(ctx, 21); err != nil || term != nil {
+		t.Fatalf("Term() of the removed term = %+v, %v", term, err)
+	}
+
+	if _, err = store.RestoreCollection(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.RestoreCollection(ctx, 1); err != ErrNotFound {
+		t.Fatalf("second RestoreCollection() error = %v", err)
+	}
+	// the terms deleted before the collection stay in the trash
+	if terms, err := store.CollectionTerms(ctx, 1); err != nil || len(terms) != 1 {
+		t.Fatalf("CollectionTerms() after the restore = %v, %v", terms, err)
+	}
+
+	if err = store.PutCollection(ctx, &Collection{CollectionId: 1, UserId: 7, PurgeAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.RemoveCollection(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	collections, terms, err = store.List(ctx, 7)
+	if err != nil || len(collections) != 0 || !slices.Equal(termIds(terms), []uint64{20}) {
+		t.Fatalf("List() after RemoveCollection = %v, %v, %v", collections, terms, err)
+	}
+	if collections, terms, err = store.Expired(ctx, now.Add(2*time.Hour)); err != nil || len(collections) != 0 || len(terms) != 1 {
+		t.Fatalf("Expired() after the removes = %v, %v, %v", collections, terms, err)
+	}
+}
+
+func TestStoreLockPurge(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Now()
+
+	for i, want := range []bool{true, false} {
+		locked, err := store.LockPurge(ctx, now, time.Hour)
+		if err != nil || locked != want {
+			t.Fatalf("LockPurge() %d = %v, %v, want %v", i, locked, err, want)
+		}
+	}
+}
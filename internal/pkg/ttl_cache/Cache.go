@@ -0,0 +1,129 @@
+package ttl_cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type Loader[V any] func(ctx context.Context) (V, error)
+
+type entry[V any] struct {
+	value    V
+	loadedAt time.Time
+}
+
+// Cache keeps loaded values for ttl. After ttl the value becomes stale: it is still returned,
+// while a fresh one is loaded in the background. Stale values are served up to maxStale,
+// so a dependency outage does not break the callers. Concurrent loads of a key are collapsed.
+type Cache[V any] struct {
+	ttl         time.Duration
+	maxStale    time.Duration
+	loadTimeout time.Duration
+
+	mu         sync.RWMutex
+	entries    map[string]entry[V]
+	generation uint64
+	group      singleflight.Group
+}
+
+func NewCache[V any](ttl time.Duration, maxStale time.Duration, loadTimeout time.Duration) *Cache[V] {
+	return &Cache[V]{
+		ttl:         ttl,
+		maxStale:    maxStale,
+		loadTimeout: loadTimeout,
+		entries:     make(map[string]entry[V]),
+	}
+}
+
+func (c *Cache[V]) Get(ctx context.Context, key string, load Loader[V]) (V, error) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok {
+		age := time.Since(e.loadedAt)
+		if age < c.ttl {
+			return e.value, nil
+		}
+		if age < c.ttl+c.maxStale {
+			c.group.DoChan(key, func() (interface{}, error) {
+				return c.load(ctx, key, load)
+			})
+			return e.value, nil
+		}
+	}
+
+	value, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return c.load(ctx, key, load)
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	return value.(V), nil
+}
+
+// Invalidate removes the key, the next Get loads it again
+func (c *Cache[V]) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	c.generation++
+	c.group.Forget(key)
+}
+
+// InvalidateAll removes all the keys
+func (c *Cache[V]) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		c.group.Forget(key)
+	}
+	c.entries = make(map[string]entry[V])
+	c.generation++
+}
+
+// DeleteExpired removes the values, which can not be served anymore, so unused keys do not pile up
+func (c *Cache[V]) DeleteExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, e := range c.entries {
+		if time.Since(e.loadedAt) >= c.ttl+c.maxStale {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// load is detached from the caller cancellation, because its result is shared with other callers.
+// A value loaded across an invalidation is returned, but not stored.
+func (c *Cache[V]) load(ctx context.Context, key string, load Loader[V]) (V, error) {
+	c.mu.RLock()
+	generation := c.generation
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.loadTimeout)
+	defer cancel()
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[key] = entry[V]{
+			value:    value,
+			loadedAt: time.Now(),
+		}
+	}
+	c.mu.Unlock()
+
+	return value, nil
+}
@@ -0,0 +1,157 @@
+package ttl_cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counter returns the number of its loads as the value
+func counter(loads *atomic.Int32) Loader[int32] {
+	return func(ctx context.Context) (int32, error) {
+		return loads.Add(1), nil
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+
+	tests := []struct {
+		name      string
+		maxStale  time.Duration
+		wait      time.Duration // between the first and the second Get
+		wantValue int32         // of the second Get
+		wantLoads int32         // after the background refresh
+	}{
+		{name: "fresh", maxStale: time.Hour, wait: 0, wantValue: 1, wantLoads: 1},
+		{name: "stale is served and refreshed", maxStale: time.Hour, wait: 2 * ttl, wantValue: 1, wantLoads: 2},
+		{name: "too stale is loaded", maxStale: ttl, wait: 3 * ttl, wantValue: 2, wantLoads: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache[int32](ttl, tt.maxStale, time.Second)
+			var loads atomic.Int32
+			ctx := context.Background()
+
+			if value, err := c.Get(ctx, "key", counter(&loads)); err != nil || value != 1 {
+				t.Fatalf("first Get() = %d, %v", value, err)
+			}
+			time.Sleep(tt.wait)
+			if value, err := c.Get(ctx, "key", counter(&loads)); err != nil || value != tt.wantValue {
+				t.Fatalf("second Get() = %d, %v, want %d", value, err, tt.wantValue)
+			}
+
+			deadline := time.Now().Add(time.Second)
+			for loads.Load() != tt.wantLoads && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if got := loads.Load(); got != tt.wantLoads {
+				t.Fatalf("loads = %d, want %d", got, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestCacheLoadError(t *testing.T) {
+	c := NewCache[int32](time.Hour, time.Hour, time.Second)
+	errLoad := errors.New("language_api is down")
+
+	_, err := c.Get(context.Background(), "key", func(ctx context.Context) (int32, error) { return 0, errLoad })
+	if !errors.Is(err, errLoad) {
+		t.Fatalf("Get() error = %v, want %v", err, errLoad)
+	}
+	// the error is not cached
+	var loads atomic.Int32
+	if value, err := c.Get(context.Background(), "key", counter(&loads)); err != nil || value != 1 {
+		t.Fatalf("Get() after the error = %d, %v", value, err)
+	}
+}
+
+func TestCacheConcurrentLoads(t *testing.T) {
+	c := NewCache[int32](time.Hour, time.Hour, time.Second)
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (int32, error) {
+		<-release
+		return loads.Add(1), nil
+	}
+
+	var wg sync.WaitGroup
+	values := make([]int32, 10)
+	for i := range values {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], _ = c.Get(context.Background(), "key", load)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, value := range values {
+		if value != 1 {
+			t.Fatalf("Get() %d = %d, want the one shared load", i, value)
+		}
+	}
+}
+
+func TestCacheInvalidateDuringLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(c *Cache[int32])
+	}{
+		{name: "Invalidate", invalidate: func(c *Cache[int32]) { c.Invalidate("key") }},
+		{name: "InvalidateAll", invalidate: func(c *Cache[int32]) { c.InvalidateAll() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache[int32](time.Hour, time.Hour, time.Second)
+			var loads atomic.Int32
+			started, release := make(chan struct{}), make(chan struct{})
+			slow := func(ctx context.Context) (int32, error) {
+				close(started)
+				<-release
+				return loads.Add(1), nil
+			}
+
+			done := make(chan int32)
+			go func() {
+				value, _ := c.Get(context.Background(), "key", slow)
+				done <- value
+			}()
+			<-started
+			tt.invalidate(c)
+			close(release)
+
+			// the value loaded across the invalidation is returned, but not stored
+			if value := <-done; value != 1 {
+				t.Fatalf("Get() during the invalidation = %d, want 1", value)
+			}
+			if value, err := c.Get(context.Background(), "key", counter(&loads)); err != nil || value != 2 {
+				t.Fatalf("Get() after the invalidation = %d, %v, want the new load", value, err)
+			}
+		})
+	}
+}
+
+func TestCacheDeleteExpired(t *testing.T) {
+	c := NewCache[int32](10*time.Millisecond, 10*time.Millisecond, time.Second)
+	var loads atomic.Int32
+	if _, err := c.Get(context.Background(), "key", counter(&loads)); err != nil {
+		t.Fatal(err)
+	}
+
+	c.DeleteExpired()
+	if len(c.entries) != 1 {
+		t.Fatalf("the fresh entry is deleted")
+	}
+	time.Sleep(30 * time.Millisecond)
+	c.DeleteExpired()
+	if len(c.entries) != 0 {
+		t.Fatalf("the expired entry is kept")
+	}
+}
@@ -0,0 +1,103 @@
+package word_extract
+
+import (
+	"sort"
+	"strings"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_match"
+	"golang.org/x/text/cases"
+)
+
+var folder = cases.Fold()
+
+// MaxSentenceLength the longer sentences are cut in runes, the sentence is the example of the term
+const MaxSentenceLength = 500
+
+// MatchOptions the words are grouped by term_match.Key with these options, so the inflected forms are one word
+var MatchOptions = term_match.Options{Stemming: true}
+
+// Word is the vocabulary of the text, all its forms are counted together
+type Word struct {
+	Key      string // term_match.Key of the word with MatchOptions
+	Text     string // the most frequent form, the lower case one when the forms are equally frequent
+	Count    int
+	Segment  int    // the index of the segment with the first occurrence
+	Sentence string // the first sentence with the word
+	Guessed  bool   // the word boundaries are guessed, it may be no word: the Chinese bigrams
+}
+
+type Result struct {
+	Words      []*Word // the most frequent first, then in the order of the text
+	TotalWords int     // all the occurrences including the stop words
+	StopWords  int     // the occurrences of the stop words
+}
+
+// Extract counts the words of the segments of the text in the language with the ISO 639-1 code.
+// The segments are the independent parts of the text, e.g. the subtitle cues, a sentence never spans them.
+func Extract(segments []string, language string) *Result {
+	language = strings.ToLower(language)
+	result := new(Result)
+
+	words := make(map[string]*Word)
+	forms := make(map[string]map[string]int)
+	order := make(map[string]int)
+	for i, segment := range segments {
+		for _, sentence := range Sentences(segment) {
+			for _, token := range tokenize(sentence, language) {
+				result.TotalWords++
+				if IsStopWord(token.text, language) {
+					result.StopWords++
+					continue
+				}
+
+				key := term_match.Key(token.text, language, MatchOptions)
+				word, ok := words[key]
+				if !ok {
+					word = &Word{Key: key, Segment: i, Sentence: cut(sentence), Guessed: token.guessed}
+					words[key] = word
+					forms[key] = make(map[string]int)
+					order[key] = len(order)
+				}
+				word.Count++
+				forms[key][token.text]++
+			}
+		}
+	}
+
+	result.Words = make([]*Word, 0, len(words))
+	for key, word := range words {
+		word.Text = mostFrequentForm(forms[key])
+		result.Words = append(result.Words, word)
+	}
+	sort.Slice(result.Words, func(i, j int) bool {
+		if result.Words[i].Count != result.Words[j].Count {
+			return result.Words[i].Count > result.Words[j].Count
+		}
+		return order[result.Words[i].Key] < order[result.Words[j].Key]
+	})
+	return result
+}
+
+// mostFrequentForm prefers the lower case on a tie, the capital is mostly the start of the sentence
+func mostFrequentForm(forms map[string]int) string {
+	best, bestCount := "", 0
+	for form, count := range forms {
+		better := count > bestCount
+		if count == bestCount {
+			lower, bestLower := form == strings.ToLower(form), best == strings.ToLower(best)
+			better = lower && !bestLower || lower == bestLower && form < best
+		}
+		if better {
+			best, bestCount = form, count
+		}
+	}
+	return best
+}
+
+func cut(sentence string) string {
+	runes := []rune(sentence)
+	if len(runes) <= MaxSentenceLength {
+		return sentence
+	}
+	return strings.TrimSpace(string(runes[:MaxSentenceLength-1])) + "…"
+}
@@ -0,0 +1,64 @@
+package word_extract
+
+import "strings"
+
+// stopWords are the most frequent function words by the ISO 639-1 code of the language, case folded.
+// The languages without the list keep all their words.
+var stopWords = map[string]map[string]struct{}{
+	"en": set(`a about above after again against all am an and any are aren't as at be because been before being
+		below between both but by can can't cannot could couldn't did didn't do does doesn't doing don't down during
+		each few for from further had hadn't has hasn't have haven't having he he'd he'll he's her here here's hers
+		herself him himself his how how's i i'd i'll i'm i've if in into is isn't it it's its itself let's me more
+		most mustn't my myself no nor not of off on once only or other ought our ours ourselves out over own same
+		shan't she she'd she'll she's should shouldn't so some such than that that's the their theirs them themselves
+		then there there's these they they'd they'll they're they've this those through to too under until up very
+		was wasn't we we'd we'll we're we've were weren't what what's when when's where where's which while who who's
+		whom why why's will with won't would wouldn't you you'd you'll you're you've your yours yourself yourselves
+		also just yes oh ok okay yeah hey`),
+	"es": set(`a al algo algunas algunos ante antes como con contra cual cuando de del desde donde durante e el
+		ella ellas ellos en entre era eras es esa esas ese eso esos esta estaba estado estamos estan estar este esto
+		estos estoy fue fueron fui ha habia han has hasta hay la las le les lo los mas me mi mis mucho muy nada ni no
+		nos nosotros o os otra otro para pero poco por porque que quien se sea ser si sin sobre son su sus también
+		te tengo ti tiene tu tus un una uno unos y ya yo él ésta está están más mí qué sí tú`),
+	"fr": set(`a au aux avec ce ces cette dans de des du elle elles en est et eu il ils je la le les leur leurs lui
+		ma mais me mes moi mon même ne nos notre nous on ont ou où par pas pour qu que qui sa se ses son sont sur ta
+		te tes toi ton tu un une vos votre vous y à été être avait avoir c'est n'est ça sont était`),
+	"de": set(`aber alle allem allen aller alles als also am an ander andere auch auf aus bei bin bis bist da damit
+		dann das dass dein deine dem den der des dich die dir doch dort du durch ein eine einem einen einer eines er
+		es etwas euch euer für hab habe haben hat hatte hier hin hinter ich ihm ihn ihr ihre im in ist ja jede jedem
+		jeden jeder jetzt kann kein keine man mein meine mich mir mit muss nach nein nicht nichts noch nun nur ob oder
+		ohne sehr sein seine sich sie sind so über um und uns unser unter vom von vor war waren was weil welche wenn
+		wer wie wir wird wo zu zum zur`),
+	"it": set(`a ad al alla alle agli ai anche che chi ci come con da dal dalla dei del della delle di dove e ed è
+		era gli ha hanno ho i il in io la le lei lo loro lui ma me mi mia mio ne nei nel nella noi non o per perché
+		più quale quando quello questa questo se si sia sono su sua suo sul sulla te ti tra tu tua tuo un una uno vi
+		voi`),
+	"pt": set(`a ao aos as até com como da das de dela dele deles do dos e ela elas ele eles em entre era essa esse
+		esta este eu foi for há isso isto já lhe mais mas me mesmo meu minha muito na nas não nem no nos nós o os ou
+		para pela pelo por qual quando que quem se sem ser seu sua são só também te tem tu um uma você é`),
+	"nl": set(`aan al als bij dan dat de der deze die dit doch door dus een en er ge geen had heb heeft het hij hem
+		hoe hun ik in is ja je kan kon maar me men met mij mijn na naar niet niets nog nu of om omdat ons ook op over
+		te tegen toch toen tot u uit van veel voor want was wat we wel werd wie wij wil worden zal ze zei zelf zich zij
+		zijn zo zonder zou`),
+	"ru": set(`а без более бы был была были было быть в вам вас весь во вот все всего всех вы где да даже для до
+		его ее если есть еще же за здесь и из или им их к как ко когда кто ли либо мне может мы на над надо наш не
+		него нее нет ни них но ну о об однако он она они оно от очень по под при с со так также такой там те тем то
+		того тоже той только том ты у уже хотя чего чей чем что чтобы чье чья эта эти это я`),
+	// the characters, which are also the parts of many words, are not here: they split the Chinese text
+	"zh": set(`的 了 是 在 我 你 他 她 它 我们 你们 他们 她们 这 那 这个 那个 这些 那些 也 就 都 不 没有 一个 什么 因为 所以
+		但是 如果 还 吗 呢 吧 啊 很 着`),
+}
+
+func set(words string) map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, word := range strings.Fields(words) {
+		result[folder.String(word)] = struct{}{}
+	}
+	return result
+}
+
+// IsStopWord reports whether the word is a function word of the language
+func IsStopWord(word string, language string) bool {
+	_, ok := stopWords[strings.ToLower(language)][folder.String(strings.ReplaceAll(word, "’", "'"))]
+	return ok
+}
@@ -0,0 +1,215 @@
+package word_extract
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// script is the class of the rune for the word boundaries, the words of the scripts without spaces
+// end where the script changes
+type script int
+
+const (
+	scriptNone   script = iota // not a part of a word
+	scriptSpaced               // Latin, Cyrillic, Greek, Hangul etc., the words are separated by spaces
+	scriptHan
+	scriptHiragana
+	scriptKatakana
+	scriptSoutheastAsian // Thai, Lao, Khmer and Myanmar, the spaces separate the phrases only
+)
+
+func scriptOf(r rune) script {
+	switch {
+	case unicode.Is(unicode.Han, r) || r == '々':
+		return scriptHan
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptHiragana
+	case unicode.Is(unicode.Katakana, r) || r == 'ー':
+		return scriptKatakana
+	case unicode.In(r, unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar):
+		return scriptSoutheastAsian
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r):
+		return scriptSpaced
+	}
+	return scriptNone
+}
+
+// the joiners are kept inside the words of the spaced scripts: "don't", "well-known"
+func isJoiner(r rune) bool {
+	return r == '\'' || r == '’' || r == '-'
+}
+
+// sentence terminators, the ideographic ones end the sentence without the following space
+func isTerminator(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
+
+func isIdeographicTerminator(r rune) bool {
+	return r == '。' || r == '！' || r == '？'
+}
+
+// Sentences splits the text into the sentences with the whitespace collapsed.
+// The single line breaks are taken as the wrapping of the text, the empty lines end the sentence.
+func Sentences(text string) []string {
+	var sentences []string
+	var b strings.Builder
+	flush := func() {
+		if sentence := strings.Join(strings.Fields(b.String()), " "); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		b.Reset()
+	}
+
+	runes := []rune(strings.ReplaceAll(text, "\r\n", "\n"))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' && i+1 < len(runes) && runes[i+1] == '\n' {
+			flush()
+			continue
+		}
+		b.WriteRune(r)
+
+		if !isTerminator(r) && !isIdeographicTerminator(r) {
+			continue
+		}
+		// "?!", "..." and the closing quotes and brackets stay with the sentence
+		for i+1 < len(runes) && (isTerminator(runes[i+1]) || isIdeographicTerminator(runes[i+1]) ||
+			unicode.Is(unicode.Pf, runes[i+1]) || unicode.Is(unicode.Pe, runes[i+1]) || runes[i+1] == '"') {
+			i++
+			b.WriteRune(runes[i])
+		}
+		if isIdeographicTerminator(r) || i+1 == len(runes) || unicode.IsSpace(runes[i+1]) {
+			flush()
+		}
+	}
+	flush()
+	return sentences
+}
+
+// token is the word as it is written, guessed when the word boundaries are not known:
+// the Chinese bigrams span the words as well, e.g. 习中 of 学习中文
+type token struct {
+	text    string
+	guessed bool
+}
+
+// tokenize returns the words of the sentence as they are written. The runs of one script are split further by the language:
+// Chinese into the bigrams, Japanese loses the hiragana, which is mostly the grammar.
+func tokenize(sentence string, language string) []token {
+	var tokens []token
+	runes := []rune(sentence)
+	for start := 0; start < len(runes); {
+		current := scriptOf(runes[start])
+		if current == scriptNone {
+			start++
+			continue
+		}
+
+		end := start + 1
+		for end < len(runes) {
+			next := scriptOf(runes[end])
+			if next == current {
+				end++
+				continue
+			}
+			if current == scriptSpaced && isJoiner(runes[end]) && end+1 < len(runes) && scriptOf(runes[end+1]) == scriptSpaced {
+				end += 2
+				continue
+			}
+			break
+		}
+
+		tokens = append(tokens, splitRun(runes[start:end], current, language)...)
+		start = end
+	}
+	return tokens
+}
+
+func splitRun(run []rune, current script, language string) []token {
+	switch current {
+	case scriptSpaced:
+		text := stripElision(string(run), language)
+		// the single letters and the numbers are not the vocabulary
+		if utf8.RuneCountInString(text) < 2 || !strings.ContainsFunc(text, unicode.IsLetter) {
+			return nil
+		}
+		return []token{{text: text}}
+	case scriptHiragana:
+		if language == "ja" {
+			return nil
+		}
+	case scriptHan:
+		if language != "ja" {
+			pieces := splitHan(run, language)
+			tokens := make([]token, len(pieces))
+			for i, piece := range pieces {
+				// the stop words are dropped anyway, the rest is not checked against a dictionary
+				tokens[i] = token{text: piece, guessed: !IsStopWord(piece, language)}
+			}
+			return tokens
+		}
+	}
+	return []token{{text: string(run)}}
+}
+
+// splitHan splits the Chinese text, which is written without the word boundaries and there is no dictionary here.
+// The stop words are the boundaries, the pieces between them are split into the overlapping bigrams
+// as most of the words are two characters long. Every other bigram is no word, so the pieces are only the guesses.
+func splitHan(run []rune, language string) []string {
+	var tokens []string
+	piece := 0
+	flush := func(end int) {
+		if end-piece <= 2 {
+			if end > piece {
+				tokens = append(tokens, string(run[piece:end]))
+			}
+			return
+		}
+		for i := piece; i+1 < end; i++ {
+			tokens = append(tokens, string(run[i:i+2]))
+		}
+	}
+
+	for i := 0; i < len(run); {
+		length := 0
+		for _, n := range []int{2, 1} {
+			if i+n <= len(run) && IsStopWord(string(run[i:i+n]), language) {
+				length = n
+				break
+			}
+		}
+		if length == 0 {
+			i++
+			continue
+		}
+		flush(i)
+		tokens = append(tokens, string(run[i:i+length]))
+		i += length
+		piece = i
+	}
+	flush(len(run))
+	return tokens
+}
+
+// elisions are the articles and the pronouns joined to the next word with the apostrophe: "l'homme"
+var elisions = map[string][]string{
+	"fr": {"l", "d", "j", "m", "n", "s", "t", "c", "qu", "jusqu", "lorsqu", "puisqu"},
+	"it": {"l", "d", "un", "dell", "all", "dall", "nell", "sull", "c"},
+	"ca": {"l", "d", "s", "m", "n", "t"},
+}
+
+func stripElision(word string, language string) string {
+	for _, apostrophe := range []string{"'", "’"} {
+		prefix, rest, ok := strings.Cut(word, apostrophe)
+		if !ok {
+			continue
+		}
+		for _, elision := range elisions[language] {
+			if strings.EqualFold(prefix, elision) {
+				return rest
+			}
+		}
+	}
+	return word
+}
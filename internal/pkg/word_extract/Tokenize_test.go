@@ -0,0 +1,72 @@
+package word_extract
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name        string
+		sentence    string
+		language    string
+		want        []string
+		wantGuessed []bool
+	}{
+		{
+			name:        "spaced words",
+			sentence:    "L'homme well-known, 42 a",
+			language:    "fr",
+			want:        []string{"homme", "well-known"},
+			wantGuessed: []bool{false, false},
+		},
+		{
+			name:        "chinese bigrams are guessed",
+			sentence:    "学习中文",
+			language:    "zh",
+			want:        []string{"学习", "习中", "中文"},
+			wantGuessed: []bool{true, true, true},
+		},
+		{
+			name:        "chinese stop words are the boundaries",
+			sentence:    "我学习中文",
+			language:    "zh",
+			want:        []string{"我", "学习", "习中", "中文"},
+			wantGuessed: []bool{false, true, true, true},
+		},
+		{
+			name:        "japanese drops the hiragana",
+			sentence:    "日本語を勉強します",
+			language:    "ja",
+			want:        []string{"日本語", "勉強"},
+			wantGuessed: []bool{false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := tokenize(tt.sentence, tt.language)
+			got := make([]string, len(tokens))
+			guessed := make([]bool, len(tokens))
+			for i, token := range tokens {
+				got[i], guessed[i] = token.text, token.guessed
+			}
+			if !slices.Equal(got, tt.want) || !slices.Equal(guessed, tt.wantGuessed) {
+				t.Fatalf("tokenize() = %q %v, want %q %v", got, guessed, tt.want, tt.wantGuessed)
+			}
+		})
+	}
+}
+
+func TestExtractGuessed(t *testing.T) {
+	want := map[string]bool{"学习": true, "习中": true, "中文": true, "Hello": false, "world": false}
+
+	result := Extract([]string{"我学习中文。Hello world."}, "zh")
+	if len(result.Words) != len(want) {
+		t.Fatalf("Extract() = %d words, want %d", len(result.Words), len(want))
+	}
+	for _, word := range result.Words {
+		if guessed, ok := want[word.Text]; !ok || word.Guessed != guessed {
+			t.Fatalf("word %q: Guessed = %v, want %v", word.Text, word.Guessed, guessed)
+		}
+	}
+}
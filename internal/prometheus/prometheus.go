@@ -16,6 +16,19 @@ type Exporter struct {
 
 	ActionApiReqDuration *prometheus.HistogramVec
 	ActionApiReqErrCount *prometheus.CounterVec
+
+	CacheReqCount *prometheus.CounterVec
+
+	RabbitPublishCount *prometheus.CounterVec
+	RabbitConsumeCount *prometheus.CounterVec
+
+	OutboxDepth       *prometheus.GaugeVec
+	OutboxOldestAge   *prometheus.GaugeVec
+	OutboxDeadLetters *prometheus.GaugeVec
+
+	EventDropCount *prometheus.CounterVec
+
+	SagaStepCount *prometheus.CounterVec
 }
 
 func NewExporter(namespace string) *Exporter {
@@ -87,11 +100,79 @@ func NewExporter(namespace string) *Exporter {
 		[]string{"method"},
 	)
 
+	// hit ratio: sum(rate(cache_request_count{result="hit"}[5m])) / sum(rate(cache_request_count[5m]))
+	prom.CacheReqCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_request_count",
+		Help:      "cache request count by result (hit or miss)",
+	},
+		[]string{"cache", "result"},
+	)
+
+	prom.RabbitPublishCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rabbit_publish_count",
+		Help:      "rabbit published messages count by result (confirmed, nacked or returned)",
+	},
+		[]string{"exchange", "result"},
+	)
+
+	prom.RabbitConsumeCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rabbit_consume_count",
+		Help:      "rabbit consumed messages count by result (handled or failed)",
+	},
+		[]string{"exchange", "result"},
+	)
+
+	prom.OutboxDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_depth",
+		Help:      "number of messages waiting in the outbox",
+	},
+		[]string{"outbox"},
+	)
+	prom.OutboxOldestAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_oldest_age_seconds",
+		Help:      "age of the oldest message waiting in the outbox",
+	},
+		[]string{"outbox"},
+	)
+	prom.OutboxDeadLetters = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_dead_letters",
+		Help:      "number of messages, which failed to be published the max attempts and are kept in the outbox file",
+	},
+		[]string{"outbox"},
+	)
+
+	prom.EventDropCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "event_drop_count",
+		Help:      "events which failed to be stored for publishing",
+	},
+		[]string{"event"},
+	)
+
+	prom.SagaStepCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "saga_step_count",
+		Help:      "saga step count by result (done, failed, deferred, compensated, retry_done, ...)",
+	},
+		[]string{"saga", "step", "result"},
+	)
+
 	prometheus.MustRegister(
 		prom.HttpReqDuration, prom.HttpRespCount,
 		prom.AuthApiReqDuration, prom.AuthApiReqErrCount,
 		prom.UserApiReqDuration, prom.UserApiReqErrCount,
 		prom.ActionApiReqDuration, prom.ActionApiReqErrCount,
+		prom.CacheReqCount,
+		prom.RabbitPublishCount, prom.RabbitConsumeCount,
+		prom.OutboxDepth, prom.OutboxOldestAge, prom.OutboxDeadLetters,
+		prom.EventDropCount,
+		prom.SagaStepCount,
 	)
 
 	return prom
@@ -42,3 +42,9 @@ var UserApiGenderTypeToGatewayGenderType = map[UserApiProto.SpeakerGenderType]Ga
 	UserApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_MALE:    GatewayApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_MALE,
 	UserApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_FEMALE:  GatewayApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_FEMALE,
 }
+
+var UserApiGenderTypeToSpeakerApiGenderType = map[UserApiProto.SpeakerGenderType]SpeakerApiProto.SpeakerGenderType{
+	UserApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_UNKNOWN: SpeakerApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_UNKNOWN,
+	UserApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_MALE:    SpeakerApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_MALE,
+	UserApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_FEMALE:  SpeakerApiProto.SpeakerGenderType_SPEAKER_GENDER_TYPE_FEMALE,
+}
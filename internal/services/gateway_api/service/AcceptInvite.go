@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// AcceptInvite makes the invited user the collaborator, only the invited user can accept the invite
+func (s *GatewayApiService) AcceptInvite(ctx context.Context, request *entities.AcceptInviteRequest) (*entities.AcceptInviteResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "AcceptInvite").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	member, err := s.collaborators.Accept(ctx, request.InviteId, tokenClaims.UserId, time.Now())
+	if err != nil {
+		outerErr := _errors.FailedToAcceptInvite
+		switch {
+		case errors.Is(err, collaborators.ErrInviteNotFound):
+			outerErr = _errors.FailedToGetInviteNotFound
+		case errors.Is(err, collaborators.ErrInviteExpired):
+			outerErr = _errors.FailedToAcceptInviteExpired
+		}
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.AcceptInviteResponse{
+		CollectionId: member.CollectionId,
+		Role:         member.Role,
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
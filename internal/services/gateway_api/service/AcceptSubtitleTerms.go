@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// AcceptSubtitleTerms creates the chosen proposals of ExtractSubtitleTerms in the collection.
+// vocabulary_api has no field for the reference, so it is appended to the example.
+func (s *GatewayApiService) AcceptSubtitleTerms(ctx context.Context, request *entities.AcceptSubtitleTermsRequest) (*entities.AcceptSubtitleTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "AcceptSubtitleTerms").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// no more terms are accepted than ExtractSubtitleTerms proposes at most
+	if len(request.Terms) > s.cfg.Extract.MaxTerms {
+		outerErr := _errors.FailedToAcceptSubtitleTermsTooMany
+		lgr.Error().Int("terms", len(request.Terms)).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit); err != nil {
+		return nil, err
+	}
+
+	items := make([]*GatewayApiProto.CreateTermItem, len(request.Terms))
+	for i, term := range request.Terms {
+		items[i] = &GatewayApiProto.CreateTermItem{
+			CollectionId:      request.CollectionId,
+			TermLanguageId:    term.TermLanguageId,
+			MeaningLanguageId: term.MeaningLanguageId,
+			Term:              strings.TrimSpace(term.Term),
+			Meaning:           term.Meaning,
+			Example:           subtitleExample(term.Example, term.Reference),
+		}
+	}
+
+	if err := s.createTerms(ctx, lgr, tokenClaims.UserId, items); err != nil {
+		return nil, err
+	}
+
+	resp := &entities.AcceptSubtitleTermsResponse{
+		Created: len(items),
+	}
+
+	lgr.Debug().Int("created", resp.Created).Msg("executed")
+	return resp, nil
+}
+
+// subtitleExample appends the reference to the example, e.g. "How you doin'? (Friends S04E05 00:12:34)"
+func subtitleExample(example, reference string) string {
+	example = strings.TrimSpace(example)
+	reference = strings.TrimSpace(reference)
+	switch {
+	case reference == "":
+		return example
+	case example == "":
+		return "(" + reference + ")"
+	default:
+		return example + " (" + reference + ")"
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+)
+
+func TestAcceptSubtitleTerms(t *testing.T) {
+	s := newTestService(t)
+	s.cfg.Extract.MaxTerms = 2
+	vocabulary := &fakeVocabularyApiClient{latency: &latency{}, collection: &VocabularyApiProto.Collection{CollectionId: 10, UserId: 1}}
+	s.vocabularyApiClient = vocabulary
+
+	resp, err := s.AcceptSubtitleTerms(userContext(1), &entities.AcceptSubtitleTermsRequest{
+		CollectionId: 10,
+		Terms: []*entities.AcceptedSubtitleTerm{
+			{TermLanguageId: 1, MeaningLanguageId: 2, Term: "doin'", Meaning: "doing", Example: "How you doin'?", Reference: "Friends S04E05 00:12:34"},
+			{TermLanguageId: 1, Term: "pivot", Reference: "Friends S05E16 00:03:10"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Created != 2 {
+		t.Fatalf("Created = %d, want 2", resp.Created)
+	}
+
+	want := []string{"How you doin'? (Friends S04E05 00:12:34)", "(Friends S05E16 00:03:10)"}
+	if len(vocabulary.created) != len(want) {
+		t.Fatalf("created %d terms, want %d", len(vocabulary.created), len(want))
+	}
+	for i, term := range vocabulary.created {
+		if term.CollectionId != 10 || term.Example != want[i] {
+			t.Fatalf("term %d: got %+v, want the example %q in the collection 10", i, term, want[i])
+		}
+	}
+	if vocabulary.created[0].Meaning != "doing" || vocabulary.created[0].MeaningLanguageId != 2 {
+		t.Fatalf("got %+v, want the meaning of the user", vocabulary.created[0])
+	}
+}
+
+func TestAcceptSubtitleTermsTooMany(t *testing.T) {
+	s := newTestService(t)
+	s.cfg.Extract.MaxTerms = 1
+	vocabulary := &fakeVocabularyApiClient{latency: &latency{}, collection: &VocabularyApiProto.Collection{CollectionId: 10, UserId: 1}}
+	s.vocabularyApiClient = vocabulary
+
+	_, err := s.AcceptSubtitleTerms(userContext(1), &entities.AcceptSubtitleTermsRequest{
+		CollectionId: 10,
+		Terms: []*entities.AcceptedSubtitleTerm{
+			{TermLanguageId: 1, Term: "a"},
+			{TermLanguageId: 1, Term: "b"},
+		},
+	})
+	if !errors.Is(err, _errors.FailedToAcceptSubtitleTermsTooMany) {
+		t.Fatalf("got %v, want %v", err, _errors.FailedToAcceptSubtitleTermsTooMany)
+	}
+	if len(vocabulary.created) != 0 {
+		t.Fatalf("created %d terms, want none", len(vocabulary.created))
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// BackfillCatalog loads the public collections of the owners, which are unknown to the catalog:
+// the collections made public before the catalog existed produce no events. vocabulary_api can not list
+// all the users, so the owners are sent by the admin (e.g. exported from user_api) in batches.
+//
+// The found collections are put to the catalog shared by the instances, so no event is published and
+// the consumers of the domain events see no fake updates. The owners known to the catalog are kept
+// by the periodic sync.
+func (s *GatewayApiService) BackfillCatalog(ctx context.Context, request *entities.BackfillCatalogRequest) (*entities.BackfillCatalogResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "BackfillCatalog").
+		Int("owners", len(request.UserIds)).
+		Logger()
+
+	if !s.cfg.Catalog.Enabled {
+		outerErr := _errors.FailedToBackfillCatalogDisabled
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+	if len(request.UserIds) > s.cfg.Catalog.BackfillOwners {
+		outerErr := _errors.FailedToBackfillCatalogTooMany
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	owners := make([]uint64, 0, len(request.UserIds))
+	seen := make(map[uint64]struct{}, len(request.UserIds))
+	for _, userId := range request.UserIds {
+		if _, ok := seen[userId]; !ok {
+			seen[userId] = struct{}{}
+			owners = append(owners, userId)
+		}
+	}
+
+	loaded, failed := s.loadCatalogOwners(ctx, owners)
+
+	sort.Slice(failed, func(i, j int) bool { return failed[i] < failed[j] })
+	resp := &entities.BackfillCatalogResponse{
+		Owners:       len(owners),
+		Collections:  len(loaded),
+		FailedOwners: failed,
+	}
+
+	lgr.Info().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
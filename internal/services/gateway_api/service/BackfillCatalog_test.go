@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/catalog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBackfillCatalog(t *testing.T) {
+	collection := func(collectionId uint64, userId uint64, isPublic bool) *VocabularyApiProto.Collection {
+		return &VocabularyApiProto.Collection{
+			CollectionId: collectionId,
+			UserId:       userId,
+			Name:         "collection",
+			IsPublic:     isPublic,
+			CreatedAt:    timestamppb.Now(),
+			UpdatedAt:    timestamppb.Now(),
+		}
+	}
+
+	s := newTestService(t)
+	s.cfg = &config.Config{
+		Catalog: config.CatalogConfig{Enabled: true, SyncConcurrency: 2, BackfillOwners: 10},
+		Cache:   config.CacheConfig{Collections: config.CollectionsCacheConfig{LoadTimeout: time.Second}},
+	}
+	redisClient := newTestRedisClient(t)
+	s.catalog = catalog.New(redisClient, "test:catalog:")
+	publisher := &fakeEventPublisherClient{}
+	s.eventPublisherClient = publisher
+	s.vocabularyApiClient = &fakeVocabularyApiClient{
+		latency: &latency{},
+		collectionsByUser: map[uint64][]*VocabularyApiProto.Collection{
+			1: {collection(10, 1, true), collection(11, 1, false)},
+			2: {collection(20, 2, true), collection(21, 2, true)},
+			3: {},
+		},
+	}
+
+	resp, err := s.BackfillCatalog(context.Background(), &entities.BackfillCatalogRequest{UserIds: []uint64{1, 2, 3, 4, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &entities.BackfillCatalogResponse{Owners: 4, Collections: 3, FailedOwners: []uint64{4}}
+	if !reflect.DeepEqual(resp, want) {
+		t.Fatalf("got %+v, want %+v", resp, want)
+	}
+	// another instance sees the collections in the shared catalog, no events are published for them
+	other := catalog.New(redisClient, "test:catalog:")
+	if size, err := other.Len(context.Background()); err != nil || size != 3 {
+		t.Fatalf("got %d collections in the catalog, %v, want 3", size, err)
+	}
+	if len(publisher.published) != 0 {
+		t.Fatalf("got the events %v, want none", publisher.published)
+	}
+
+	_, err = s.BackfillCatalog(context.Background(), &entities.BackfillCatalogRequest{UserIds: make([]uint64, 11)})
+	if err == nil {
+		t.Fatal("got nil, want the too many owners error")
+	}
+}
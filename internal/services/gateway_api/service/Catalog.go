@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/catalog"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// catalogEvents are the events which may change the public collections
+var catalogEvents = []string{
+	events.CollectionCreatedName,
+	events.CollectionUpdatedName,
+	events.CollectionDeletedName,
+	events.CollectionClonedName,
+	events.TermsCreatedName,
+}
+
+// startCatalog starts the periodic sync, the events come by consumeCollectionEvents
+func (s *GatewayApiService) startCatalog() {
+	lgr := s.lgr.With().Str("worker", "catalog").Logger()
+	if !s.cfg.Rabbit.Events.Enabled {
+		lgr.Warn().Msg("events are disabled, the catalog is updated by the sync only")
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.Catalog.SyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.shutdownCh:
+				return
+			case <-ticker.C:
+				s.syncCatalog(lgr)
+			}
+		}
+	}()
+}
+
+// handleCatalogEvent the events carry the ids only, so the changed collection is loaded from vocabulary_api.
+// Every instance gets the event, the one claiming it applies it to the shared catalog, the others skip it.
+func (s *GatewayApiService) handleCatalogEvent(ctx context.Context, routingKey string, body []byte) error {
+	claimed, err := s.catalog.Claim(ctx, routingKey, body, s.cfg.Catalog.EventTTL)
+	if err != nil || !claimed {
+		return err
+	}
+
+	switch routingKey {
+	case events.CollectionCreatedName:
+		event := &events.CollectionCreated{}
+		if err := proto.Unmarshal(body, event); err != nil {
+			return err
+		}
+		return s.refreshCatalogEntry(ctx, event.CollectionId)
+	case events.CollectionUpdatedName:
+		event := &events.CollectionUpdated{}
+		if err := proto.Unmarshal(body, event); err != nil {
+			return err
+		}
+		return s.refreshCatalogEntry(ctx, event.CollectionId)
+	case events.TermsCreatedName:
+		event := &events.TermsCreated{}
+		if err := proto.Unmarshal(body, event); err != nil {
+			return err
+		}
+		return s.refreshCatalogEntry(ctx, event.CollectionId)
+	case events.CollectionDeletedName:
+		event := &events.CollectionDeleted{}
+		if err := proto.Unmarshal(body, event); err != nil {
+			return err
+		}
+		return s.catalog.Delete(ctx, event.CollectionId)
+	case events.CollectionClonedName:
+		event := &events.CollectionCloned{}
+		if err := proto.Unmarshal(body, event); err != nil {
+			return err
+		}
+		return s.catalog.AddClone(ctx, event.SourceCollectionId)
+	}
+	return nil
+}
+
+// refreshCatalogEntry puts the public collection to the catalog and removes the private or the deleted one
+func (s *GatewayApiService) refreshCatalogEntry(ctx context.Context, collectionId uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Cache.Collections.LoadTimeout)
+	defer cancel()
+
+	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
+		CollectionId: collectionId,
+	})
+	if status.Code(err) == codes.NotFound {
+		return s.catalog.Delete(ctx, collectionId)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !getCollection.Collection.IsPublic {
+		return s.catalog.Delete(ctx, collectionId)
+	}
+	return s.catalog.Put(ctx, catalogEntry(getCollection.Collection))
+}
+
+// syncCatalog reloads the collections of the owners known to the catalog. vocabulary_api can not list
+// the public collections of all the users, so the new owners come to the catalog by the events
+// and by BackfillCatalog only. One of the instances syncs the shared catalog per interval.
+func (s *GatewayApiService) syncCatalog(lgr zerolog.Logger) {
+	ctx := context.Background()
+	start := time.Now()
+
+	locked, err := s.catalog.LockSync(ctx, start, s.cfg.Catalog.SyncInterval)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed to sync the catalog")
+		return
+	}
+	if !locked {
+		lgr.Debug().Msg("the catalog is synced by another instance")
+		return
+	}
+
+	owners, err := s.catalog.Owners(ctx)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed to sync the catalog")
+		return
+	}
+	_, failed := s.loadCatalogOwners(ctx, owners)
+
+	size, err := s.catalog.Len(ctx)
+	if err != nil {
+		lgr.Warn().Err(err).Msg("failed to count the catalog")
+	}
+	lgr.Info().
+		Int("owners", len(owners)).
+		Int("failed", len(failed)).
+		Int64("size", size).
+		Dur("duration", time.Since(start)).
+		Msg("catalog is synced")
+}
+
+// loadCatalogOwners replaces the collections of the owners in the catalog, it returns the public collections
+// and the owners which failed to load
+func (s *GatewayApiService) loadCatalogOwners(ctx context.Context, owners []uint64) ([]catalog.Entry, []uint64) {
+	var mu sync.Mutex
+	var loaded []catalog.Entry
+	failed := make([]uint64, 0)
+
+	// a failed owner does not stop the others, so the branches never fail
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.Catalog.SyncConcurrency)
+	for _, userId := range owners {
+		userId := userId
+		group.Go(strconv.FormatUint(userId, 10), func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, s.cfg.Cache.Collections.LoadTimeout)
+			defer cancel()
+
+			getCollections, err := s.vocabularyApiClient.GetCollections(ctx, &VocabularyApiProto.GetCollectionsRequest{
+				UserId: userId,
+			})
+			if err != nil {
+				mu.Lock()
+				failed = append(failed, userId)
+				mu.Unlock()
+				return nil
+			}
+
+			entries := make([]catalog.Entry, 0, len(getCollections.Collections))
+			for _, collection := range getCollections.Collections {
+				if collection.IsPublic {
+					entries = append(entries, catalogEntry(collection))
+				}
+			}
+			if err = s.catalog.PutOwner(ctx, userId, entries); err != nil {
+				mu.Lock()
+				failed = append(failed, userId)
+				mu.Unlock()
+				return nil
+			}
+
+			mu.Lock()
+			loaded = append(loaded, entries...)
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	return loaded, failed
+}
+
+func catalogEntry(collection *VocabularyApiProto.Collection) catalog.Entry {
+	return catalog.Entry{
+		CollectionId: collection.CollectionId,
+		UserId:       collection.UserId,
+		LanguageId:   collection.LanguageId,
+		Name:         collection.Name,
+		Description:  collection.Description,
+		TotalTerms:   collection.TotalTerms,
+		CreatedAt:    collection.CreatedAt.AsTime(),
+		UpdatedAt:    collection.UpdatedAt.AsTime(),
+	}
+}
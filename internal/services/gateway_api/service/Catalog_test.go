@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/catalog"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestHandleCatalogEventAppliedOnce(t *testing.T) {
+	ctx := context.Background()
+	redisClient := newTestRedisClient(t)
+	vocabulary := &fakeVocabularyApiClient{latency: &latency{}, collection: &VocabularyApiProto.Collection{
+		CollectionId: 10,
+		UserId:       1,
+		Name:         "Travel",
+		IsPublic:     true,
+		CreatedAt:    timestamppb.Now(),
+		UpdatedAt:    timestamppb.Now(),
+	}}
+	// every instance consumes all the events through its own queue
+	instances := make([]*GatewayApiService, 3)
+	for i := range instances {
+		s := newTestService(t)
+		s.cfg = &config.Config{
+			Catalog: config.CatalogConfig{Enabled: true, EventTTL: time.Hour},
+			Cache:   config.CacheConfig{Collections: config.CollectionsCacheConfig{LoadTimeout: time.Second}},
+		}
+		s.catalog = catalog.New(redisClient, "test:catalog:")
+		s.vocabularyApiClient = vocabulary
+		instances[i] = s
+	}
+
+	deliver := func(routingKey string, event proto.Message) {
+		body, err := proto.Marshal(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range instances {
+			if err = s.handleCatalogEvent(ctx, routingKey, body); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	deliver(events.CollectionCreatedName, &events.CollectionCreated{CollectionId: 10, UserId: 1, OccurredAt: timestamppb.Now()})
+	deliver(events.CollectionClonedName, &events.CollectionCloned{CollectionId: 20, SourceCollectionId: 10, OccurredAt: timestamppb.Now()})
+	deliver(events.CollectionClonedName, &events.CollectionCloned{CollectionId: 21, SourceCollectionId: 10, OccurredAt: timestamppb.Now()})
+
+	page, err := instances[1].catalog.Search(ctx, catalog.Query{Sort: catalog.SortPopular, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Entries) != 1 || page.Entries[0].Clones != 2 {
+		t.Fatalf("got %+v, want the collection with 2 clones", page.Entries)
+	}
+}
@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
+	"github.com/rs/zerolog"
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 )
@@ -27,12 +30,18 @@ func (s *GatewayApiService) ChangeTermStatus(ctx context.Context, request *Gatew
 		return nil, outerErr
 	}
 
-	_, err := s.vocabularyApiClient.ChangeTermStatus(ctx, &VocabularyApiProto.ChangeTermStatusRequest{
-		UserId:       tokenClaims.UserId,
-		CollectionId: request.CollectionId,
-		TermId:       request.TermId,
-		Status:       mappers.GatewayStatusToVocabularyStatus[request.Status],
-	})
+	collection, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	// The old status is only needed for the event
+	oldStatus := GatewayApiProto.StatusType_STATUS_TYPE_UNKNOWN
+	if s.cfg.Rabbit.Events.Enabled {
+		oldStatus = s.getTermStatus(ctx, lgr, request.CollectionId, request.TermId)
+	}
+
+	err = s.changeTermStatus(ctx, lgr, tokenClaims.UserId, collection.UserId, request.CollectionId, request.TermId, oldStatus, request.Status)
 	if err != nil {
 		outerErr := _errors.FailedToChangeTermStatus
 		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
@@ -42,3 +51,66 @@ func (s *GatewayApiService) ChangeTermStatus(ctx context.Context, request *Gatew
 	lgr.Debug().Msg("executed")
 	return &GatewayApiProto.ChangeTermStatusResponse{}, nil
 }
+
+// changeTermStatus changes the status and publishes the event, oldStatus is used only for the event.
+// vocabulary_api accepts the changes from the owner only, so the change of the editor is made on behalf of the owner.
+func (s *GatewayApiService) changeTermStatus(
+	ctx context.Context,
+	lgr zerolog.Logger,
+	userId uint64,
+	ownerId uint64,
+	collectionId uint64,
+	termId uint64,
+	oldStatus GatewayApiProto.StatusType,
+	newStatus GatewayApiProto.StatusType,
+) error {
+	_, err := s.vocabularyApiClient.ChangeTermStatus(ctx, &VocabularyApiProto.ChangeTermStatusRequest{
+		UserId:       ownerId,
+		CollectionId: collectionId,
+		TermId:       termId,
+		Status:       mappers.GatewayStatusToVocabularyStatus[newStatus],
+	})
+	if err != nil {
+		return err
+	}
+
+	s.eventPublisherClient.Publish(ctx, lgr, events.TermStatusChangedName, &events.TermStatusChanged{
+		CollectionId: collectionId,
+		TermId:       termId,
+		UserId:       userId,
+		OldStatus:    int32(oldStatus),
+		NewStatus:    int32(newStatus),
+		OccurredAt:   timestamppb.Now(),
+	})
+	return nil
+}
+
+// getTermStatus returns the unknown status if the term can not be found, it never fails the request
+func (s *GatewayApiService) getTermStatus(ctx context.Context, lgr zerolog.Logger, collectionId uint64, termId uint64) GatewayApiProto.StatusType {
+	term, err := s.getTerm(ctx, collectionId, termId)
+	if err != nil {
+		lgr.Warn().Err(err).Msg(_errors.FailedToGetTermsMsg)
+		return GatewayApiProto.StatusType_STATUS_TYPE_UNKNOWN
+	}
+	if term == nil {
+		return GatewayApiProto.StatusType_STATUS_TYPE_UNKNOWN
+	}
+	return mappers.VocabularyStatusToGatewayStatus[term.Status]
+}
+
+// getTerm returns nil if there is no such term in the collection
+func (s *GatewayApiService) getTerm(ctx context.Context, collectionId uint64, termId uint64) (*VocabularyApiProto.Term, error) {
+	getTerms, err := s.vocabularyApiClient.GetTerms(ctx, &VocabularyApiProto.GetTermsRequest{
+		CollectionId: collectionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, term := range getTerms.Terms {
+		if term.TermId == termId {
+			return term, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/grpc/status"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+)
+
+// statusChangeCollection the access to the collection checked once for all its items
+type statusChangeCollection struct {
+	access *collectionAccess
+	err    error
+}
+
+// ChangeTermStatuses changes the statuses of many terms at once, e.g. after a study session.
+// The access is checked once per collection, the items of the forbidden collections fail alone.
+// The old status of every term is read for the event like in ChangeTermStatus.
+func (s *GatewayApiService) ChangeTermStatuses(ctx context.Context, request *entities.ChangeTermStatusesRequest) (*entities.ChangeTermStatusesResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "ChangeTermStatuses").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if len(request.Items) > s.cfg.BulkStatus.MaxItems {
+		outerErr := _errors.FailedToChangeTermStatusesTooMany
+		lgr.Error().Int("items", len(request.Items)).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	statuses := make([]GatewayApiProto.StatusType, len(request.Items))
+	collections := make(map[uint64]*statusChangeCollection)
+	for i, item := range request.Items {
+		value, ok := GatewayApiProto.StatusType_value[item.Status]
+		if !ok || GatewayApiProto.StatusType(value) == GatewayApiProto.StatusType_STATUS_TYPE_UNKNOWN {
+			outerErr := _errors.FailedToChangeTermStatusesInvalid
+			lgr.Error().Str("status", item.Status).Msg(outerErr.ErrorMessage)
+			return nil, outerErr
+		}
+		statuses[i] = GatewayApiProto.StatusType(value)
+		collections[item.CollectionId] = &statusChangeCollection{}
+	}
+
+	// the branches never fail, the errors are kept per collection
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.BulkStatus.Concurrency)
+	for collectionId, collection := range collections {
+		collectionId, collection := collectionId, collection
+		group.Go(fmt.Sprint(collectionId), func(ctx context.Context) error {
+			collection.access, collection.err = s.authorizeCollection(ctx, lgr, tokenClaims.UserId, collectionId, accessEdit)
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	resp := &entities.ChangeTermStatusesResponse{
+		Items: make([]*entities.TermStatusChangeResult, len(request.Items)),
+	}
+
+	group, _ = fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.BulkStatus.Concurrency)
+	for i, item := range request.Items {
+		i, item := i, item
+		result := &entities.TermStatusChangeResult{
+			TermId:       item.TermId,
+			CollectionId: item.CollectionId,
+		}
+		resp.Items[i] = result
+
+		collection := collections[item.CollectionId]
+		if collection.err != nil {
+			result.Result = entities.TermStatusForbidden
+			result.Error = collection.err.Error()
+			continue
+		}
+
+		group.Go(fmt.Sprint(item.TermId), func(ctx context.Context) error {
+			// The old status is only needed for the event
+			oldStatus := GatewayApiProto.StatusType_STATUS_TYPE_UNKNOWN
+			if s.cfg.Rabbit.Events.Enabled {
+				oldStatus = s.getTermStatus(ctx, lgr, item.CollectionId, item.TermId)
+			}
+
+			err := s.changeTermStatus(ctx, lgr, tokenClaims.UserId, collection.access.UserId, item.CollectionId, item.TermId, oldStatus, statuses[i])
+			if err != nil {
+				lgr.Error().Err(err).Uint64("term_id", item.TermId).Msg(_errors.FailedToChangeTermStatusMsg)
+				result.Result = entities.TermStatusFailed
+				result.Error = status.Convert(err).Message()
+				return nil
+			}
+			result.Result = entities.TermStatusChanged
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	for _, result := range resp.Items {
+		if result.Result == entities.TermStatusChanged {
+			resp.Changed++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	lgr.Debug().Int("changed", resp.Changed).Int("failed", resp.Failed).Msg("executed")
+	return resp, nil
+}
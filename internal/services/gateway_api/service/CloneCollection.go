@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/clone_source"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/saga"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Steps of the CloneCollection saga
+const (
+	cloneCollectionSaga           = "clone_collection"
+	cloneCollectionStepCreate     = "create_collection"
+	cloneCollectionStepCopyTerms  = "copy_terms"
+	cloneCollectionStepKeepSource = "keep_source"
+)
+
+// CloneCollection copies the public collection (or the own one) to the user, the terms start from NEW.
+// It is the saga:
+//  1. create_collection - the private copy with the metadata of the source, it is deleted if the saga fails after it.
+//     The failed delete is retried in the background, the copy is left only when the retries are exhausted.
+//  2. copy_terms - the terms are created in chunks, a failed chunk fails the saga, so no half-copied collection is left
+//  3. keep_source - the source collection and its owner are stored in Redis for the attribution,
+//     vocabulary_api has no field for them. It is retried in the background, the copy is usable without it.
+//
+// The source of the copy is returned by GetCollectionSource.
+func (s *GatewayApiService) CloneCollection(ctx context.Context, request *entities.CloneCollectionRequest) (*entities.CloneCollectionResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "CloneCollection").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	terms, err := s.getReadableTerms(ctx, lgr, tokenClaims.UserId, request.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
+		CollectionId: request.CollectionId,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToGetCollection
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+	source := getCollection.Collection
+
+	var collectionId uint64
+	clonedAt := time.Now()
+	clone := saga.New(cloneCollectionSaga, s.sagaRetrier, lgr, s.prom).
+		AddStep(saga.Step{
+			Name: cloneCollectionStepCreate,
+			Action: func(ctx context.Context) error {
+				createCollection, err := s.vocabularyApiClient.CreateCollection(ctx, &VocabularyApiProto.CreateCollectionRequest{
+					UserId:      tokenClaims.UserId,
+					LanguageId:  source.LanguageId,
+					Name:        source.Name,
+					Description: source.Description,
+				})
+				if err != nil {
+					return err
+				}
+				collectionId = createCollection.CollectionId
+				return nil
+			},
+			Compensate: func(ctx context.Context) error {
+				_, err := s.vocabularyApiClient.DeleteCollection(ctx, &VocabularyApiProto.DeleteCollectionRequest{
+					UserId:       tokenClaims.UserId,
+					CollectionId: collectionId,
+				})
+				// the retried delete finds the collection deleted by the previous attempt
+				if status.Code(err) == codes.NotFound {
+					return nil
+				}
+				return err
+			},
+			RetryCompensation: true,
+		}).
+		AddStep(saga.Step{
+			Name: cloneCollectionStepCopyTerms,
+			Action: func(ctx context.Context) error {
+				return s.copyTerms(ctx, collectionId, terms)
+			},
+		}).
+		AddStep(saga.Step{
+			Name:       cloneCollectionStepKeepSource,
+			Deferrable: true,
+			Action: func(ctx context.Context) error {
+				return s.cloneSources.Put(ctx, &clone_source.Source{
+					CollectionId:       collectionId,
+					SourceCollectionId: source.CollectionId,
+					SourceUserId:       source.UserId,
+					ClonedAt:           clonedAt,
+				})
+			},
+		})
+
+	if err = clone.Run(ctx, detachContext(ctx)); err != nil {
+		var stepErr *saga.StepError
+		if !errors.As(err, &stepErr) {
+			stepErr = &saga.StepError{Err: err}
+		}
+
+		outerErr := _errors.FailedToCloneCollection
+		if stepErr.Step == cloneCollectionStepCreate {
+			outerErr = _errors.FailedToCreateCollection
+		}
+		lgr.Error().Err(err).Str("step", stepErr.Step).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	s.eventPublisherClient.Publish(ctx, lgr, events.CollectionCreatedName, &events.CollectionCreated{
+		CollectionId: collectionId,
+		UserId:       tokenClaims.UserId,
+		OccurredAt:   timestamppb.Now(),
+	})
+	s.eventPublisherClient.Publish(ctx, lgr, events.CollectionClonedName, &events.CollectionCloned{
+		CollectionId:       collectionId,
+		UserId:             tokenClaims.UserId,
+		SourceCollectionId: source.CollectionId,
+		SourceUserId:       source.UserId,
+		TermsCount:         uint32(len(terms)),
+		OccurredAt:         timestamppb.Now(),
+	})
+
+	resp := &entities.CloneCollectionResponse{
+		CollectionId:       collectionId,
+		SourceCollectionId: source.CollectionId,
+		TermsCount:         len(terms),
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
+
+// copyTerms creates the terms in the collection by chunks, the status is not copied, so they start from NEW
+func (s *GatewayApiService) copyTerms(ctx context.Context, collectionId uint64, terms []*VocabularyApiProto.Term) error {
+	for start := 0; start < len(terms); start += s.cfg.Clone.ChunkSize {
+		end := min(start+s.cfg.Clone.ChunkSize, len(terms))
+
+		chunk := make([]*VocabularyApiProto.CreateTerms, 0, end-start)
+		for _, term := range terms[start:end] {
+			chunk = append(chunk, &VocabularyApiProto.CreateTerms{
+				CollectionId:      collectionId,
+				TermLanguageId:    term.TermLanguageId,
+				MeaningLanguageId: term.MeaningLanguageId,
+				Term:              term.Term,
+				Meaning:           term.Meaning,
+				Example:           term.Example,
+				ImageUrl:          term.ImageUrl,
+			})
+		}
+
+		_, err := s.vocabularyApiClient.CreateTerms(ctx, &VocabularyApiProto.CreateTermsRequest{
+			Terms: chunk,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
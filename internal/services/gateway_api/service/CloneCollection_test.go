@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+)
+
+func TestCloneCollectionKeepsSource(t *testing.T) {
+	s := newTestService(t)
+	defer s.sagaRetrier.Stop()
+	s.cfg.Clone.ChunkSize = 1
+	vocabulary := &fakeVocabularyApiClient{
+		latency:             &latency{},
+		collection:          &VocabularyApiProto.Collection{CollectionId: 10, UserId: 1, IsPublic: true, Name: "Travel"},
+		terms:               []*VocabularyApiProto.Term{{TermId: 100, Term: "hello"}, {TermId: 101, Term: "world"}},
+		createdCollectionId: 20,
+	}
+	s.vocabularyApiClient = vocabulary
+
+	clone, err := s.CloneCollection(userContext(2), &entities.CloneCollectionRequest{CollectionId: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.CollectionId != 20 || clone.TermsCount != 2 || len(vocabulary.created) != 2 {
+		t.Fatalf("CloneCollection() = %+v, created %d terms", clone, len(vocabulary.created))
+	}
+
+	tests := []struct {
+		name         string
+		collectionId uint64
+		want         *entities.CollectionSource
+	}{
+		{name: "clone", collectionId: 20, want: &entities.CollectionSource{CollectionId: 10, UserId: 1}},
+		{name: "not a clone", collectionId: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetCollectionSource(userContext(2), &entities.GetCollectionSourceRequest{CollectionId: tt.collectionId})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == nil {
+				if resp.Source != nil {
+					t.Fatalf("Source = %+v, want nil", resp.Source)
+				}
+				return
+			}
+			if resp.Source == nil || resp.Source.CollectionId != tt.want.CollectionId || resp.Source.UserId != tt.want.UserId || resp.Source.ClonedAt.IsZero() {
+				t.Fatalf("Source = %+v, want %+v", resp.Source, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collectionAccessEvents are the events which may change the owner or the public flag of the collection
+var collectionAccessEvents = []string{
+	events.CollectionUpdatedName,
+	events.CollectionDeletedName,
+}
+
+// consumeCollectionEvents subscribes every instance to the collection events: the cached access is invalidated
+// on all the instances, not only on the one which changed the collection, and the catalog is updated
+func (s *GatewayApiService) consumeCollectionEvents() {
+	routingKeys := slices.Clone(collectionAccessEvents)
+	if s.cfg.Catalog.Enabled {
+		for _, routingKey := range catalogEvents {
+			if !slices.Contains(routingKeys, routingKey) {
+				routingKeys = append(routingKeys, routingKey)
+			}
+		}
+	}
+
+	if err := s.eventConsumerClient.Consume(routingKeys, s.handleCollectionEvent); err != nil {
+		s.lgr.Fatal().Err(err).Msg("failed to consume the collection events")
+	}
+}
+
+func (s *GatewayApiService) handleCollectionEvent(ctx context.Context, routingKey string, body []byte) error {
+	switch routingKey {
+	case events.CollectionUpdatedName:
+		event := &events.CollectionUpdated{}
+		if err := proto.Unmarshal(body, event); err != nil {
+			return err
+		}
+		s.invalidateCollectionAccess(event.CollectionId)
+	case events.CollectionDeletedName:
+		event := &events.CollectionDeleted{}
+		if err := proto.Unmarshal(body, event); err != nil {
+			return err
+		}
+		s.invalidateCollectionAccess(event.CollectionId)
+	}
+
+	if !s.cfg.Catalog.Enabled {
+		return nil
+	}
+	return s.handleCatalogEvent(ctx, routingKey, body)
+}
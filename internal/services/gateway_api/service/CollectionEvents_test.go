@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"google.golang.org/protobuf/proto"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+)
+
+func TestHandleCollectionEventInvalidatesAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		routingKey string
+		event      proto.Message
+		wantPublic bool
+	}{
+		{name: "updated", routingKey: events.CollectionUpdatedName, event: &events.CollectionUpdated{CollectionId: 10}},
+		{name: "deleted", routingKey: events.CollectionDeletedName, event: &events.CollectionDeleted{CollectionId: 10}},
+		{name: "another collection", routingKey: events.CollectionUpdatedName, event: &events.CollectionUpdated{CollectionId: 11}, wantPublic: true},
+		{name: "not an access event", routingKey: events.TermsCreatedName, event: &events.TermsCreated{CollectionId: 10}, wantPublic: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTestService(t)
+			s.cfg.Cache.Collections.Enabled = true
+			vocabulary := &fakeVocabularyApiClient{latency: &latency{}, collection: &VocabularyApiProto.Collection{CollectionId: 10, UserId: 1, IsPublic: true}}
+			s.vocabularyApiClient = vocabulary
+
+			if _, err := s.getCollectionAccess(ctx, 10); err != nil {
+				t.Fatal(err)
+			}
+			// the collection is made private through another instance
+			vocabulary.collection = &VocabularyApiProto.Collection{CollectionId: 10, UserId: 1}
+
+			body, err := proto.Marshal(tt.event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = s.handleCollectionEvent(ctx, tt.routingKey, body); err != nil {
+				t.Fatal(err)
+			}
+
+			access, err := s.getCollectionAccess(ctx, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if access.IsPublic != tt.wantPublic {
+				t.Fatalf("IsPublic = %v, want %v", access.IsPublic, tt.wantPublic)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// CopyTerms copies the terms to the target collection with their statuses, the time of the last review is not kept
+func (s *GatewayApiService) CopyTerms(ctx context.Context, request *entities.TransferTermsRequest) (*entities.TransferTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "CopyTerms").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp, err := s.transferTerms(ctx, lgr, tokenClaims.UserId, request, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
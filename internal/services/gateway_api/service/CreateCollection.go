@@ -4,9 +4,11 @@ import (
 	"context"
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 )
@@ -39,6 +41,12 @@ func (s *GatewayApiService) CreateCollection(ctx context.Context, request *Gatew
 		return nil, outerErr
 	}
 
+	s.eventPublisherClient.Publish(ctx, lgr, events.CollectionCreatedName, &events.CollectionCreated{
+		CollectionId: createCollection.CollectionId,
+		UserId:       tokenClaims.UserId,
+		OccurredAt:   timestamppb.Now(),
+	})
+
 	resp := &GatewayApiProto.CreateCollectionResponse{
 		CollectionId: createCollection.CollectionId,
 	}
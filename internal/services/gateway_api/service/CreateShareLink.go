@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/share_link"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// CreateShareLink creates the read-only link to the collection of the owner, the collection may stay private.
+// The link opens GetSharedCollection and GetSharedTerms without the sign in.
+func (s *GatewayApiService) CreateShareLink(ctx context.Context, request *entities.CreateShareLinkRequest) (*entities.CreateShareLinkResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "CreateShareLink").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessOwn); err != nil {
+		return nil, err
+	}
+
+	linkId, err := share_link.NewLinkId()
+	if err != nil {
+		outerErr := _errors.FailedToCreateShareLink
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	now := time.Now()
+	link := &share_link.Link{
+		LinkId:       linkId,
+		CollectionId: request.CollectionId,
+		UserId:       tokenClaims.UserId,
+		CreatedAt:    now,
+	}
+	if request.ExpiresIn > 0 {
+		link.ExpiresAt = now.Add(time.Duration(request.ExpiresIn) * time.Second)
+	}
+
+	token, err := share_link.Sign([]byte(s.cfg.ShareLink.Secret), share_link.Claims{
+		LinkId:       link.LinkId,
+		CollectionId: link.CollectionId,
+		ExpiresAt:    link.ExpiresAt,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToCreateShareLink
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	url := s.cfg.ShareLink.BaseUrl + token
+	qrCode, err := qrcode.Encode(url, qrcode.Medium, s.cfg.ShareLink.QrCodeSize)
+	if err != nil {
+		outerErr := _errors.FailedToCreateShareLink
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if err = s.shareLinks.Put(ctx, link); err != nil {
+		outerErr := _errors.FailedToCreateShareLink
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.CreateShareLinkResponse{
+		LinkId:    link.LinkId,
+		Token:     token,
+		Url:       url,
+		QrCodePng: qrCode,
+	}
+	if !link.ExpiresAt.IsZero() {
+		resp.ExpiresAt = &link.ExpiresAt
+	}
+
+	lgr.Debug().Str("link_id", link.LinkId).Msg("executed")
+	return resp, nil
+}
@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"github.com/rs/zerolog"
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 )
@@ -26,6 +29,22 @@ func (s *GatewayApiService) CreateTerms(ctx context.Context, request *GatewayApi
 		return nil, outerErr
 	}
 
+	if _, err := s.checkCreateTerms(ctx, lgr, tokenClaims.UserId, request); err != nil {
+		return nil, err
+	}
+
+	if err := s.createTerms(ctx, lgr, tokenClaims.UserId, request.Terms); err != nil {
+		return nil, err
+	}
+
+	resp := &GatewayApiProto.CreateTermsResponse{}
+
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
+
+// checkCreateTerms checks that all the terms go to one collection editable by the user and returns the collection
+func (s *GatewayApiService) checkCreateTerms(ctx context.Context, lgr zerolog.Logger, userId uint64, request *GatewayApiProto.CreateTermsRequest) (*collectionAccess, error) {
 	for i, term := range request.Terms {
 		if i == 0 {
 			continue
@@ -38,23 +57,12 @@ func (s *GatewayApiService) CreateTerms(ctx context.Context, request *GatewayApi
 		}
 	}
 
-	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
-		CollectionId: request.Terms[0].CollectionId,
-	})
-	if err != nil {
-		outerErr := _errors.FailedToGetCollection
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
-	}
-
-	if getCollection.Collection.UserId != tokenClaims.UserId {
-		outerErr := _errors.FailedToGetCollectionPrivateCollection
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
-	}
+	return s.authorizeCollection(ctx, lgr, userId, request.Terms[0].CollectionId, accessEdit)
+}
 
-	terms := make([]*VocabularyApiProto.CreateTerms, len(request.Terms))
-	for i, term := range request.Terms {
+func (s *GatewayApiService) createTerms(ctx context.Context, lgr zerolog.Logger, userId uint64, items []*GatewayApiProto.CreateTermItem) error {
+	terms := make([]*VocabularyApiProto.CreateTerms, len(items))
+	for i, term := range items {
 		terms[i] = &VocabularyApiProto.CreateTerms{
 			CollectionId:      term.CollectionId,
 			TermLanguageId:    term.TermLanguageId,
@@ -66,17 +74,20 @@ func (s *GatewayApiService) CreateTerms(ctx context.Context, request *GatewayApi
 		}
 	}
 
-	_, err = s.vocabularyApiClient.CreateTerms(ctx, &VocabularyApiProto.CreateTermsRequest{
+	_, err := s.vocabularyApiClient.CreateTerms(ctx, &VocabularyApiProto.CreateTermsRequest{
 		Terms: terms,
 	})
 	if err != nil {
 		outerErr := _errors.FailedToCreateTerms
 		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
+		return outerErr
 	}
 
-	resp := &GatewayApiProto.CreateTermsResponse{}
-
-	lgr.Debug().Msg("executed")
-	return resp, nil
+	s.eventPublisherClient.Publish(ctx, lgr, events.TermsCreatedName, &events.TermsCreated{
+		CollectionId: items[0].CollectionId,
+		UserId:       userId,
+		Count:        uint32(len(items)),
+		OccurredAt:   timestamppb.Now(),
+	})
+	return nil
 }
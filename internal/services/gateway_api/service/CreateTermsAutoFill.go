@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rs/zerolog"
+	SpeakerApiProto "gitlab.com/wbwapis/go-genproto/wbw/speaker/speaker_api/v1"
+	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+)
+
+const (
+	autoFillBranchUser      = "user"
+	autoFillBranchLanguages = "languages"
+)
+
+// CreateTermsAutoFill creates the terms like CreateTerms, but first fills the empty meanings with the translation
+// from the collection language to the interface language of the user and loads the voiceovers of the terms.
+// The enrichment is limited by the timeout, the terms whose enrichment failed are created as they are.
+func (s *GatewayApiService) CreateTermsAutoFill(ctx context.Context, request *GatewayApiProto.CreateTermsRequest) (*entities.CreateTermsAutoFillResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "CreateTermsAutoFill").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collection, err := s.checkCreateTerms(ctx, lgr, tokenClaims.UserId, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// the request is logged as it came, so the filled terms are copies
+	items := make([]*GatewayApiProto.CreateTermItem, len(request.Terms))
+	for i, term := range request.Terms {
+		items[i] = &GatewayApiProto.CreateTermItem{
+			CollectionId:      term.CollectionId,
+			TermLanguageId:    term.TermLanguageId,
+			MeaningLanguageId: term.MeaningLanguageId,
+			Term:              term.Term,
+			Meaning:           term.Meaning,
+			Example:           term.Example,
+			ImageUrl:          term.ImageUrl,
+		}
+	}
+
+	report := s.autoFillTerms(ctx, lgr, tokenClaims.UserId, collection, items)
+
+	if err = s.createTerms(ctx, lgr, tokenClaims.UserId, items); err != nil {
+		return nil, err
+	}
+
+	resp := &entities.CreateTermsAutoFillResponse{
+		Terms: report,
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
+
+// autoFillTerms fills the items in place and reports every item, it never fails as a whole
+func (s *GatewayApiService) autoFillTerms(ctx context.Context, lgr zerolog.Logger, userId uint64, collection *collectionAccess, items []*GatewayApiProto.CreateTermItem) []*entities.AutoFilledTerm {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.AutoFill.Timeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	report := make([]*entities.AutoFilledTerm, len(items))
+	for i, item := range items {
+		report[i] = &entities.AutoFilledTerm{
+			Term:    item.Term,
+			Meaning: item.Meaning,
+		}
+	}
+	fail := func(i int, msg string) {
+		mu.Lock()
+		report[i].Errors = append(report[i].Errors, msg)
+		mu.Unlock()
+	}
+
+	var settings *UserApiProto.Settings
+	var languages *GatewayApiProto.GetLanguagesResponse
+	group, _ := fan_out.WithContext(ctx)
+	group.Go(autoFillBranchUser, func(ctx context.Context) error {
+		getUser, err := s.userApiClient.GetUser(ctx, &UserApiProto.GetUserRequest{
+			FindBy: &UserApiProto.GetUserRequest_UserId{
+				UserId: userId,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		settings = getUser.User.Settings
+		return nil
+	})
+	group.Go(autoFillBranchLanguages, func(ctx context.Context) error {
+		var err error
+		languages, err = s.listLanguages(ctx, lgr)
+		return err
+	})
+	if err := group.Wait(); err != nil {
+		msg := _errors.FailedToGetUserMsg
+		var branchErr *fan_out.BranchError
+		if errors.As(err, &branchErr) && branchErr.Branch == autoFillBranchLanguages {
+			msg = _errors.FailedToGetLanguagesMsg
+		}
+		lgr.Warn().Err(err).Msg(msg)
+		for i := range items {
+			fail(i, msg)
+		}
+		return report
+	}
+
+	codes := make(map[uint64]string, len(languages.Languages))
+	for _, language := range languages.Languages {
+		codes[language.LanguageId] = language.Code
+	}
+	gender := mappers.UserApiGenderTypeToSpeakerApiGenderType[settings.SpeakerGender]
+
+	group, _ = fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.AutoFill.Concurrency)
+	for i, item := range items {
+		i, item := i, item
+		if item.TermLanguageId == 0 {
+			item.TermLanguageId = collection.LanguageId
+		}
+
+		if item.Meaning == "" {
+			if item.MeaningLanguageId == 0 {
+				item.MeaningLanguageId = settings.InterfaceLanguageId
+			}
+
+			sourceLanguage, targetLanguage := codes[item.TermLanguageId], codes[item.MeaningLanguageId]
+			if sourceLanguage == "" || targetLanguage == "" {
+				fail(i, _errors.FailedToAutoFillLanguageNotFoundMsg)
+			} else {
+				group.Go(item.Term, func(ctx context.Context) error {
+					texts, err := s.translate(ctx, &GatewayApiProto.GetTranslationRequest{
+						Text:           item.Term,
+						SourceLanguage: sourceLanguage,
+						TargetLanguage: targetLanguage,
+					})
+					if err != nil {
+						fail(i, _errors.FailedToGetTranslationMsg)
+						return nil
+					}
+					if len(texts) == 0 || texts[0] == "" {
+						fail(i, _errors.FailedToAutoFillTranslationNotFoundMsg)
+						return nil
+					}
+
+					// the voiceover branch of the item writes only the voiceover url, so the fields do not race
+					item.Meaning = texts[0]
+					report[i].Meaning = texts[0]
+					report[i].MeaningFilled = true
+					return nil
+				})
+			}
+		}
+
+		group.Go(item.Term, func(ctx context.Context) error {
+			voiceover, err := s.speakerApiClient.GetVoiceover(ctx, &SpeakerApiProto.GetVoiceoverRequest{
+				Text:       item.Term,
+				LanguageId: item.TermLanguageId,
+				Gender:     gender,
+			})
+			if err != nil {
+				fail(i, _errors.FailedToGetVoiceoverMsg)
+				return nil
+			}
+			report[i].VoiceoverUrl = voiceover.Url
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	failed := 0
+	for _, term := range report {
+		if len(term.Errors) > 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		lgr.Warn().Int("failed", failed).Err(ctx.Err()).Msg("auto fill of some terms failed")
+	}
+
+	return report
+}
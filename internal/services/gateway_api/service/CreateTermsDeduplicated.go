@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_match"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/grpc/status"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+)
+
+// meaningSeparator joins the meanings of the merged terms
+const meaningSeparator = "; "
+
+// termMerge collects the fields of the new terms merged into the existing one
+type termMerge struct {
+	term    *VocabularyApiProto.Term
+	meaning string
+	example string
+	image   string
+	results []*entities.DeduplicatedTerm
+}
+
+func (m *termMerge) changed() bool {
+	return m.meaning != m.term.Meaning || m.example != m.term.Example || m.image != m.term.ImageUrl
+}
+
+// mergeTermFields adds the meaning unless it is there already, the example and the image fill only the empty ones
+func mergeTermFields(meaning, example, image *string, item *GatewayApiProto.CreateTermItem) {
+	if item.Meaning != "" {
+		known := false
+		newKey := term_match.Key(item.Meaning, "", term_match.Options{})
+		for _, part := range strings.Split(*meaning, strings.TrimSpace(meaningSeparator)) {
+			if term_match.Key(part, "", term_match.Options{}) == newKey {
+				known = true
+				break
+			}
+		}
+		if *meaning == "" {
+			*meaning = item.Meaning
+		} else if !known {
+			*meaning += meaningSeparator + item.Meaning
+		}
+	}
+	if *example == "" {
+		*example = item.Example
+	}
+	if *image == "" {
+		*image = item.ImageUrl
+	}
+}
+
+// CreateTermsDeduplicated creates the terms like CreateTerms, but first compares them with the terms of the own
+// collections of the user and the target collection, and with the previous terms of the request.
+// The policy decides what happens with the duplicates, every term of the request is reported.
+// Merge works within the target collection only, the duplicate from another collection is created.
+func (s *GatewayApiService) CreateTermsDeduplicated(ctx context.Context, request *GatewayApiProto.CreateTermsRequest, options *entities.CreateTermsOptions) (*entities.CreateTermsDeduplicatedResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "CreateTermsDeduplicated").
+		Interface("request", request).
+		Interface("options", options).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collection, err := s.checkCreateTerms(ctx, lgr, tokenClaims.UserId, request)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionIds, err := s.ownCollectionIds(ctx, lgr, tokenClaims.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(collectionIds, collection.CollectionId) {
+		collectionIds = append(collectionIds, collection.CollectionId)
+	}
+
+	index, err := s.loadDuplicateIndex(ctx, lgr, collectionIds, options.MatchOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &entities.CreateTermsDeduplicatedResponse{
+		Terms: make([]*entities.DeduplicatedTerm, len(request.Terms)),
+	}
+	var items []*GatewayApiProto.CreateTermItem
+	var created []*entities.DeduplicatedTerm
+	var merges []*termMerge
+	mergesById := make(map[uint64]*termMerge)
+	requested := make(map[duplicateKey]*GatewayApiProto.CreateTermItem)
+
+	for i, term := range request.Terms {
+		// the request is logged as it came, so the created terms are copies
+		item := &GatewayApiProto.CreateTermItem{
+			CollectionId:      term.CollectionId,
+			TermLanguageId:    term.TermLanguageId,
+			MeaningLanguageId: term.MeaningLanguageId,
+			Term:              term.Term,
+			Meaning:           term.Meaning,
+			Example:           term.Example,
+			ImageUrl:          term.ImageUrl,
+		}
+		languageId := item.TermLanguageId
+		if languageId == 0 {
+			languageId = collection.LanguageId
+		}
+
+		result := &entities.DeduplicatedTerm{
+			Term:       item.Term,
+			Duplicates: make([]*entities.DuplicateTerm, 0),
+		}
+		resp.Terms[i] = result
+
+		var sameCollection *VocabularyApiProto.Term
+		for _, duplicate := range index.find(item.Term, languageId) {
+			result.Duplicates = append(result.Duplicates, newDuplicateTerm(duplicate))
+			if sameCollection == nil && duplicate.CollectionId == collection.CollectionId {
+				sameCollection = duplicate
+			}
+		}
+		key := index.key(item.Term, languageId)
+		previous := requested[key]
+
+		switch {
+		case options.OnDuplicate == entities.DuplicatePolicySkip && (len(result.Duplicates) > 0 || previous != nil):
+			result.Result = entities.DuplicateTermSkipped
+
+		case options.OnDuplicate == entities.DuplicatePolicyMerge && sameCollection != nil:
+			merge, ok := mergesById[sameCollection.TermId]
+			if !ok {
+				merge = &termMerge{
+					term:    sameCollection,
+					meaning: sameCollection.Meaning,
+					example: sameCollection.Example,
+					image:   sameCollection.ImageUrl,
+				}
+				mergesById[sameCollection.TermId] = merge
+				merges = append(merges, merge)
+			}
+			mergeTermFields(&merge.meaning, &merge.example, &merge.image, item)
+			merge.results = append(merge.results, result)
+			result.Result = entities.DuplicateTermMerged
+			result.MergedInto = sameCollection.TermId
+
+		case options.OnDuplicate == entities.DuplicatePolicyMerge && previous != nil:
+			mergeTermFields(&previous.Meaning, &previous.Example, &previous.ImageUrl, item)
+			result.Result = entities.DuplicateTermMerged
+
+		default:
+			result.Result = entities.DuplicateTermCreated
+			items = append(items, item)
+			created = append(created, result)
+			if previous == nil {
+				requested[key] = item
+			}
+		}
+	}
+
+	if len(items) > 0 {
+		if options.AutoFill {
+			report := s.autoFillTerms(ctx, lgr, tokenClaims.UserId, collection, items)
+			for i, result := range created {
+				result.AutoFill = report[i]
+			}
+		}
+
+		if err = s.createTerms(ctx, lgr, tokenClaims.UserId, items); err != nil {
+			return nil, err
+		}
+	}
+
+	// the terms are merged after the creation, so the failed request changes nothing
+	s.mergeTerms(ctx, lgr, collection, merges)
+
+	for _, result := range resp.Terms {
+		switch result.Result {
+		case entities.DuplicateTermCreated:
+			resp.Created++
+		case entities.DuplicateTermSkipped:
+			resp.Skipped++
+		case entities.DuplicateTermMerged:
+			resp.Merged++
+		default:
+			resp.Failed++
+		}
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
+
+// mergeTerms updates the existing terms, the failed update fails only the terms merged into it.
+// vocabulary_api accepts the owner only, the terms are updated on behalf of the owner of the collection.
+func (s *GatewayApiService) mergeTerms(ctx context.Context, lgr zerolog.Logger, collection *collectionAccess, merges []*termMerge) {
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.Duplicates.Concurrency)
+	for _, merge := range merges {
+		if !merge.changed() {
+			continue
+		}
+
+		merge := merge
+		group.Go(merge.term.Term, func(ctx context.Context) error {
+			_, err := s.vocabularyApiClient.UpdateTerm(ctx, &VocabularyApiProto.UpdateTermRequest{
+				UserId:   collection.UserId,
+				TermId:   merge.term.TermId,
+				Meaning:  &merge.meaning,
+				Example:  &merge.example,
+				ImageUrl: &merge.image,
+			})
+			if err != nil {
+				lgr.Error().Err(err).Uint64("term_id", merge.term.TermId).Msg(_errors.FailedToMergeTermMsg)
+				for _, result := range merge.results {
+					result.Result = entities.DuplicateTermFailed
+					result.Error = status.Convert(err).Message()
+				}
+			}
+			return nil
+		})
+	}
+	// the branches never fail, the errors are kept in the results
+	_ = group.Wait()
+}
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"time"
+
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/trash"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 )
@@ -26,8 +31,11 @@ func (s *GatewayApiService) DeleteCollection(ctx context.Context, request *Gatew
 		return nil, outerErr
 	}
 
-	_, err := s.vocabularyApiClient.DeleteCollection(ctx, &VocabularyApiProto.DeleteCollectionRequest{
-		UserId:       tokenClaims.UserId,
+	if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessOwn); err != nil {
+		return nil, err
+	}
+
+	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
 		CollectionId: request.CollectionId,
 	})
 	if err != nil {
@@ -36,6 +44,31 @@ func (s *GatewayApiService) DeleteCollection(ctx context.Context, request *Gatew
 		return nil, outerErr
 	}
 
+	// The collection goes to the trash and stays in vocabulary_api with its collaborators until the purge
+	now := time.Now()
+	err = s.trash.PutCollection(ctx, &trash.Collection{
+		CollectionId: request.CollectionId,
+		UserId:       tokenClaims.UserId,
+		LanguageId:   getCollection.Collection.LanguageId,
+		Name:         getCollection.Collection.Name,
+		TotalTerms:   getCollection.Collection.TotalTerms,
+		DeletedBy:    tokenClaims.UserId,
+		DeletedAt:    now,
+		PurgeAt:      now.Add(s.cfg.Trash.Retention),
+	})
+	s.invalidateCollectionAccess(request.CollectionId)
+	if err != nil {
+		outerErr := _errors.FailedToDeleteCollection
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	s.eventPublisherClient.Publish(ctx, lgr, events.CollectionDeletedName, &events.CollectionDeleted{
+		CollectionId: request.CollectionId,
+		UserId:       tokenClaims.UserId,
+		OccurredAt:   timestamppb.Now(),
+	})
+
 	lgr.Debug().Msg("executed")
 	return &GatewayApiProto.DeleteCollectionResponse{}, nil
 }
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
-	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"time"
+
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/trash"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
@@ -26,22 +28,50 @@ func (s *GatewayApiService) DeleteTerms(ctx context.Context, request *GatewayApi
 		return nil, outerErr
 	}
 
-	termIds := make([]uint64, len(request.TermIds))
-	for i, termId := range request.TermIds {
-		termIds[i] = termId
+	collection, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := s.vocabularyApiClient.DeleteTerms(ctx, &VocabularyApiProto.DeleteTermsRequest{
-		UserId:       tokenClaims.UserId,
-		CollectionId: request.CollectionId,
-		TermIds:      termIds,
-	})
+	terms, err := s.listTerms(ctx, request.CollectionId)
 	if err != nil {
 		outerErr := _errors.FailedToDeleteTerms
 		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
 		return nil, outerErr
 	}
 
+	requested := make(map[uint64]struct{}, len(request.TermIds))
+	for _, termId := range request.TermIds {
+		requested[termId] = struct{}{}
+	}
+
+	// The terms go to the trash of the owner and stay in vocabulary_api until the purge.
+	// The unknown ids are skipped, they may be deleted already.
+	now := time.Now()
+	trashed := make([]*trash.Term, 0, len(request.TermIds))
+	for _, term := range terms {
+		if _, ok := requested[term.TermId]; !ok {
+			continue
+		}
+		trashed = append(trashed, &trash.Term{
+			TermId:       term.TermId,
+			CollectionId: request.CollectionId,
+			UserId:       collection.UserId,
+			Term:         term.Term,
+			Meaning:      term.Meaning,
+			Status:       int32(term.Status),
+			DeletedBy:    tokenClaims.UserId,
+			DeletedAt:    now,
+			PurgeAt:      now.Add(s.cfg.Trash.Retention),
+		})
+	}
+
+	if err = s.trash.PutTerms(ctx, trashed); err != nil {
+		outerErr := _errors.FailedToDeleteTerms
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
 	lgr.Debug().Msg("executed")
 	return &GatewayApiProto.DeleteTermsResponse{}, nil
 }
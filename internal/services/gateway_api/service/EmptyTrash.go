@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// EmptyTrash purges the trash of the user now instead of waiting for the retention
+func (s *GatewayApiService) EmptyTrash(ctx context.Context, request *entities.EmptyTrashRequest) (*entities.EmptyTrashResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "EmptyTrash").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collections, terms, err := s.trash.List(ctx, tokenClaims.UserId)
+	if err != nil {
+		outerErr := _errors.FailedToEmptyTrash
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+	resp := s.purgeTrashItems(ctx, lgr, collections, terms)
+	if resp.Failed > 0 && resp.PurgedCollections == 0 && resp.PurgedTerms == 0 {
+		outerErr := _errors.FailedToEmptyTrash
+		lgr.Error().Int("failed", resp.Failed).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	lgr.Debug().Int("collections", resp.PurgedCollections).Int("terms", resp.PurgedTerms).Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog"
+	SpeakerApiProto "gitlab.com/wbwapis/go-genproto/wbw/speaker/speaker_api/v1"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collection_export"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// ExportCollection loads the terms and their voiceovers, the file is written by the transport
+func (s *GatewayApiService) ExportCollection(ctx context.Context, request *entities.ExportCollectionRequest) (*entities.ExportCollectionResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "ExportCollection").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	vocabularyTerms, err := s.getReadableTerms(ctx, lgr, tokenClaims.UserId, request.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
+		CollectionId: request.CollectionId,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToGetCollection
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collection := &collection_export.Collection{
+		CollectionId: getCollection.Collection.CollectionId,
+		Name:         getCollection.Collection.Name,
+		Description:  getCollection.Collection.Description,
+	}
+
+	terms := make([]*collection_export.Term, len(vocabularyTerms))
+	for i, term := range vocabularyTerms {
+		terms[i] = &collection_export.Term{
+			TermId:   term.TermId,
+			Term:     term.Term,
+			Meaning:  term.Meaning,
+			Example:  term.Example,
+			ImageUrl: term.ImageUrl,
+			Status:   mappers.VocabularyStatusToGatewayStatus[term.Status].String(),
+		}
+	}
+
+	if s.cfg.Export.VoiceoverEnabled {
+		s.loadVoiceovers(ctx, lgr, vocabularyTerms, terms)
+	}
+
+	resp := &entities.ExportCollectionResponse{
+		FileName:    collection_export.FileName(collection, request.Format),
+		ContentType: collection_export.ContentType(request.Format),
+		Write: func(w io.Writer) error {
+			return collection_export.Write(w, request.Format, collection, terms)
+		},
+	}
+
+	lgr.Debug().Int("terms", len(terms)).Msg("executed")
+	return resp, nil
+}
+
+// loadVoiceovers sets the voiceover urls of the terms, the term without the voiceover is exported without the url
+func (s *GatewayApiService) loadVoiceovers(ctx context.Context, lgr zerolog.Logger, vocabularyTerms []*VocabularyApiProto.Term, terms []*collection_export.Term) {
+	var mu sync.Mutex
+	failed := 0
+
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.Export.VoiceoverConcurrency)
+	for i, term := range vocabularyTerms {
+		i, term := i, term
+		group.Go(term.Term, func(ctx context.Context) error {
+			voiceover, err := s.speakerApiClient.GetVoiceover(ctx, &SpeakerApiProto.GetVoiceoverRequest{
+				Text:       term.Term,
+				LanguageId: term.TermLanguageId,
+			})
+			if err != nil {
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return nil
+			}
+			terms[i].VoiceoverUrl = voiceover.Url
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	if failed > 0 {
+		lgr.Warn().Int("failed", failed).Msg(_errors.FailedToGetVoiceoverMsg)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/subtitles"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_import"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/word_extract"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// ExtractSubtitleTerms proposes the words of the subtitles, which are not in the collections of the user yet.
+// Every cue is extracted alone, the proposed term has the text and the time of its first cue.
+func (s *GatewayApiService) ExtractSubtitleTerms(ctx context.Context, request *entities.ExtractSubtitleTermsRequest) (*entities.ExtractSubtitleTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "ExtractSubtitleTerms").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// the terms are created in the collection by CreateTerms, so it must be editable already
+	collection, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit)
+	if err != nil {
+		return nil, err
+	}
+	languageId := request.LanguageId
+	if languageId == 0 {
+		languageId = collection.LanguageId
+	}
+
+	fallbackEncoding := request.Encoding
+	if fallbackEncoding == "" {
+		fallbackEncoding = s.cfg.Subtitles.FallbackEncoding
+	}
+	text, encoding, err := term_import.Decode(request.File, fallbackEncoding)
+	if err != nil {
+		outerErr := _errors.BadRequestError(err)
+		lgr.Error().Err(err).Msg(_errors.FailedToExtractSubtitleTermsMsg)
+		return nil, outerErr
+	}
+
+	format := request.Format
+	if format == "" {
+		format = subtitles.FormatAuto
+	}
+	cues, format, err := subtitles.Parse(text, format)
+	if err != nil {
+		outerErr := _errors.BadRequestError(err)
+		lgr.Error().Err(err).Msg(_errors.FailedToExtractSubtitleTermsMsg)
+		return nil, outerErr
+	}
+
+	if len(cues) > s.cfg.Subtitles.MaxCues {
+		outerErr := _errors.FailedToExtractSubtitleTermsTooManyCues
+		lgr.Error().Int("cues", len(cues)).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	maxTerms := request.MaxTerms
+	if maxTerms == 0 {
+		maxTerms = s.cfg.Extract.Terms
+	}
+	maxTerms = min(maxTerms, s.cfg.Extract.MaxTerms)
+
+	language, err := s.languageShortCode(ctx, lgr, languageId)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]string, len(cues))
+	for i, cue := range cues {
+		segments[i] = cue.Text
+	}
+	result := word_extract.Extract(segments, language)
+	words, known, err := s.unknownWords(ctx, lgr, tokenClaims.UserId, collection, languageId, result.Words)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) > maxTerms {
+		words = words[:maxTerms]
+	}
+
+	source := request.Source
+	if source == "" {
+		source = strings.TrimSuffix(filepath.Base(request.FileName), filepath.Ext(request.FileName))
+	}
+
+	resp := &entities.ExtractSubtitleTermsResponse{
+		Format:     format,
+		Encoding:   encoding,
+		Cues:       len(cues),
+		TotalWords: result.TotalWords,
+		StopWords:  result.StopWords,
+		KnownWords: known,
+		Terms:      make([]*entities.SubtitleTerm, len(words)),
+	}
+	for i, word := range words {
+		cue := cues[word.Segment]
+		resp.Terms[i] = &entities.SubtitleTerm{
+			CollectionId:   request.CollectionId,
+			TermLanguageId: languageId,
+			Term:           word.Text,
+			// the lines of the speakers are one example
+			Example:   strings.Join(strings.Fields(cue.Text), " "),
+			Count:     word.Count,
+			Guessed:   word.Guessed,
+			Reference: strings.TrimSpace(source + " " + subtitles.Timestamp(cue.Start)),
+			StartMs:   cue.Start.Milliseconds(),
+			EndMs:     cue.End.Milliseconds(),
+		}
+	}
+
+	lgr.Debug().
+		Int("cues", resp.Cues).
+		Int("terms", len(resp.Terms)).
+		Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/word_extract"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+)
+
+// ExtractTerms returns the words of the text, which are not in the collections of the user yet,
+// and creates them with the translations when it is requested. The guessed words are only proposed,
+// the Chinese bigrams span the word boundaries as often as not.
+func (s *GatewayApiService) ExtractTerms(ctx context.Context, request *entities.ExtractTermsRequest) (*entities.ExtractTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "ExtractTerms").
+		Uint64("language_id", request.LanguageId).
+		Uint64("collection_id", request.CollectionId).
+		Bool("create", request.Create).
+		Logger() // the text is not logged, it may be long
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if length := utf8.RuneCountInString(request.Text); length > s.cfg.Extract.MaxTextLength {
+		outerErr := _errors.FailedToExtractTermsTooLong
+		lgr.Error().Int("length", length).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	maxTerms := request.MaxTerms
+	if maxTerms == 0 {
+		maxTerms = s.cfg.Extract.Terms
+	}
+	maxTerms = min(maxTerms, s.cfg.Extract.MaxTerms)
+
+	var collection *collectionAccess
+	languageId := request.LanguageId
+	if request.CollectionId != 0 {
+		access := accessView
+		if request.Create {
+			access = accessEdit
+		}
+		var err error
+		if collection, err = s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, access); err != nil {
+			return nil, err
+		}
+		if languageId == 0 {
+			languageId = collection.LanguageId
+		}
+	}
+
+	language, err := s.languageShortCode(ctx, lgr, languageId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := word_extract.Extract([]string{request.Text}, language)
+	words, known, err := s.unknownWords(ctx, lgr, tokenClaims.UserId, collection, languageId, result.Words)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) > maxTerms {
+		words = words[:maxTerms]
+	}
+
+	resp := &entities.ExtractTermsResponse{
+		Terms:      make([]*entities.ExtractedTerm, len(words)),
+		TotalWords: result.TotalWords,
+		StopWords:  result.StopWords,
+		KnownWords: known,
+	}
+	for i, word := range words {
+		resp.Terms[i] = &entities.ExtractedTerm{
+			Term:    word.Text,
+			Count:   word.Count,
+			Example: word.Sentence,
+			Guessed: word.Guessed,
+		}
+	}
+
+	if request.Create {
+		items := make([]*GatewayApiProto.CreateTermItem, 0, len(words))
+		created := make([]*entities.ExtractedTerm, 0, len(words))
+		for i, word := range words {
+			if word.Guessed {
+				continue
+			}
+			items = append(items, &GatewayApiProto.CreateTermItem{
+				CollectionId:      request.CollectionId,
+				TermLanguageId:    languageId,
+				MeaningLanguageId: request.MeaningLanguageId,
+				Term:              word.Text,
+				Example:           word.Sentence,
+			})
+			created = append(created, resp.Terms[i])
+		}
+
+		if len(items) > 0 {
+			// the same enrichment as CreateTerms with auto_fill
+			report := s.autoFillTerms(ctx, lgr, tokenClaims.UserId, collection, items)
+			if err = s.createTerms(ctx, lgr, tokenClaims.UserId, items); err != nil {
+				return nil, err
+			}
+			for i, term := range created {
+				term.AutoFill = report[i]
+			}
+			resp.Created = len(items)
+		}
+	}
+
+	lgr.Debug().
+		Int("total_words", resp.TotalWords).
+		Int("terms", len(resp.Terms)).
+		Int("created", resp.Created).
+		Msg("executed")
+	return resp, nil
+}
+
+// languageShortCode returns the ISO 639-1 code of the language, it chooses the tokenizer and the stemmer
+func (s *GatewayApiService) languageShortCode(ctx context.Context, lgr zerolog.Logger, languageId uint64) (string, error) {
+	languages, err := s.listLanguages(ctx, lgr)
+	if err != nil {
+		return "", _errors.FailedToGetLanguages
+	}
+	for _, language := range languages.Languages {
+		if language.LanguageId == languageId {
+			return language.ShortCode, nil
+		}
+	}
+
+	outerErr := _errors.FailedToExtractTermsLanguageNotFound
+	lgr.Error().Uint64("language_id", languageId).Msg(outerErr.ErrorMessage)
+	return "", outerErr
+}
+
+// unknownWords drops the words, which are in the own collections of the user or in the collection,
+// in the same language. The number of the dropped words is returned as well.
+func (s *GatewayApiService) unknownWords(
+	ctx context.Context,
+	lgr zerolog.Logger,
+	userId uint64,
+	collection *collectionAccess,
+	languageId uint64,
+	words []*word_extract.Word,
+) ([]*word_extract.Word, int, error) {
+	collectionIds, err := s.ownCollectionIds(ctx, lgr, userId)
+	if err != nil {
+		return nil, 0, err
+	}
+	// the collection shared with the user is not among the own ones
+	if collection != nil && !slices.Contains(collectionIds, collection.CollectionId) {
+		collectionIds = append(collectionIds, collection.CollectionId)
+	}
+
+	index, err := s.loadDuplicateIndex(ctx, lgr, collectionIds, word_extract.MatchOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unknown := make([]*word_extract.Word, 0, len(words))
+	for _, word := range words {
+		if len(index.find(word.Text, languageId)) == 0 {
+			unknown = append(unknown, word)
+		}
+	}
+	return unknown, len(words) - len(unknown), nil
+}
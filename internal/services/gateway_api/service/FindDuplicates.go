@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_match"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// duplicateKey the terms are duplicates only in the same language
+type duplicateKey struct {
+	languageId uint64
+	text       string
+}
+
+// duplicateIndex finds the existing terms by their normalized form
+type duplicateIndex struct {
+	options   term_match.Options
+	languages map[uint64]string // short codes by the language id, they choose the stemmer
+	terms     map[duplicateKey][]*VocabularyApiProto.Term
+}
+
+func (x *duplicateIndex) key(term string, languageId uint64) duplicateKey {
+	return duplicateKey{
+		languageId: languageId,
+		text:       term_match.Key(term, x.languages[languageId], x.options),
+	}
+}
+
+// find returns the indexed terms ordered by the term id
+func (x *duplicateIndex) find(term string, languageId uint64) []*VocabularyApiProto.Term {
+	return x.terms[x.key(term, languageId)]
+}
+
+// loadDuplicateIndex indexes all the terms of the collections, the access to them is checked by the caller
+func (s *GatewayApiService) loadDuplicateIndex(ctx context.Context, lgr zerolog.Logger, collectionIds []uint64, options term_match.Options) (*duplicateIndex, error) {
+	index := &duplicateIndex{
+		options: options,
+		terms:   make(map[duplicateKey][]*VocabularyApiProto.Term),
+	}
+
+	if options.Stemming {
+		languages, err := s.listLanguages(ctx, lgr)
+		if err != nil {
+			return nil, _errors.FailedToGetLanguages
+		}
+		index.languages = make(map[uint64]string, len(languages.Languages))
+		for _, language := range languages.Languages {
+			index.languages[language.LanguageId] = language.ShortCode
+		}
+	}
+
+	var mu sync.Mutex
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.Duplicates.Concurrency)
+	for _, collectionId := range collectionIds {
+		collectionId := collectionId
+		group.Go(fmt.Sprint(collectionId), func(ctx context.Context) error {
+			terms, err := s.listTerms(ctx, collectionId)
+			if err != nil {
+				return err
+			}
+
+			keys := make([]duplicateKey, len(terms))
+			for i, term := range terms {
+				keys[i] = index.key(term.Term, term.TermLanguageId)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for i, term := range terms {
+				index.terms[keys[i]] = append(index.terms[keys[i]], term)
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		outerErr := _errors.FailedToGetTerms
+		var branchErr *fan_out.BranchError
+		if !errors.As(err, &branchErr) {
+			lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+			return nil, outerErr
+		}
+		lgr.Error().Err(branchErr.Err).Str("collection_id", branchErr.Branch).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	for _, terms := range index.terms {
+		sort.Slice(terms, func(i, j int) bool {
+			return terms[i].TermId < terms[j].TermId
+		})
+	}
+	return index, nil
+}
+
+// ownCollectionIds returns the ids of the collections owned by the user, the shared ones are not included
+func (s *GatewayApiService) ownCollectionIds(ctx context.Context, lgr zerolog.Logger, userId uint64) ([]uint64, error) {
+	getCollections, err := s.vocabularyApiClient.GetCollections(ctx, &VocabularyApiProto.GetCollectionsRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToGetCollections
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collectionIds := make([]uint64, len(getCollections.Collections))
+	for i, collection := range getCollections.Collections {
+		collectionIds[i] = collection.CollectionId
+	}
+	return collectionIds, nil
+}
+
+func newDuplicateTerm(term *VocabularyApiProto.Term) *entities.DuplicateTerm {
+	return &entities.DuplicateTerm{
+		TermId:       term.TermId,
+		CollectionId: term.CollectionId,
+		Term:         term.Term,
+		Meaning:      term.Meaning,
+	}
+}
+
+// FindDuplicates groups the terms of the collections, which are the same after the normalization.
+// The collections shared with the user are searched only when they are requested explicitly.
+func (s *GatewayApiService) FindDuplicates(ctx context.Context, request *entities.FindDuplicatesRequest) (*entities.FindDuplicatesResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "FindDuplicates").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	var collectionIds []uint64
+	if len(request.CollectionIds) == 0 {
+		var err error
+		collectionIds, err = s.ownCollectionIds(ctx, lgr, tokenClaims.UserId)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		seen := make(map[uint64]struct{}, len(request.CollectionIds))
+		for _, collectionId := range request.CollectionIds {
+			if _, ok := seen[collectionId]; ok {
+				continue
+			}
+			seen[collectionId] = struct{}{}
+
+			if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, collectionId, accessView); err != nil {
+				return nil, err
+			}
+			collectionIds = append(collectionIds, collectionId)
+		}
+	}
+
+	index, err := s.loadDuplicateIndex(ctx, lgr, collectionIds, request.MatchOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*entities.DuplicateGroup, 0)
+	for key, terms := range index.terms {
+		if len(terms) < 2 {
+			continue
+		}
+
+		group := &entities.DuplicateGroup{
+			Key:            key.text,
+			TermLanguageId: key.languageId,
+			Terms:          make([]*entities.DuplicateTerm, len(terms)),
+		}
+		for i, term := range terms {
+			group.Terms[i] = newDuplicateTerm(term)
+		}
+		groups = append(groups, group)
+	}
+	// the group of the oldest term first, the terms of the groups are ordered by the id already
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Terms[0].TermId < groups[j].Terms[0].TermId
+	})
+
+	resp := &entities.FindDuplicatesResponse{
+		Groups: groups,
+	}
+
+	lgr.Debug().Int("groups", len(groups)).Msg("executed")
+	return resp, nil
+}
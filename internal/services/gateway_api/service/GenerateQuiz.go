@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/quiz"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+func (s *GatewayApiService) GenerateQuiz(ctx context.Context, request *entities.GenerateQuizRequest) (*entities.GenerateQuizResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GenerateQuiz").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	terms, err := s.getReadableTerms(ctx, lgr, tokenClaims.UserId, request.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]quiz.Card, len(terms))
+	for i, term := range terms {
+		cards[i] = quiz.Card{
+			TermId:            term.TermId,
+			TermLanguageId:    term.TermLanguageId,
+			MeaningLanguageId: term.MeaningLanguageId,
+			Term:              term.Term,
+			Meaning:           term.Meaning,
+		}
+	}
+
+	// The seed is returned, so the client can repeat the quiz
+	seed := request.Seed
+	for seed == 0 {
+		seed = rand.Int63()
+	}
+
+	questions, err := quiz.Generate(cards, request.Mode, request.Size, seed)
+	if err != nil {
+		outerErr := _errors.FailedToGenerateQuiz
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.GenerateQuizResponse{
+		CollectionId: request.CollectionId,
+		Mode:         request.Mode,
+		Seed:         seed,
+		Questions:    make([]*entities.QuizQuestion, len(questions)),
+	}
+	for i, question := range questions {
+		resp.Questions[i] = &entities.QuizQuestion{
+			TermId:    question.TermId,
+			Prompt:    question.Prompt,
+			Options:   question.Options,
+			Statement: question.Statement,
+			IsTrue:    question.IsTrue,
+			Answer:    question.Answer,
+		}
+	}
+
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
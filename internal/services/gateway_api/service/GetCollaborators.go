@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// GetCollaborators lists the collaborators to the owner and to the collaborators, the readers of the public
+// collection do not see them. The pending invites are listed to the owner only.
+func (s *GatewayApiService) GetCollaborators(ctx context.Context, request *entities.GetCollaboratorsRequest) (*entities.GetCollaboratorsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GetCollaborators").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collection, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessView)
+	if err != nil {
+		return nil, err
+	}
+	isOwner := collection.UserId == tokenClaims.UserId
+
+	members, err := s.collaborators.Members(ctx, request.CollectionId)
+	if err != nil {
+		outerErr := _errors.FailedToGetCollaborators
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.GetCollaboratorsResponse{
+		OwnerId:       collection.UserId,
+		Collaborators: make([]*entities.Collaborator, 0, len(members)),
+		Invites:       make([]*entities.CollaboratorInvite, 0),
+	}
+	isMember := false
+	for _, member := range members {
+		isMember = isMember || member.UserId == tokenClaims.UserId
+		resp.Collaborators = append(resp.Collaborators, &entities.Collaborator{
+			UserId:    member.UserId,
+			Role:      member.Role,
+			InvitedBy: member.InvitedBy,
+			CreatedAt: member.CreatedAt,
+		})
+	}
+
+	if !isOwner && !isMember {
+		outerErr := _errors.FailedToCheckCollectionAccessRole
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if isOwner {
+		invites, err := s.collaborators.Invites(ctx, request.CollectionId)
+		if err != nil {
+			outerErr := _errors.FailedToGetCollaborators
+			lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+			return nil, outerErr
+		}
+		for _, invite := range invites {
+			resp.Invites = append(resp.Invites, &entities.CollaboratorInvite{
+				InviteId:  invite.InviteId,
+				UserId:    invite.UserId,
+				Email:     invite.Email,
+				Role:      invite.Role,
+				CreatedAt: invite.CreatedAt,
+				ExpiresAt: invite.ExpiresAt,
+			})
+		}
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
@@ -35,29 +35,32 @@ func (s *GatewayApiService) GetCollection(ctx context.Context, request *GatewayA
 		return nil, outerErr
 	}
 
-	if getCollection.Collection.UserId != tokenClaims.UserId && getCollection.Collection.IsPublic != true {
-		outerErr := _errors.FailedToGetCollectionPrivateCollection
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
+	err = s.checkCollectionAccess(ctx, lgr, newCollectionAccess(getCollection.Collection), tokenClaims.UserId, accessView)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &GatewayApiProto.GetCollectionResponse{
-		Collection: &GatewayApiProto.Collection{
-			CollectionId: getCollection.Collection.CollectionId,
-			UserId:       getCollection.Collection.UserId,
-			LanguageId:   getCollection.Collection.LanguageId,
-			Name:         getCollection.Collection.Name,
-			Description:  getCollection.Collection.Description,
-			IsPinned:     getCollection.Collection.IsPinned,
-			IsPublic:     getCollection.Collection.IsPublic,
-			TotalTerms:   getCollection.Collection.TotalTerms,
-			LearnedTerms: getCollection.Collection.LearnedTerms,
-			OpenedAt:     getCollection.Collection.OpenedAt,
-			CreatedAt:    getCollection.Collection.CreatedAt,
-			UpdatedAt:    getCollection.Collection.UpdatedAt,
-		},
+		Collection: gatewayCollection(getCollection.Collection),
 	}
 
 	lgr.Debug().Msg("executed")
 	return resp, nil
 }
+
+func gatewayCollection(collection *VocabularyApiProto.Collection) *GatewayApiProto.Collection {
+	return &GatewayApiProto.Collection{
+		CollectionId: collection.CollectionId,
+		UserId:       collection.UserId,
+		LanguageId:   collection.LanguageId,
+		Name:         collection.Name,
+		Description:  collection.Description,
+		IsPinned:     collection.IsPinned,
+		IsPublic:     collection.IsPublic,
+		TotalTerms:   collection.TotalTerms,
+		LearnedTerms: collection.LearnedTerms,
+		OpenedAt:     collection.OpenedAt,
+		CreatedAt:    collection.CreatedAt,
+		UpdatedAt:    collection.UpdatedAt,
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rs/zerolog"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+)
+
+// accessLevel of the user to the collection, every level includes the lower ones
+type accessLevel int
+
+const (
+	accessNone accessLevel = iota
+	accessView             // the public collection or the viewer
+	accessEdit             // the editor: creates, updates and deletes the terms
+	accessOwn              // the owner: changes the collection itself and its collaborators
+)
+
+// collectionAccess is the part of the collection needed by the access checks
+type collectionAccess struct {
+	CollectionId uint64
+	UserId       uint64
+	IsPublic     bool
+	LanguageId   uint64
+}
+
+// getCollectionAccess returns the owner and the public flag of the collection, they are cached for a short time
+func (s *GatewayApiService) getCollectionAccess(ctx context.Context, collectionId uint64) (*collectionAccess, error) {
+	if !s.cfg.Cache.Collections.Enabled {
+		return s.loadCollectionAccess(ctx, collectionId)
+	}
+
+	return s.collectionsCache.Get(ctx, collectionsCacheKey(collectionId), func(ctx context.Context) (*collectionAccess, error) {
+		return s.loadCollectionAccess(ctx, collectionId)
+	})
+}
+
+func (s *GatewayApiService) loadCollectionAccess(ctx context.Context, collectionId uint64) (*collectionAccess, error) {
+	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
+		CollectionId: collectionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newCollectionAccess(getCollection.Collection), nil
+}
+
+func newCollectionAccess(collection *VocabularyApiProto.Collection) *collectionAccess {
+	return &collectionAccess{
+		CollectionId: collection.CollectionId,
+		UserId:       collection.UserId,
+		IsPublic:     collection.IsPublic,
+		LanguageId:   collection.LanguageId,
+	}
+}
+
+// authorizeCollection loads the collection and checks that the user has at least the level of access to it
+func (s *GatewayApiService) authorizeCollection(ctx context.Context, lgr zerolog.Logger, userId uint64, collectionId uint64, level accessLevel) (*collectionAccess, error) {
+	collection, err := s.getCollectionAccess(ctx, collectionId)
+	if err != nil {
+		outerErr := _errors.FailedToGetCollection
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if err = s.checkCollectionAccess(ctx, lgr, collection, userId, level); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// checkCollectionAccess the private collection is reported as such to the stranger,
+// the collaborator without enough rights gets the role error
+func (s *GatewayApiService) checkCollectionAccess(ctx context.Context, lgr zerolog.Logger, collection *collectionAccess, userId uint64, level accessLevel) error {
+	granted, err := s.collectionAccessLevel(ctx, collection, userId)
+	if err != nil {
+		outerErr := _errors.FailedToCheckCollectionAccess
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return outerErr
+	}
+	if granted >= level {
+		return nil
+	}
+
+	outerErr := _errors.FailedToCheckCollectionAccessRole
+	if granted == accessNone {
+		outerErr = _errors.FailedToGetCollectionPrivateCollection
+	}
+	lgr.Error().Uint64("collection_id", collection.CollectionId).Msg(outerErr.ErrorMessage)
+	return outerErr
+}
+
+func (s *GatewayApiService) collectionAccessLevel(ctx context.Context, collection *collectionAccess, userId uint64) (accessLevel, error) {
+	if collection.UserId == userId {
+		return accessOwn, nil
+	}
+
+	role, err := s.collaborators.Role(ctx, collection.CollectionId, userId)
+	if err != nil {
+		return accessNone, err
+	}
+	switch {
+	case role == collaborators.RoleEditor:
+		return accessEdit, nil
+	case role == collaborators.RoleViewer || collection.IsPublic:
+		return accessView, nil
+	}
+	return accessNone, nil
+}
+
+// invalidateCollectionAccess must be called after the owner or the public flag of the collection may have changed,
+// the other instances invalidate it by the collection events
+func (s *GatewayApiService) invalidateCollectionAccess(collectionId uint64) {
+	s.collectionsCache.Invalidate(collectionsCacheKey(collectionId))
+}
+
+func collectionsCacheKey(collectionId uint64) string {
+	return strconv.FormatUint(collectionId, 10)
+}
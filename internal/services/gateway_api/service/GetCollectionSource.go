@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// GetCollectionSource returns the collection the readable collection was cloned from, for the attribution
+func (s *GatewayApiService) GetCollectionSource(ctx context.Context, request *entities.GetCollectionSourceRequest) (*entities.GetCollectionSourceResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GetCollectionSource").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessView); err != nil {
+		return nil, err
+	}
+
+	source, err := s.cloneSources.Get(ctx, request.CollectionId)
+	if err != nil {
+		outerErr := _errors.FailedToGetCollectionSource
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.GetCollectionSourceResponse{CollectionId: request.CollectionId}
+	if source != nil {
+		resp.Source = &entities.CollectionSource{
+			CollectionId: source.SourceCollectionId,
+			UserId:       source.SourceUserId,
+			ClonedAt:     source.ClonedAt,
+		}
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
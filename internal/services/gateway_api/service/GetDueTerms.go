@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/spaced_repetition"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+)
+
+// reviewStatuses are the levels of the review scheduler, from the new term to the known one
+var reviewStatuses = []GatewayApiProto.StatusType{
+	GatewayApiProto.StatusType_STATUS_TYPE_NEW,
+	GatewayApiProto.StatusType_STATUS_TYPE_RECOGNIZE,
+	GatewayApiProto.StatusType_STATUS_TYPE_FAMILIAR,
+	GatewayApiProto.StatusType_STATUS_TYPE_LEARNED,
+	GatewayApiProto.StatusType_STATUS_TYPE_KNOWN,
+}
+
+// reviewLevel returns the scheduler level of the status, the unknown status is studied as the new one
+func reviewLevel(status GatewayApiProto.StatusType) int {
+	for level, reviewStatus := range reviewStatuses {
+		if reviewStatus == status {
+			return level
+		}
+	}
+	return 0
+}
+
+// termDueAt is counted from the last repetition, the never repeated term is due since it is created
+func (s *GatewayApiService) termDueAt(term *VocabularyApiProto.Term) time.Time {
+	var repeatedAt time.Time
+	if term.RepeatedAt != nil {
+		repeatedAt = term.RepeatedAt.AsTime()
+	} else if term.CreatedAt != nil {
+		repeatedAt = term.CreatedAt.AsTime()
+	}
+	level := reviewLevel(mappers.VocabularyStatusToGatewayStatus[term.Status])
+	return spaced_repetition.DueAt(s.scheduler, level, repeatedAt)
+}
+
+func (s *GatewayApiService) GetDueTerms(ctx context.Context, request *entities.GetDueTermsRequest) (*entities.GetDueTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GetDueTerms").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = s.cfg.Review.QueueLimit
+	}
+	if limit > s.cfg.Review.MaxQueueLimit {
+		limit = s.cfg.Review.MaxQueueLimit
+	}
+
+	getCollections, err := s.vocabularyApiClient.GetCollections(ctx, &VocabularyApiProto.GetCollectionsRequest{
+		UserId: tokenClaims.UserId,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToGetCollections
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collectionIds := make([]uint64, 0, len(getCollections.Collections))
+	for _, collection := range getCollections.Collections {
+		collectionIds = append(collectionIds, collection.CollectionId)
+	}
+
+	// Only the own collections can be reviewed
+	if len(request.CollectionIds) > 0 {
+		own := make(map[uint64]struct{}, len(collectionIds))
+		for _, collectionId := range collectionIds {
+			own[collectionId] = struct{}{}
+		}
+		for _, collectionId := range request.CollectionIds {
+			if _, ok := own[collectionId]; !ok {
+				outerErr := _errors.FailedToGetCollectionPrivateCollection
+				lgr.Error().Uint64("collection_id", collectionId).Msg(outerErr.ErrorMessage)
+				return nil, outerErr
+			}
+		}
+		collectionIds = request.CollectionIds
+	}
+
+	now := time.Now()
+	var mu sync.Mutex
+	var dueTerms []*entities.DueTerm
+	collections := make([]*entities.CollectionDueCount, len(collectionIds))
+
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.Review.Concurrency)
+	for i, collectionId := range collectionIds {
+		i, collectionId := i, collectionId
+		group.Go(fmt.Sprint(collectionId), func(ctx context.Context) error {
+			getTerms, err := s.vocabularyApiClient.GetTerms(ctx, &VocabularyApiProto.GetTermsRequest{
+				CollectionId: collectionId,
+			})
+			if err != nil {
+				return err
+			}
+
+			var due []*entities.DueTerm
+			for _, term := range getTerms.Terms {
+				dueAt := s.termDueAt(term)
+				if dueAt.After(now) {
+					continue
+				}
+
+				dueTerm := &entities.DueTerm{
+					TermId:            term.TermId,
+					CollectionId:      term.CollectionId,
+					TermLanguageId:    term.TermLanguageId,
+					MeaningLanguageId: term.MeaningLanguageId,
+					Term:              term.Term,
+					Meaning:           term.Meaning,
+					Example:           term.Example,
+					ImageUrl:          term.ImageUrl,
+					IsPhrase:          term.IsPhrase,
+					Status:            mappers.VocabularyStatusToGatewayStatus[term.Status].String(),
+					DueAt:             dueAt,
+				}
+				if term.RepeatedAt != nil {
+					repeatedAt := term.RepeatedAt.AsTime()
+					dueTerm.RepeatedAt = &repeatedAt
+				}
+				due = append(due, dueTerm)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			dueTerms = append(dueTerms, due...)
+			collections[i] = &entities.CollectionDueCount{CollectionId: collectionId, Due: len(due)}
+			return nil
+		})
+	}
+
+	if err = group.Wait(); err != nil {
+		outerErr := _errors.FailedToGetDueTerms
+		var branchErr *fan_out.BranchError
+		if !errors.As(err, &branchErr) {
+			lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+			return nil, outerErr
+		}
+		lgr.Error().Err(branchErr.Err).Str("collection_id", branchErr.Branch).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// The most overdue first, the order of the equally overdue terms is kept stable by the term id
+	sort.Slice(dueTerms, func(i, j int) bool {
+		if !dueTerms[i].DueAt.Equal(dueTerms[j].DueAt) {
+			return dueTerms[i].DueAt.Before(dueTerms[j].DueAt)
+		}
+		return dueTerms[i].TermId < dueTerms[j].TermId
+	})
+
+	resp := &entities.GetDueTermsResponse{
+		Terms:       dueTerms,
+		Collections: collections,
+		TotalDue:    len(dueTerms),
+	}
+	if len(resp.Terms) > limit {
+		resp.Terms = resp.Terms[:limit]
+	}
+	if resp.Terms == nil {
+		resp.Terms = []*entities.DueTerm{}
+	}
+
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
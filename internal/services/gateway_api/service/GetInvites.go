@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// GetInvites lists the pending invites of the caller, so the invited user can accept them without the email
+func (s *GatewayApiService) GetInvites(ctx context.Context, request *entities.GetInvitesRequest) (*entities.GetInvitesResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GetInvites").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	invites, err := s.collaborators.UserInvites(ctx, tokenClaims.UserId)
+	if err != nil {
+		outerErr := _errors.FailedToGetInvites
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	now := time.Now()
+	resp := &entities.GetInvitesResponse{
+		Invites: make([]*entities.ReceivedInvite, 0, len(invites)),
+	}
+	for _, invite := range invites {
+		if now.After(invite.ExpiresAt) {
+			continue
+		}
+		resp.Invites = append(resp.Invites, &entities.ReceivedInvite{
+			InviteId:     invite.InviteId,
+			CollectionId: invite.CollectionId,
+			Role:         invite.Role,
+			InvitedBy:    invite.InvitedBy,
+			CreatedAt:    invite.CreatedAt,
+			ExpiresAt:    invite.ExpiresAt,
+		})
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
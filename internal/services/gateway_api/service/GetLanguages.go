@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"github.com/rs/zerolog"
 	LanguageApiProto "gitlab.com/wbwapis/go-genproto/wbw/language/language_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
@@ -10,7 +11,7 @@ import (
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 )
 
-func (s *GatewayApiService) GetLanguages(ctx context.Context, request *GatewayApiProto.GetLanguagesRequest) (*GatewayApiProto.GetLanguagesResponse, error) {
+func (s *GatewayApiService) GetLanguages(ctx context.Context, request *GatewayApiProto.GetLanguagesRequest) (resp *GatewayApiProto.GetLanguagesResponse, err error) {
 	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
 	lgr := s.lgr.With().
 		Str(constants.RequestIdKey, requestId).
@@ -18,13 +19,37 @@ func (s *GatewayApiService) GetLanguages(ctx context.Context, request *GatewayAp
 		Interface("request", request).
 		Logger()
 
-	getLanguages, err := s.languageApiClient.GetLanguages(ctx, &LanguageApiProto.GetLanguagesRequest{})
+	resp, err = s.listLanguages(ctx, lgr)
 	if err != nil {
 		outerErr := _errors.FailedToGetLanguages
 		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
 		return nil, outerErr
 	}
 
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
+
+// listLanguages returns the languages, through the cache when it is enabled
+func (s *GatewayApiService) listLanguages(ctx context.Context, lgr zerolog.Logger) (*GatewayApiProto.GetLanguagesResponse, error) {
+	if s.cfg.Cache.Languages.Enabled {
+		// Language names are localized by language_api, so the list is cached per Accept-Language
+		acceptLanguage := utils.AnyToString(ctx.Value(constants.AcceptLanguageKey))
+		// the stale list is refreshed in the background, so the loader gets the detached context
+		return s.languagesCache.Get(detachContext(ctx), acceptLanguage, func(ctx context.Context) (*GatewayApiProto.GetLanguagesResponse, error) {
+			return s.getLanguages(ctx, lgr)
+		})
+	}
+	return s.getLanguages(ctx, lgr)
+}
+
+func (s *GatewayApiService) getLanguages(ctx context.Context, lgr zerolog.Logger) (*GatewayApiProto.GetLanguagesResponse, error) {
+	getLanguages, err := s.languageApiClient.GetLanguages(ctx, &LanguageApiProto.GetLanguagesRequest{})
+	if err != nil {
+		lgr.Error().Err(err).Msg(_errors.FailedToGetLanguagesMsg)
+		return nil, err
+	}
+
 	languages := make([]*GatewayApiProto.Language, len(getLanguages.Languages))
 	for i, language := range getLanguages.Languages {
 		languages[i] = &GatewayApiProto.Language{
@@ -38,10 +63,7 @@ func (s *GatewayApiService) GetLanguages(ctx context.Context, request *GatewayAp
 		}
 	}
 
-	resp := &GatewayApiProto.GetLanguagesResponse{
+	return &GatewayApiProto.GetLanguagesResponse{
 		Languages: languages,
-	}
-
-	lgr.Debug().Msg("executed")
-	return resp, nil
+	}, nil
 }
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+const dateLayout = "2006-01-02"
+
+func newStatusCounts() entities.StatusCounts {
+	counts := make(entities.StatusCounts, len(reviewStatuses))
+	for _, status := range reviewStatuses {
+		counts[status.String()] = 0
+	}
+	return counts
+}
+
+// learningStats collects the counters of the terms, the days are the dates in the requested time zone
+type learningStats struct {
+	location  *time.Location
+	total     int
+	statuses  entities.StatusCounts
+	languages map[uint64]*entities.LanguageStats
+	added     map[string]int
+	reviewed  map[string]int
+}
+
+func newLearningStats(location *time.Location) *learningStats {
+	return &learningStats{
+		location:  location,
+		statuses:  newStatusCounts(),
+		languages: make(map[uint64]*entities.LanguageStats),
+		added:     make(map[string]int),
+		reviewed:  make(map[string]int),
+	}
+}
+
+func (l *learningStats) add(collection *entities.CollectionStats, term *VocabularyApiProto.Term) {
+	status := mappers.VocabularyStatusToGatewayStatus[term.Status].String()
+
+	language, ok := l.languages[term.TermLanguageId]
+	if !ok {
+		language = &entities.LanguageStats{
+			LanguageId: term.TermLanguageId,
+			Statuses:   newStatusCounts(),
+		}
+		l.languages[term.TermLanguageId] = language
+	}
+
+	for _, counts := range []entities.StatusCounts{l.statuses, collection.Statuses, language.Statuses} {
+		counts[status]++
+	}
+	l.total++
+	collection.Total++
+	language.Total++
+
+	if term.CreatedAt != nil {
+		l.added[term.CreatedAt.AsTime().In(l.location).Format(dateLayout)]++
+	}
+	if term.RepeatedAt != nil {
+		l.reviewed[term.RepeatedAt.AsTime().In(l.location).Format(dateLayout)]++
+	}
+}
+
+// series returns the counts of the last days, the missing days are zero
+func series(counts map[string]int, today time.Time, days int) []*entities.DailyCount {
+	result := make([]*entities.DailyCount, days)
+	for i := 0; i < days; i++ {
+		date := today.AddDate(0, 0, i-days+1).Format(dateLayout)
+		result[i] = &entities.DailyCount{Date: date, Count: counts[date]}
+	}
+	return result
+}
+
+// streak counts the days in a row with a review back from today, or from yesterday while today has none yet.
+// The days come from RepeatedAt, the last review of every term: a day is lost when all the terms reviewed
+// on it are reviewed again later, so the streak may be shorter than the real one.
+func streak(reviewed map[string]int, today time.Time) *entities.StudyStreak {
+	result := &entities.StudyStreak{
+		StudiedToday: reviewed[today.Format(dateLayout)] > 0,
+	}
+
+	day := today
+	if !result.StudiedToday {
+		day = today.AddDate(0, 0, -1)
+	}
+	for reviewed[day.Format(dateLayout)] > 0 {
+		result.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	for date := range reviewed {
+		if date > result.LastStudiedOn {
+			result.LastStudiedOn = date
+		}
+	}
+	return result
+}
+
+// GetLearningStats aggregates the terms of the own collections of the user.
+// The collections are loaded concurrently and the result is cached for a short time.
+// vocabulary_api keeps no review history, the reviews per day and the streak are built from RepeatedAt only.
+func (s *GatewayApiService) GetLearningStats(ctx context.Context, request *entities.GetLearningStatsRequest) (*entities.GetLearningStatsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GetLearningStats").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	days := request.Days
+	if days == 0 {
+		days = s.cfg.Stats.Days
+	}
+	if days > s.cfg.Stats.MaxDays {
+		days = s.cfg.Stats.MaxDays
+	}
+
+	location, err := time.LoadLocation(request.TimeZone)
+	if err != nil {
+		outerErr := _errors.BadRequestError(err)
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	load := func(ctx context.Context) (*entities.GetLearningStatsResponse, error) {
+		return s.loadLearningStats(ctx, lgr, tokenClaims.UserId, location, days)
+	}
+
+	var resp *entities.GetLearningStatsResponse
+	if s.cfg.Cache.Stats.Enabled {
+		key := fmt.Sprintf("%d:%s:%d", tokenClaims.UserId, location, days)
+		resp, err = s.statsCache.Get(ctx, key, load)
+	} else {
+		resp, err = load(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
+
+func (s *GatewayApiService) loadLearningStats(ctx context.Context, lgr zerolog.Logger, userId uint64, location *time.Location, days int) (*entities.GetLearningStatsResponse, error) {
+	getCollections, err := s.vocabularyApiClient.GetCollections(ctx, &VocabularyApiProto.GetCollectionsRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToGetCollections
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	var mu sync.Mutex
+	stats := newLearningStats(location)
+	collections := make([]*entities.CollectionStats, len(getCollections.Collections))
+
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.Stats.Concurrency)
+	for i, collection := range getCollections.Collections {
+		i, collection := i, collection
+		collections[i] = &entities.CollectionStats{
+			CollectionId: collection.CollectionId,
+			Name:         collection.Name,
+			LanguageId:   collection.LanguageId,
+			Statuses:     newStatusCounts(),
+		}
+
+		group.Go(fmt.Sprint(collection.CollectionId), func(ctx context.Context) error {
+			terms, err := s.listTerms(ctx, collection.CollectionId)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, term := range terms {
+				stats.add(collections[i], term)
+			}
+			return nil
+		})
+	}
+
+	if err = group.Wait(); err != nil {
+		var branchErr *fan_out.BranchError
+		if !errors.As(err, &branchErr) {
+			branchErr = &fan_out.BranchError{Err: err}
+		}
+
+		outerErr := _errors.FailedToGetLearningStats
+		lgr.Error().Err(err).Str("collection_id", branchErr.Branch).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	languages := make([]*entities.LanguageStats, 0, len(stats.languages))
+	for _, language := range stats.languages {
+		languages = append(languages, language)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Total != languages[j].Total {
+			return languages[i].Total > languages[j].Total
+		}
+		return languages[i].LanguageId < languages[j].LanguageId
+	})
+
+	now := time.Now()
+	today := now.In(location)
+	return &entities.GetLearningStatsResponse{
+		Total:          stats.total,
+		Statuses:       stats.statuses,
+		Collections:    collections,
+		Languages:      languages,
+		AddedPerDay:    series(stats.added, today, days),
+		ReviewedPerDay: series(stats.reviewed, today, days),
+		Streak:         streak(stats.reviewed, today),
+		GeneratedAt:    now,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+)
+
+// GetSharedCollection returns the collection of the share link, it is called without the authorization
+func (s *GatewayApiService) GetSharedCollection(ctx context.Context, request *entities.SharedCollectionRequest) (*GatewayApiProto.GetCollectionResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GetSharedCollection").
+		Logger()
+
+	claims, err := s.verifyShareLink(ctx, lgr, request.Token)
+	if err != nil {
+		return nil, err
+	}
+	lgr = lgr.With().Str("link_id", claims.LinkId).Uint64("collection_id", claims.CollectionId).Logger()
+
+	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
+		CollectionId: claims.CollectionId,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToGetCollection
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &GatewayApiProto.GetCollectionResponse{
+		Collection: gatewayCollection(getCollection.Collection),
+	}
+
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
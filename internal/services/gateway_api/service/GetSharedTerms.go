@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+)
+
+// GetSharedTerms returns the terms of the collection of the share link, it is called without the authorization
+func (s *GatewayApiService) GetSharedTerms(ctx context.Context, request *entities.SharedCollectionRequest) (*GatewayApiProto.GetTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "GetSharedTerms").
+		Logger()
+
+	claims, err := s.verifyShareLink(ctx, lgr, request.Token)
+	if err != nil {
+		return nil, err
+	}
+	lgr = lgr.With().Str("link_id", claims.LinkId).Uint64("collection_id", claims.CollectionId).Logger()
+
+	getTerms, err := s.vocabularyApiClient.GetTerms(ctx, &VocabularyApiProto.GetTermsRequest{
+		CollectionId: claims.CollectionId,
+	})
+	if err != nil {
+		outerErr := _errors.FailedToGetTerms
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &GatewayApiProto.GetTermsResponse{
+		Terms: gatewayTerms(getTerms.Terms),
+	}
+
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
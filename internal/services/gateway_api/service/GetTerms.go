@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
@@ -12,6 +16,12 @@ import (
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 )
 
+// Branches of the GetTerms fan-out
+const (
+	getTermsBranchCollection = "collection"
+	getTermsBranchTerms      = "terms"
+)
+
 func (s *GatewayApiService) GetTerms(ctx context.Context, request *GatewayApiProto.GetTermsRequest) (*GatewayApiProto.GetTermsResponse, error) {
 	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
 	lgr := s.lgr.With().
@@ -27,32 +37,22 @@ func (s *GatewayApiService) GetTerms(ctx context.Context, request *GatewayApiPro
 		return nil, outerErr
 	}
 
-	getCollection, err := s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
-		CollectionId: request.CollectionId,
-	})
+	vocabularyTerms, err := s.getReadableTerms(ctx, lgr, tokenClaims.UserId, request.CollectionId)
 	if err != nil {
-		outerErr := _errors.FailedToGetCollection
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
+		return nil, err
 	}
 
-	if getCollection.Collection.UserId != tokenClaims.UserId && getCollection.Collection.IsPublic != true {
-		outerErr := _errors.FailedToGetCollectionPrivateCollection
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
+	resp := &GatewayApiProto.GetTermsResponse{
+		Terms: gatewayTerms(vocabularyTerms),
 	}
 
-	getCollections, err := s.vocabularyApiClient.GetTerms(ctx, &VocabularyApiProto.GetTermsRequest{
-		CollectionId: request.CollectionId,
-	})
-	if err != nil {
-		outerErr := _errors.FailedToGetTerms
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
-	}
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
 
-	var terms = make([]*GatewayApiProto.Term, len(getCollections.Terms))
-	for i, term := range getCollections.Terms {
+func gatewayTerms(vocabularyTerms []*VocabularyApiProto.Term) []*GatewayApiProto.Term {
+	var terms = make([]*GatewayApiProto.Term, len(vocabularyTerms))
+	for i, term := range vocabularyTerms {
 		terms[i] = &GatewayApiProto.Term{
 			TermId:            term.TermId,
 			CollectionId:      term.CollectionId,
@@ -69,11 +69,36 @@ func (s *GatewayApiService) GetTerms(ctx context.Context, request *GatewayApiPro
 			UpdatedAt:         term.UpdatedAt,
 		}
 	}
+	return terms
+}
 
-	resp := &GatewayApiProto.GetTermsResponse{
-		Terms: terms,
+// getReadableTerms loads the terms of the collection, which is public or owned or shared with the user.
+// The terms are loaded together with the access check, the denied access cancels the load.
+func (s *GatewayApiService) getReadableTerms(ctx context.Context, lgr zerolog.Logger, userId uint64, collectionId uint64) ([]*VocabularyApiProto.Term, error) {
+	var getTerms *VocabularyApiProto.GetTermsResponse
+
+	group, _ := fan_out.WithContext(ctx)
+	group.Go(getTermsBranchCollection, func(ctx context.Context) error {
+		_, err := s.authorizeCollection(ctx, lgr, userId, collectionId, accessView)
+		return err
+	})
+	group.Go(getTermsBranchTerms, func(ctx context.Context) (err error) {
+		getTerms, err = s.vocabularyApiClient.GetTerms(ctx, &VocabularyApiProto.GetTermsRequest{
+			CollectionId: collectionId,
+		})
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		var branchErr *fan_out.BranchError
+		if errors.As(err, &branchErr) && branchErr.Branch == getTermsBranchCollection {
+			return nil, branchErr.Err // it is logged by authorizeCollection
+		}
+
+		outerErr := _errors.FailedToGetTerms
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
 	}
 
-	lgr.Debug().Msg("executed")
-	return resp, nil
+	return getTerms.Terms, nil
 }
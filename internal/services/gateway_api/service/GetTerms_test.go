@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+)
+
+func newGetTermsService(tb testing.TB, calls *latency, ownerId uint64) *GatewayApiService {
+	s := newTestService(tb)
+	s.vocabularyApiClient = &fakeVocabularyApiClient{
+		latency:    calls,
+		collection: &VocabularyApiProto.Collection{CollectionId: 10, UserId: ownerId},
+		terms: []*VocabularyApiProto.Term{
+			{TermId: 100, CollectionId: 10, Term: "hello"},
+			{TermId: 101, CollectionId: 10, Term: "world"},
+		},
+	}
+	return s
+}
+
+func userContext(userId uint64) context.Context {
+	return context.WithValue(context.Background(), constants.TokenClaimsKey, &_jwt.TokenClaims{UserId: userId})
+}
+
+func TestGetTermsPrivateCollection(t *testing.T) {
+	s := newGetTermsService(t, &latency{}, 1)
+
+	resp, err := s.GetTerms(userContext(1), &GatewayApiProto.GetTermsRequest{CollectionId: 10})
+	if err != nil || len(resp.Terms) != 2 {
+		t.Fatalf("owner: got %v, %v", resp, err)
+	}
+
+	_, err = s.GetTerms(userContext(2), &GatewayApiProto.GetTermsRequest{CollectionId: 10})
+	if !errors.Is(err, _errors.FailedToGetCollectionPrivateCollection) {
+		t.Fatalf("stranger: got %v, want the private collection error", err)
+	}
+}
+
+// BenchmarkGetTerms compares the concurrent access check and load with the same calls made one after the other:
+// sequential-ns/op is the sum of the injected latencies of all the calls.
+func BenchmarkGetTerms(b *testing.B) {
+	calls := &latency{delay: 2 * time.Millisecond}
+	s := newGetTermsService(b, calls, 1)
+	ctx := userContext(1)
+	request := &GatewayApiProto.GetTermsRequest{CollectionId: 10}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GetTerms(ctx, request); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(calls.total.Load())/float64(b.N), "sequential-ns/op")
+}
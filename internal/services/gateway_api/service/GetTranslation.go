@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	TranslationApiProto "gitlab.com/wbwapis/go-genproto/wbw/translation/translation_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
@@ -18,21 +21,17 @@ func (s *GatewayApiService) GetTranslation(ctx context.Context, request *Gateway
 		Interface("request", request).
 		Logger()
 
-	getTranslation, err := s.translationApiClient.GetTranslation(ctx, &TranslationApiProto.GetTranslationRequest{
-		Text:           request.Text,
-		SourceLanguage: request.SourceLanguage,
-		TargetLanguage: request.TargetLanguage,
-	})
+	texts, err := s.translate(ctx, request)
 	if err != nil {
 		outerErr := _errors.FailedToGetTranslation
 		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
 		return nil, outerErr
 	}
 
-	translations := make([]*GatewayApiProto.Translations, len(getTranslation.Translations))
-	for i, tr := range getTranslation.Translations {
+	translations := make([]*GatewayApiProto.Translations, len(texts))
+	for i, text := range texts {
 		translations[i] = &GatewayApiProto.Translations{
-			Text: tr.Text,
+			Text: text,
 		}
 	}
 
@@ -43,3 +42,72 @@ func (s *GatewayApiService) GetTranslation(ctx context.Context, request *Gateway
 	lgr.Debug().Interface("response", resp).Msg("executed")
 	return resp, nil
 }
+
+// translate returns the translations of the text, through the cache when it is enabled
+func (s *GatewayApiService) translate(ctx context.Context, request *GatewayApiProto.GetTranslationRequest) ([]string, error) {
+	if s.cfg.Cache.Translations.Enabled {
+		return s.getCachedTranslation(ctx, request)
+	}
+	return s.getTranslation(ctx, request)
+}
+
+// getCachedTranslation returns the translation from the cache, concurrent misses of the same key share a single call
+func (s *GatewayApiService) getCachedTranslation(ctx context.Context, request *GatewayApiProto.GetTranslationRequest) ([]string, error) {
+	key := translationCacheKey(request)
+
+	if texts, ok := s.translationsCache.Get(key); ok {
+		s.prom.CacheReqCount.WithLabelValues(translationsCacheName, "hit").Inc()
+		return texts, nil
+	}
+	s.prom.CacheReqCount.WithLabelValues(translationsCacheName, "miss").Inc()
+
+	// the caller stops waiting at its own deadline, the shared call goes on and fills the cache
+	loaded := s.translationsLoader.DoChan(key, func() (interface{}, error) {
+		// the result is shared with other callers, so it does not depend on the caller cancellation
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.cfg.Cache.Translations.LoadTimeout)
+		defer cancel()
+
+		generation := s.translationsCache.Generation()
+		texts, err := s.getTranslation(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		// the translation loaded across the invalidation is returned, but not stored
+		s.translationsCache.AddIfUnchanged(key, texts, generation)
+		return texts, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-loaded:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.([]string), nil
+	}
+}
+
+func (s *GatewayApiService) getTranslation(ctx context.Context, request *GatewayApiProto.GetTranslationRequest) ([]string, error) {
+	getTranslation, err := s.translationApiClient.GetTranslation(ctx, &TranslationApiProto.GetTranslationRequest{
+		Text:           request.Text,
+		SourceLanguage: request.SourceLanguage,
+		TargetLanguage: request.TargetLanguage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, len(getTranslation.Translations))
+	for i, tr := range getTranslation.Translations {
+		texts[i] = tr.Text
+	}
+	return texts, nil
+}
+
+// translationCacheKey ignores the case and the extra whitespaces of the text
+func translationCacheKey(request *GatewayApiProto.GetTranslationRequest) string {
+	text := strings.Join(strings.Fields(strings.ToLower(request.Text)), " ")
+	return fmt.Sprintf("%v:%v:%s", request.SourceLanguage, request.TargetLanguage, text)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/term_import"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ImportTerms creates the valid rows of the file in chunks, every row gets its status in the report
+func (s *GatewayApiService) ImportTerms(ctx context.Context, request *entities.ImportTermsRequest) (*entities.ImportTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "ImportTerms").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit); err != nil {
+		return nil, err
+	}
+
+	fallbackEncoding := request.Encoding
+	if fallbackEncoding == "" {
+		fallbackEncoding = s.cfg.Import.FallbackEncoding
+	}
+	text, encoding, err := term_import.Decode(request.File, fallbackEncoding)
+	if err != nil {
+		outerErr := _errors.BadRequestError(err)
+		lgr.Error().Err(err).Msg(_errors.FailedToImportTermsMsg)
+		return nil, outerErr
+	}
+
+	format := request.Format
+	if format == "" {
+		format = term_import.FormatAuto
+	}
+	rows, format, err := term_import.Parse(text, format)
+	if err != nil {
+		outerErr := _errors.BadRequestError(err)
+		lgr.Error().Err(err).Msg(_errors.FailedToImportTermsMsg)
+		return nil, outerErr
+	}
+
+	if len(rows) > s.cfg.Import.MaxRows {
+		outerErr := _errors.FailedToImportTermsTooManyRows
+		lgr.Error().Int("rows", len(rows)).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.ImportTermsResponse{
+		Format:   format,
+		Encoding: encoding,
+		Total:    len(rows),
+		Rows:     make([]*entities.ImportRowReport, len(rows)),
+	}
+
+	var valid []int
+	for i, row := range rows {
+		resp.Rows[i] = &entities.ImportRowReport{Line: row.Line, Status: entities.ImportRowCreated}
+		if err = row.Validate(); err != nil {
+			resp.Rows[i].Status = entities.ImportRowInvalid
+			resp.Rows[i].Error = err.Error()
+			resp.Invalid++
+			continue
+		}
+		valid = append(valid, i)
+	}
+
+	for start := 0; start < len(valid); start += s.cfg.Import.ChunkSize {
+		end := min(start+s.cfg.Import.ChunkSize, len(valid))
+		chunk := valid[start:end]
+
+		terms := make([]*VocabularyApiProto.CreateTerms, len(chunk))
+		for i, rowIndex := range chunk {
+			row := rows[rowIndex]
+			terms[i] = &VocabularyApiProto.CreateTerms{
+				CollectionId:      request.CollectionId,
+				TermLanguageId:    request.TermLanguageId,
+				MeaningLanguageId: request.MeaningLanguageId,
+				Term:              row.Term,
+				Meaning:           row.Meaning,
+				Example:           row.Example,
+				ImageUrl:          row.ImageUrl,
+			}
+		}
+
+		_, err = s.vocabularyApiClient.CreateTerms(ctx, &VocabularyApiProto.CreateTermsRequest{
+			Terms: terms,
+		})
+		if err != nil {
+			lgr.Error().Err(err).Int("first_line", rows[chunk[0]].Line).Msg(_errors.FailedToCreateTermsMsg)
+			for _, rowIndex := range chunk {
+				resp.Rows[rowIndex].Status = entities.ImportRowFailed
+				resp.Rows[rowIndex].Error = status.Convert(err).Message()
+			}
+			resp.Failed += len(chunk)
+			continue
+		}
+		resp.Created += len(chunk)
+	}
+
+	if resp.Created > 0 {
+		s.eventPublisherClient.Publish(ctx, lgr, events.TermsCreatedName, &events.TermsCreated{
+			CollectionId: request.CollectionId,
+			UserId:       tokenClaims.UserId,
+			Count:        uint32(resp.Created),
+			OccurredAt:   timestamppb.Now(),
+		})
+	}
+
+	lgr.Debug().
+		Int("created", resp.Created).
+		Int("invalid", resp.Invalid).
+		Int("failed", resp.Failed).
+		Msg("executed")
+	return resp, nil
+}
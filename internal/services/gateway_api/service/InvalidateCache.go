@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"sort"
+)
+
+func (s *GatewayApiService) InvalidateCache(ctx context.Context, request *entities.InvalidateCacheRequest) (*entities.InvalidateCacheResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "InvalidateCache").
+		Interface("request", request).
+		Logger()
+
+	caches := s.caches()
+
+	names := request.Caches
+	if len(names) == 0 {
+		for name := range caches {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	for _, name := range names {
+		if _, ok := caches[name]; !ok {
+			outerErr := _errors.FailedToInvalidateCacheUnknownCache
+			lgr.Error().Str("cache", name).Msg(outerErr.ErrorMessage)
+			return nil, outerErr
+		}
+	}
+
+	for _, name := range names {
+		if len(request.Keys) == 0 {
+			caches[name].all()
+			continue
+		}
+		for _, key := range request.Keys {
+			caches[name].key(key)
+		}
+	}
+
+	resp := &entities.InvalidateCacheResponse{
+		Invalidated: names,
+	}
+
+	lgr.Info().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	NotificationApiProto "gitlab.com/wbwapis/go-genproto/wbw/notification/notification_api/v1"
+	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Branches of the InviteCollaborator fan-out
+const (
+	inviteCollaboratorBranchCollection = "collection"
+	inviteCollaboratorBranchInvitee    = "invitee"
+	inviteCollaboratorBranchInviter    = "inviter"
+)
+
+// InviteCollaborator stores the invite of the user to the collection, sends the invite email through notification_api
+// and publishes collaborator.invited, the user also finds the invite with GetInvites. The new invite of the same user
+// replaces the pending one, the role of the collaborator is changed by the new invite too.
+//
+// The unknown email or username gets the same response with an invite id which is never stored,
+// so the endpoint does not tell whether the account exists.
+func (s *GatewayApiService) InviteCollaborator(ctx context.Context, request *entities.InviteCollaboratorRequest) (*entities.InviteCollaboratorResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "InviteCollaborator").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if !collaborators.IsRole(request.Role) {
+		outerErr := _errors.FailedToInviteCollaboratorInvalidRole
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	inviteeRequest := &UserApiProto.GetUserRequest{
+		FindBy: &UserApiProto.GetUserRequest_Email{Email: request.Email},
+	}
+	if request.Username != "" {
+		inviteeRequest.FindBy = &UserApiProto.GetUserRequest_Username{Username: request.Username}
+	}
+
+	var getCollection *VocabularyApiProto.GetCollectionResponse
+	var invitee, inviter *UserApiProto.GetUserResponse
+
+	group, _ := fan_out.WithContext(ctx)
+	group.Go(inviteCollaboratorBranchCollection, func(ctx context.Context) (err error) {
+		getCollection, err = s.vocabularyApiClient.GetCollection(ctx, &VocabularyApiProto.GetCollectionRequest{
+			CollectionId: request.CollectionId,
+		})
+		return err
+	})
+	group.Go(inviteCollaboratorBranchInvitee, func(ctx context.Context) (err error) {
+		invitee, err = s.userApiClient.GetUser(ctx, inviteeRequest)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return err
+	})
+	group.Go(inviteCollaboratorBranchInviter, func(ctx context.Context) (err error) {
+		inviter, err = s.userApiClient.GetUser(ctx, &UserApiProto.GetUserRequest{
+			FindBy: &UserApiProto.GetUserRequest_UserId{UserId: tokenClaims.UserId},
+		})
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		var branchErr *fan_out.BranchError
+		if !errors.As(err, &branchErr) {
+			branchErr = &fan_out.BranchError{Err: err}
+		}
+
+		outerErr := _errors.FailedToGetUser
+		if branchErr.Branch == inviteCollaboratorBranchCollection {
+			outerErr = _errors.FailedToGetCollection
+		}
+		lgr.Error().Err(err).Str("branch", branchErr.Branch).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collection := getCollection.Collection
+	err := s.checkCollectionAccess(ctx, lgr, newCollectionAccess(collection), tokenClaims.UserId, accessOwn)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if invitee == nil {
+		resp := &entities.InviteCollaboratorResponse{
+			InviteId:  uuid.NewString(),
+			ExpiresAt: now.Add(s.cfg.Collaboration.InviteTTL),
+		}
+		lgr.Debug().Interface("response", resp).Msg("the invitee is not found, nothing is sent")
+		return resp, nil
+	}
+
+	if invitee.User.UserId == collection.UserId {
+		outerErr := _errors.FailedToInviteCollaboratorOwner
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	invite := &collaborators.Invite{
+		CollectionId: collection.CollectionId,
+		UserId:       invitee.User.UserId,
+		Email:        invitee.User.Email,
+		Role:         request.Role,
+		InvitedBy:    tokenClaims.UserId,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(s.cfg.Collaboration.InviteTTL),
+	}
+	if err = s.collaborators.PutInvite(ctx, invite); err != nil {
+		outerErr := _errors.FailedToInviteCollaborator
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// the invite is kept when the email fails, the repeated invite replaces it
+	err = s.notificationApiClient.SendEmail(ctx, lgr, &NotificationApiProto.SendEmailRequest{
+		Email: invite.Email,
+		Type:  NotificationApiProto.EmailType_EMAIL_TYPE_COLLECTION_INVITE,
+		Params: &NotificationApiProto.SendEmailRequest_CollectionInviteParams{
+			CollectionInviteParams: &NotificationApiProto.CollectionInviteParams{
+				InviteUuid:      invite.InviteId,
+				CollectionName:  collection.Name,
+				InviterUsername: inviter.User.Username,
+				Role:            invite.Role,
+			},
+		},
+	})
+	if err != nil {
+		outerErr := _errors.FailedToSendInviteEmail
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	s.eventPublisherClient.Publish(ctx, lgr, events.CollaboratorInvitedName, &events.CollaboratorInvited{
+		InviteId:        invite.InviteId,
+		CollectionId:    collection.CollectionId,
+		CollectionName:  collection.Name,
+		UserId:          invite.UserId,
+		Email:           invite.Email,
+		Role:            invite.Role,
+		InvitedBy:       invite.InvitedBy,
+		InviterUsername: inviter.User.Username,
+		ExpiresAt:       timestamppb.New(invite.ExpiresAt),
+		OccurredAt:      timestamppb.New(now),
+	})
+
+	resp := &entities.InviteCollaboratorResponse{
+		InviteId:  invite.InviteId,
+		ExpiresAt: invite.ExpiresAt,
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	NotificationApiProto "gitlab.com/wbwapis/go-genproto/wbw/notification/notification_api/v1"
+	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+)
+
+func TestInviteCollaborator(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *entities.InviteCollaboratorRequest
+		wantInvited bool
+	}{
+		{name: "by email", request: &entities.InviteCollaboratorRequest{CollectionId: 10, Email: "bob@example.com", Role: collaborators.RoleEditor}, wantInvited: true},
+		{name: "by username", request: &entities.InviteCollaboratorRequest{CollectionId: 10, Username: "bob", Role: collaborators.RoleViewer}, wantInvited: true},
+		// the unknown account gets the same response, nothing is stored or sent
+		{name: "unknown email", request: &entities.InviteCollaboratorRequest{CollectionId: 10, Email: "nobody@example.com", Role: collaborators.RoleEditor}},
+		{name: "unknown username", request: &entities.InviteCollaboratorRequest{CollectionId: 10, Username: "nobody", Role: collaborators.RoleEditor}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			s.cfg.Collaboration.InviteTTL = time.Hour
+			s.vocabularyApiClient = &fakeVocabularyApiClient{latency: &latency{}, collection: &VocabularyApiProto.Collection{CollectionId: 10, UserId: 1, Name: "Travel"}}
+			s.userApiClient = &fakeUserApiClient{latency: &latency{}, users: []*UserApiProto.User{
+				{UserId: 1, Email: "alice@example.com", Username: "alice"},
+				{UserId: 2, Email: "bob@example.com", Username: "bob"},
+			}}
+			notification := &fakeNotificationApiClient{latency: &latency{}}
+			s.notificationApiClient = notification
+
+			resp, err := s.InviteCollaborator(userContext(1), tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.InviteId == "" || resp.ExpiresAt.IsZero() {
+				t.Fatalf("got %+v, want the invite id and the expiration", resp)
+			}
+
+			invites, err := s.collaborators.UserInvites(userContext(1), 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if invited := len(invites) == 1 && invites[0].InviteId == resp.InviteId; invited != tt.wantInvited {
+				t.Fatalf("got the invites %+v, want invited %v", invites, tt.wantInvited)
+			}
+			if !tt.wantInvited {
+				if sent := notification.sent.Load(); sent != 0 {
+					t.Fatalf("got %d emails, want none", sent)
+				}
+				return
+			}
+
+			email := notification.last.Load()
+			params, ok := email.Params.(*NotificationApiProto.SendEmailRequest_CollectionInviteParams)
+			if email.Email != "bob@example.com" || email.Type != NotificationApiProto.EmailType_EMAIL_TYPE_COLLECTION_INVITE || !ok ||
+				params.CollectionInviteParams.InviteUuid != resp.InviteId || params.CollectionInviteParams.InviterUsername != "alice" {
+				t.Fatalf("got the email %+v, want the invite of bob by alice", email)
+			}
+		})
+	}
+}
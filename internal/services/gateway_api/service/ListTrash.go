@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/trash"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// ListTrash returns the trash of the user, or the trashed terms of the collection the user may edit
+func (s *GatewayApiService) ListTrash(ctx context.Context, request *entities.ListTrashRequest) (*entities.ListTrashResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "ListTrash").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	var collections []*trash.Collection
+	var terms []*trash.Term
+	var err error
+	if request.CollectionId == 0 {
+		collections, terms, err = s.trash.List(ctx, tokenClaims.UserId)
+	} else {
+		if _, err = s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit); err != nil {
+			return nil, err
+		}
+		var trashed map[uint64]*trash.Term
+		trashed, err = s.trash.CollectionTerms(ctx, request.CollectionId)
+		for _, term := range trashed {
+			terms = append(terms, term)
+		}
+		trash.SortTerms(terms)
+	}
+	if err != nil {
+		outerErr := _errors.FailedToListTrash
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// the terms of the trashed collections are restored together with the collection
+	collectionIds := make([]uint64, 0, len(terms))
+	for _, term := range terms {
+		collectionIds = append(collectionIds, term.CollectionId)
+	}
+	trashedCollections, err := s.trash.Collections(ctx, collectionIds)
+	if err != nil {
+		outerErr := _errors.FailedToListTrash
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.ListTrashResponse{
+		Collections: make([]*entities.TrashedCollection, len(collections)),
+		Terms:       make([]*entities.TrashedTerm, len(terms)),
+	}
+	for i, collection := range collections {
+		resp.Collections[i] = trashedCollection(collection)
+	}
+	for i, term := range terms {
+		_, collectionInTrash := trashedCollections[term.CollectionId]
+		resp.Terms[i] = trashedTerm(term, collectionInTrash)
+	}
+
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
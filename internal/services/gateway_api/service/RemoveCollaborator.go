@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// RemoveCollaborator the owner removes any collaborator, the collaborator leaves the collection by removing itself
+func (s *GatewayApiService) RemoveCollaborator(ctx context.Context, request *entities.RemoveCollaboratorRequest) (*entities.RemoveCollaboratorResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "RemoveCollaborator").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if request.UserId != tokenClaims.UserId {
+		_, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessOwn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.collaborators.DeleteMember(ctx, request.CollectionId, request.UserId); err != nil {
+		outerErr := _errors.FailedToRemoveCollaborator
+		if errors.Is(err, collaborators.ErrMemberNotFound) {
+			outerErr = _errors.FailedToRemoveCollaboratorNotFound
+		}
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.RemoveCollaboratorResponse{}
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RestoreCollection takes the collection of the user out of the trash.
+// The terms deleted before the collection stay in the trash and are restored separately.
+func (s *GatewayApiService) RestoreCollection(ctx context.Context, request *entities.RestoreCollectionRequest) (*entities.RestoreCollectionResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "RestoreCollection").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// only the owner sees the collection in the trash
+	collection, err := s.trash.Collection(ctx, request.CollectionId)
+	if err != nil {
+		outerErr := _errors.FailedToRestoreCollection
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+	if collection == nil || collection.UserId != tokenClaims.UserId {
+		outerErr := _errors.FailedToRestoreCollectionNotFound
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	_, err = s.trash.RestoreCollection(ctx, request.CollectionId)
+	s.invalidateCollectionAccess(request.CollectionId)
+	if err != nil {
+		outerErr := _errors.FailedToRestoreCollection
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// the catalog takes the public collection back on the update
+	s.eventPublisherClient.Publish(ctx, lgr, events.CollectionUpdatedName, &events.CollectionUpdated{
+		CollectionId: request.CollectionId,
+		UserId:       tokenClaims.UserId,
+		OccurredAt:   timestamppb.Now(),
+	})
+
+	lgr.Debug().Msg("executed")
+	return &entities.RestoreCollectionResponse{CollectionId: request.CollectionId}, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// RestoreTerms takes the terms of the collection out of the trash, the editors may restore them as well
+func (s *GatewayApiService) RestoreTerms(ctx context.Context, request *entities.RestoreTermsRequest) (*entities.RestoreTermsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "RestoreTerms").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	// the collection in the trash is not found, it is restored first
+	if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit); err != nil {
+		return nil, err
+	}
+
+	removed, err := s.trash.RemoveTerms(ctx, request.CollectionId, request.TermIds)
+	if err != nil {
+		outerErr := _errors.FailedToRestoreTerms
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	restored := make(map[uint64]struct{}, len(removed))
+	for _, term := range removed {
+		restored[term.TermId] = struct{}{}
+	}
+	resp := &entities.RestoreTermsResponse{
+		RestoredTermIds: make([]uint64, 0, len(removed)),
+		NotFoundTermIds: make([]uint64, 0),
+	}
+	for _, termId := range request.TermIds {
+		if _, ok := restored[termId]; ok {
+			resp.RestoredTermIds = append(resp.RestoredTermIds, termId)
+		} else {
+			resp.NotFoundTermIds = append(resp.NotFoundTermIds, termId)
+		}
+	}
+
+	lgr.Debug().Int("restored", len(resp.RestoredTermIds)).Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/review_override"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// reviewOverrideVocabularyApiClient returns the transferred terms with RepeatedAt of their source,
+// so GetDueTerms schedules them and GetLearningStats counts their reviews as before the transfer.
+// The override of the term reviewed after the transfer is stale, it is deleted on the way.
+type reviewOverrideVocabularyApiClient struct {
+	IVocabularyApiClient
+	overrides *review_override.Store
+}
+
+func (c *reviewOverrideVocabularyApiClient) GetTerms(ctx context.Context, request *VocabularyApiProto.GetTermsRequest) (*VocabularyApiProto.GetTermsResponse, error) {
+	resp, err := c.IVocabularyApiClient.GetTerms(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := c.overrides.Collection(ctx, request.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	if len(overrides) == 0 {
+		return resp, nil
+	}
+
+	var stale []uint64
+	for _, term := range resp.Terms {
+		override, ok := overrides[term.TermId]
+		if !ok {
+			continue
+		}
+		var repeatedAt *timestamppb.Timestamp
+		if term.RepeatedAt != nil {
+			at := term.RepeatedAt.AsTime()
+			if !override.Applies(&at) {
+				stale = append(stale, term.TermId)
+				continue
+			}
+		}
+		if override.RepeatedAt != nil {
+			repeatedAt = timestamppb.New(*override.RepeatedAt)
+		}
+		term.RepeatedAt = repeatedAt
+	}
+	// the stale override is skipped anyway, the failed delete is retried by the next read
+	_ = c.overrides.Delete(ctx, request.CollectionId, stale...)
+	return resp, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// RevokeInvite deletes the pending invite. The owner of the collection revokes it, the invited user declines it.
+func (s *GatewayApiService) RevokeInvite(ctx context.Context, request *entities.RevokeInviteRequest) (*entities.RevokeInviteResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "RevokeInvite").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	invite, err := s.collaborators.GetInvite(ctx, request.InviteId)
+	if err != nil {
+		outerErr := _errors.FailedToRevokeInvite
+		if errors.Is(err, collaborators.ErrInviteNotFound) {
+			outerErr = _errors.FailedToGetInviteNotFound
+		}
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if invite.UserId != tokenClaims.UserId {
+		_, err = s.authorizeCollection(ctx, lgr, tokenClaims.UserId, invite.CollectionId, accessOwn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.collaborators.DeleteInvite(ctx, invite.InviteId); err != nil && !errors.Is(err, collaborators.ErrInviteNotFound) {
+		outerErr := _errors.FailedToRevokeInvite
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.RevokeInviteResponse{}
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/share_link"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// RevokeShareLink deletes the link, its token stops working at once
+func (s *GatewayApiService) RevokeShareLink(ctx context.Context, request *entities.RevokeShareLinkRequest) (*entities.RevokeShareLinkResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "RevokeShareLink").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	link, err := s.shareLinks.Get(ctx, request.LinkId)
+	if err != nil {
+		outerErr := _errors.FailedToRevokeShareLink
+		if errors.Is(err, share_link.ErrLinkNotFound) {
+			outerErr = _errors.FailedToGetShareLinkNotFound
+		}
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	if _, err = s.authorizeCollection(ctx, lgr, tokenClaims.UserId, link.CollectionId, accessOwn); err != nil {
+		return nil, err
+	}
+
+	if err = s.shareLinks.Delete(ctx, link.LinkId); err != nil && !errors.Is(err, share_link.ErrLinkNotFound) {
+		outerErr := _errors.FailedToRevokeShareLink
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.RevokeShareLinkResponse{}
+	lgr.Debug().Msg("executed")
+	return resp, nil
+}
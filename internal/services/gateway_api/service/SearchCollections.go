@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/catalog"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// SearchCollections searches the public collections in the catalog shared by the instances, vocabulary_api is not called
+func (s *GatewayApiService) SearchCollections(ctx context.Context, request *entities.SearchCollectionsRequest) (*entities.SearchCollectionsResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "SearchCollections").
+		Interface("request", request).
+		Logger()
+
+	if !s.cfg.Catalog.Enabled {
+		outerErr := _errors.FailedToSearchCollectionsDisabled
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	sort := request.Sort
+	if sort == "" {
+		sort = catalog.SortPopular
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = s.cfg.Catalog.PageSize
+	}
+	if limit > s.cfg.Catalog.MaxPageSize {
+		limit = s.cfg.Catalog.MaxPageSize
+	}
+
+	page, err := s.catalog.Search(ctx, catalog.Query{
+		Text:       request.Query,
+		LanguageId: request.LanguageId,
+		MinTerms:   request.MinTerms,
+		MaxTerms:   request.MaxTerms,
+		Sort:       sort,
+		Cursor:     request.Cursor,
+		Limit:      limit,
+	})
+	if errors.Is(err, catalog.ErrInvalidCursor) {
+		outerErr := _errors.FailedToSearchCollectionsInvalidCursor
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+	if err != nil {
+		outerErr := _errors.FailedToSearchCollections
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.SearchCollectionsResponse{
+		Collections: make([]*entities.CatalogCollection, len(page.Entries)),
+		NextCursor:  page.NextCursor,
+	}
+	for i, entry := range page.Entries {
+		resp.Collections[i] = &entities.CatalogCollection{
+			CollectionId: entry.CollectionId,
+			UserId:       entry.UserId,
+			LanguageId:   entry.LanguageId,
+			Name:         entry.Name,
+			Description:  entry.Description,
+			TotalTerms:   entry.TotalTerms,
+			Clones:       entry.Clones,
+			CreatedAt:    entry.CreatedAt,
+			UpdatedAt:    entry.UpdatedAt,
+		}
+	}
+
+	lgr.Debug().Int("collections", len(resp.Collections)).Msg("executed")
+	return resp, nil
+}
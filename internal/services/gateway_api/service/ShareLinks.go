@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/share_link"
+)
+
+// verifyShareLink returns the claims of the signed, not expired and not revoked token
+func (s *GatewayApiService) verifyShareLink(ctx context.Context, lgr zerolog.Logger, token string) (*share_link.Claims, error) {
+	claims, err := share_link.Verify([]byte(s.cfg.ShareLink.Secret), token, time.Now())
+	if err != nil {
+		outerErr := _errors.FailedToGetShareLinkInvalid
+		if errors.Is(err, share_link.ErrExpiredToken) {
+			outerErr = _errors.FailedToGetShareLinkExpired
+		}
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	link, err := s.shareLinks.Get(ctx, claims.LinkId)
+	if err != nil {
+		outerErr := _errors.FailedToGetShareLinkInvalid
+		lgr.Error().Err(err).Str("link_id", claims.LinkId).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+	if link.CollectionId != claims.CollectionId {
+		outerErr := _errors.FailedToGetShareLinkInvalid
+		lgr.Error().Str("link_id", claims.LinkId).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	return claims, nil
+}
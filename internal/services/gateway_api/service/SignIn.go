@@ -8,6 +8,7 @@ import (
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
@@ -26,7 +27,9 @@ func (s *GatewayApiService) SignIn(ctx context.Context, request *GatewayApiProto
 		Logger()
 
 	var user *GatewayApiProto.User
+	provider := events.AuthProvider_AUTH_PROVIDER_PASSWORD
 	if request.GetGoogleToken() != "" {
+		provider = events.AuthProvider_AUTH_PROVIDER_GOOGLE
 		user, err = s.getOrCreateUserByGoogleToken(ctx, lgr, request)
 	} else {
 		user, err = s.getUserByEmailOrUsername(ctx, lgr, request)
@@ -49,6 +52,12 @@ func (s *GatewayApiService) SignIn(ctx context.Context, request *GatewayApiProto
 		return nil, outerErr
 	}
 
+	s.eventPublisherClient.Publish(ctx, lgr, events.UserSignedInName, &events.UserSignedIn{
+		UserId:     user.UserId,
+		Provider:   provider,
+		OccurredAt: timestamppb.Now(),
+	})
+
 	resp = &GatewayApiProto.SignInResponse{
 		AccessToken:  generateTokensResp.AccessToken,
 		RefreshToken: generateTokensResp.RefreshToken,
@@ -117,6 +126,12 @@ func (s *GatewayApiService) getOrCreateUserByGoogleToken(ctx context.Context, lg
 		return nil, outerErr
 	}
 
+	s.eventPublisherClient.Publish(ctx, lgr, events.UserSignedUpName, &events.UserSignedUp{
+		UserId:     createUserResp.User.UserId,
+		Provider:   events.AuthProvider_AUTH_PROVIDER_GOOGLE,
+		OccurredAt: timestamppb.Now(),
+	})
+
 	return &GatewayApiProto.User{
 		UserId:          createUserResp.User.UserId,
 		Email:           createUserResp.User.Email,
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/saga"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
 
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	"gitlab.com/wordbyword.io/microservices/pkg/errors/outer"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	ActionApiProto "gitlab.com/wbwapis/go-genproto/wbw/action/action_api/v1"
 	AuthApiProto "gitlab.com/wbwapis/go-genproto/wbw/auth/auth_api/v1"
@@ -15,8 +20,25 @@ import (
 	NotificationApiProto "gitlab.com/wbwapis/go-genproto/wbw/notification/notification_api/v1"
 	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
 )
 
+// Steps of the SignUp saga
+const (
+	signUpSaga                  = "sign_up"
+	signUpStepCreateUser        = "create_user"
+	signUpStepGenerateTokens    = "generate_tokens"
+	signUpStepConfirmationEmail = "send_confirmation_email"
+)
+
+// SignUp is the saga:
+//  1. create_user - can not be undone (user_api can not delete users), so it goes first.
+//     The repeated sign up of the unconfirmed user with the same password resumes the saga with the existing user,
+//     so the failed sign up does not leave the account without the tokens and the confirmation email.
+//  2. generate_tokens and send_confirmation_email run concurrently, both depend on the user only:
+//     - generate_tokens - is not undone, the deferred email fails the saga only when the retrier is full,
+//     then the tokens are never returned to the client and just expire
+//     - send_confirmation_email - is retried in the background, the account is usable without it
 func (s *GatewayApiService) SignUp(ctx context.Context, request *GatewayApiProto.SignUpRequest) (*GatewayApiProto.SignUpResponse, error) {
 	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
 	clientIP := utils.AnyToString(ctx.Value(constants.ClientIPKey))
@@ -33,63 +55,78 @@ func (s *GatewayApiService) SignUp(ctx context.Context, request *GatewayApiProto
 		settings.InterfaceLanguageId = request.Settings.InterfaceLanguageId
 	}
 
-	createUserResp, err := s.userApiClient.CreateUser(ctx, &UserApiProto.CreateUserRequest{
-		Password: request.Password,
-		Email:    request.Email,
-		Username: request.Username,
-		Settings: &settings,
-	})
-	if err != nil {
-		if errGrpc, ok := status.FromError(err); ok {
-			if errGrpc.Code() == codes.AlreadyExists {
-				outerErr := _errors.AlreadyExistError(errGrpc.Message())
-				lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-				return nil, outerErr
-			}
-		}
-
-		outerErr := _errors.FailedToCreateUser
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
-	}
+	var createUserResp *UserApiProto.CreateUserResponse
+	var generateTokensResp *AuthApiProto.GenerateTokensResponse
+	var actionUuid string
 
-	generateTokensResp, err := s.authApiClient.GenerateTokens(ctx, &AuthApiProto.GenerateTokensRequest{
-		UserId: createUserResp.User.UserId,
-		Ip:     clientIP,
-		Device: device,
-	})
-	if err != nil {
-		outerErr := _errors.FailedToGenerateTokens
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
-	}
+	signUp := saga.New(signUpSaga, s.sagaRetrier, lgr, s.prom).
+		AddStep(saga.Step{
+			Name: signUpStepCreateUser,
+			Action: func(ctx context.Context) (err error) {
+				createUserResp, err = s.userApiClient.CreateUser(ctx, &UserApiProto.CreateUserRequest{
+					Password: request.Password,
+					Email:    request.Email,
+					Username: request.Username,
+					Settings: &settings,
+				})
+				if errGrpc, ok := status.FromError(err); ok && errGrpc.Code() == codes.AlreadyExists {
+					user := s.unconfirmedSignUpUser(ctx, lgr, request)
+					if user == nil {
+						return err
+					}
+					lgr.Info().Uint64("user_id", user.UserId).Msg("resuming the sign up of the unconfirmed user")
+					createUserResp = &UserApiProto.CreateUserResponse{User: user}
+					return nil // the user is signed up already, the event is published
+				}
+				if err != nil {
+					return err
+				}
 
-	createActionRes, err := s.actionApiClient.CreateAction(ctx, &ActionApiProto.CreateActionRequest{
-		Action: ActionApiProto.ActionType_ACTION_TYPE_EMAIL_CONFIRMATION,
-		Params: &ActionApiProto.CreateActionRequest_EmailConfirmationParams{
-			EmailConfirmationParams: &ActionApiProto.EmailConfirmationParams{
-				UserId: createUserResp.User.UserId,
+				s.eventPublisherClient.Publish(ctx, lgr, events.UserSignedUpName, &events.UserSignedUp{
+					UserId:     createUserResp.User.UserId,
+					Provider:   events.AuthProvider_AUTH_PROVIDER_PASSWORD,
+					OccurredAt: timestamppb.Now(),
+				})
+				return nil
 			},
-		},
-	})
-	if err != nil {
-		outerErr := _errors.FailedToCreateAction
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
-		return nil, outerErr
-	}
-
-	err = s.notificationApiClient.SendEmail(ctx, lgr, &NotificationApiProto.SendEmailRequest{
-		Email: request.Email,
-		Type:  NotificationApiProto.EmailType_EMAIL_TYPE_EMAIL_CONFIRMATION,
-		Params: &NotificationApiProto.SendEmailRequest_EmailConfirmationParams{
-			EmailConfirmationParams: &NotificationApiProto.EmailConfirmationParams{
-				ActionUuid: createActionRes.ActionUuid,
+		}).
+		AddParallelSteps(saga.Step{
+			Name: signUpStepGenerateTokens,
+			Action: func(ctx context.Context) (err error) {
+				generateTokensResp, err = s.authApiClient.GenerateTokens(ctx, &AuthApiProto.GenerateTokensRequest{
+					UserId: createUserResp.User.UserId,
+					Ip:     clientIP,
+					Device: device,
+				})
+				return err
 			},
-		},
-	})
-	if err != nil {
-		outerErr := _errors.FailedToSendConfirmationEmail
-		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		}, saga.Step{
+			Name:       signUpStepConfirmationEmail,
+			Deferrable: true,
+			Action: func(ctx context.Context) error {
+				return s.sendConfirmationEmail(ctx, lgr, createUserResp.User.UserId, request.Email, &actionUuid)
+			},
+		})
+
+	if err := signUp.Run(ctx, detachContext(ctx)); err != nil {
+		var stepErr *saga.StepError
+		if !errors.As(err, &stepErr) {
+			stepErr = &saga.StepError{Step: signUpStepCreateUser, Err: err}
+		}
+
+		var outerErr *outer.OuterError
+		switch stepErr.Step {
+		case signUpStepCreateUser:
+			outerErr = _errors.FailedToCreateUser
+			if errGrpc, ok := status.FromError(stepErr.Err); ok && errGrpc.Code() == codes.AlreadyExists {
+				outerErr = _errors.AlreadyExistError(errGrpc.Message())
+			}
+		case signUpStepGenerateTokens:
+			outerErr = _errors.FailedToGenerateTokens
+		default:
+			outerErr = _errors.FailedToSendConfirmationEmail
+		}
+		lgr.Error().Err(err).Str("step", stepErr.Step).Msg(outerErr.ErrorMessage)
 		return nil, outerErr
 	}
 
@@ -112,3 +149,57 @@ func (s *GatewayApiService) SignUp(ctx context.Context, request *GatewayApiProto
 	lgr.Debug().Interface("response", resp).Msg("executed")
 	return resp, nil
 }
+
+// unconfirmedSignUpUser returns the existing user of the repeated sign up, nil when it is not the same user:
+// the password does not match, the username differs or the email is confirmed already
+func (s *GatewayApiService) unconfirmedSignUpUser(ctx context.Context, lgr zerolog.Logger, request *GatewayApiProto.SignUpRequest) *UserApiProto.User {
+	resp, err := s.userApiClient.GetUserByCredentials(ctx, &UserApiProto.GetUserByCredentialsRequest{
+		LoginBy:  &UserApiProto.GetUserByCredentialsRequest_Email{Email: request.Email},
+		Password: request.Password,
+	})
+	if err != nil {
+		lgr.Debug().Err(err).Msg("the existing user does not match the sign up")
+		return nil
+	}
+
+	user := resp.User
+	if user.EmailVerifiedAt != nil || user.Username != request.Username {
+		return nil
+	}
+	return user
+}
+
+// sendConfirmationEmail is safe to repeat: the created action is kept in actionUuid and reused by the next attempts
+func (s *GatewayApiService) sendConfirmationEmail(ctx context.Context, lgr zerolog.Logger, userId uint64, email string, actionUuid *string) error {
+	if *actionUuid == "" {
+		createActionRes, err := s.actionApiClient.CreateAction(ctx, &ActionApiProto.CreateActionRequest{
+			Action: ActionApiProto.ActionType_ACTION_TYPE_EMAIL_CONFIRMATION,
+			Params: &ActionApiProto.CreateActionRequest_EmailConfirmationParams{
+				EmailConfirmationParams: &ActionApiProto.EmailConfirmationParams{
+					UserId: userId,
+				},
+			},
+		})
+		if err != nil {
+			lgr.Error().Err(err).Msg(_errors.FailedToCreateAction.ErrorMessage)
+			return err
+		}
+		*actionUuid = createActionRes.ActionUuid
+	}
+
+	err := s.notificationApiClient.SendEmail(ctx, lgr, &NotificationApiProto.SendEmailRequest{
+		Email: email,
+		Type:  NotificationApiProto.EmailType_EMAIL_TYPE_EMAIL_CONFIRMATION,
+		Params: &NotificationApiProto.SendEmailRequest_EmailConfirmationParams{
+			EmailConfirmationParams: &NotificationApiProto.EmailConfirmationParams{
+				ActionUuid: *actionUuid,
+			},
+		},
+	})
+	if err != nil {
+		lgr.Error().Err(err).Msg(_errors.FailedToSendConfirmationEmail.ErrorMessage)
+		return err
+	}
+
+	return nil
+}
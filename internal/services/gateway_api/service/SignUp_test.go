@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/pkg/errors/outer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
+)
+
+func TestSignUp(t *testing.T) {
+	unconfirmed := &UserApiProto.User{UserId: 42, Email: "user@example.com", Username: "user", Settings: &UserApiProto.Settings{}}
+	confirmed := &UserApiProto.User{UserId: 42, Email: "user@example.com", Username: "user", Settings: &UserApiProto.Settings{}, EmailVerifiedAt: timestamppb.Now()}
+
+	tests := []struct {
+		name          string
+		existing      *UserApiProto.User
+		password      string // of the existing user
+		request       *GatewayApiProto.SignUpRequest
+		wantUserId    uint64
+		wantPublished []string
+		wantErr       bool
+	}{
+		{
+			name:          "new user",
+			request:       &GatewayApiProto.SignUpRequest{Email: "user@example.com", Username: "user", Password: "password"},
+			wantUserId:    1,
+			wantPublished: []string{events.UserSignedUpName},
+		},
+		{
+			name:       "unconfirmed user with the same credentials is resumed",
+			existing:   unconfirmed,
+			password:   "password",
+			request:    &GatewayApiProto.SignUpRequest{Email: "user@example.com", Username: "user", Password: "password"},
+			wantUserId: 42,
+		},
+		{
+			name:     "wrong password on the resume",
+			existing: unconfirmed,
+			password: "password",
+			request:  &GatewayApiProto.SignUpRequest{Email: "user@example.com", Username: "user", Password: "guess"},
+			wantErr:  true,
+		},
+		{
+			name:     "another username on the resume",
+			existing: unconfirmed,
+			password: "password",
+			request:  &GatewayApiProto.SignUpRequest{Email: "user@example.com", Username: "other", Password: "password"},
+			wantErr:  true,
+		},
+		{
+			name:     "confirmed user is not resumed",
+			existing: confirmed,
+			password: "password",
+			request:  &GatewayApiProto.SignUpRequest{Email: "user@example.com", Username: "user", Password: "password"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := &latency{}
+			s := newTestService(t)
+			defer s.sagaRetrier.Stop()
+			publisher := &fakeEventPublisherClient{}
+			notification := &fakeNotificationApiClient{latency: calls}
+			s.eventPublisherClient = publisher
+			s.userApiClient = &fakeUserApiClient{latency: calls, existing: tt.existing, password: tt.password}
+			s.authApiClient = &fakeAuthApiClient{latency: calls}
+			s.actionApiClient = &fakeActionApiClient{latency: calls}
+			s.notificationApiClient = notification
+
+			resp, err := s.SignUp(context.Background(), tt.request)
+			if tt.wantErr {
+				// the existing account is reported as it was before the resume
+				var outerErr *outer.OuterError
+				if !errors.As(err, &outerErr) || outerErr.GrpcStatusCode != codes.AlreadyExists {
+					t.Fatalf("SignUp() error = %v, want already exists", err)
+				}
+				if notification.sent.Load() != 0 {
+					t.Fatal("the confirmation email is sent to the existing user")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.User.UserId != tt.wantUserId || resp.AccessToken == "" {
+				t.Fatalf("SignUp() user = %d, access token %q, want %d", resp.User.UserId, resp.AccessToken, tt.wantUserId)
+			}
+			if !slices.Equal(publisher.published, tt.wantPublished) {
+				t.Fatalf("published = %v, want %v", publisher.published, tt.wantPublished)
+			}
+			if sent := notification.sent.Load(); sent != 1 {
+				t.Fatalf("confirmation emails = %d, want 1", sent)
+			}
+		})
+	}
+}
+
+func TestSignUpDefersConfirmationEmail(t *testing.T) {
+	calls := &latency{}
+	s := newTestService(t)
+	defer s.sagaRetrier.Stop()
+	notification := &fakeNotificationApiClient{latency: calls, err: errors.New("notification_api is down")}
+	s.userApiClient = &fakeUserApiClient{latency: calls}
+	s.authApiClient = &fakeAuthApiClient{latency: calls}
+	s.actionApiClient = &fakeActionApiClient{latency: calls}
+	s.notificationApiClient = notification
+
+	resp, err := s.SignUp(context.Background(), &GatewayApiProto.SignUpRequest{Email: "user@example.com", Username: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("SignUp() error = %v, want the email deferred", err)
+	}
+	if resp.AccessToken == "" {
+		t.Fatal("SignUp() returns no tokens")
+	}
+
+	// the retrier of the test service makes one more attempt
+	deadline := time.Now().Add(time.Second)
+	for notification.sent.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if sent := notification.sent.Load(); sent != 2 {
+		t.Fatalf("confirmation email attempts = %d, want the background retry", sent)
+	}
+}
+
+// BenchmarkSignUp compares the saga with the same calls made one after the other:
+// sequential-ns/op is the sum of the injected latencies of all the calls.
+func BenchmarkSignUp(b *testing.B) {
+	calls := &latency{delay: 2 * time.Millisecond}
+	s := newTestService(b)
+	s.userApiClient = &fakeUserApiClient{latency: calls}
+	s.authApiClient = &fakeAuthApiClient{latency: calls}
+	s.actionApiClient = &fakeActionApiClient{latency: calls}
+	s.notificationApiClient = &fakeNotificationApiClient{latency: calls}
+	defer s.sagaRetrier.Stop()
+
+	request := &GatewayApiProto.SignUpRequest{
+		Email:    "user@example.com",
+		Username: "user",
+		Password: "password",
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.SignUp(context.Background(), request); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(calls.total.Load())/float64(b.N), "sequential-ns/op")
+}
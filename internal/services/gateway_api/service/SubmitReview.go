@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/services/gateway_api/mappers"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
+	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
+	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+)
+
+// SubmitReview moves the term to the status chosen by the scheduler for the recall grade
+func (s *GatewayApiService) SubmitReview(ctx context.Context, request *entities.SubmitReviewRequest) (*entities.SubmitReviewResponse, error) {
+	requestId := utils.AnyToString(ctx.Value(constants.RequestIdKey))
+	lgr := s.lgr.With().
+		Str(constants.RequestIdKey, requestId).
+		Str("api", "SubmitReview").
+		Interface("request", request).
+		Logger()
+
+	tokenClaims, ok := ctx.Value(constants.TokenClaimsKey).(*_jwt.TokenClaims)
+	if !ok {
+		outerErr := _errors.TokenClaimsDoesNotSet
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	collection, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	term, err := s.getTerm(ctx, request.CollectionId, request.TermId)
+	if err != nil {
+		outerErr := _errors.FailedToSubmitReview
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+	if term == nil {
+		outerErr := _errors.FailedToSubmitReviewTermNotFound
+		lgr.Error().Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	oldStatus := mappers.VocabularyStatusToGatewayStatus[term.Status]
+	level := s.scheduler.Next(reviewLevel(oldStatus), request.Grade)
+	status := reviewStatuses[level]
+
+	// The status is changed even if it stays the same: vocabulary_api keeps the time of the repetition
+	err = s.changeTermStatus(ctx, lgr, tokenClaims.UserId, collection.UserId, request.CollectionId, request.TermId, oldStatus, status)
+	if err != nil {
+		outerErr := _errors.FailedToChangeTermStatus
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp := &entities.SubmitReviewResponse{
+		CollectionId: request.CollectionId,
+		TermId:       request.TermId,
+		OldStatus:    oldStatus.String(),
+		Status:       status.String(),
+		DueAt:        time.Now().Add(s.scheduler.Interval(level)),
+	}
+
+	lgr.Debug().Interface("response", resp).Msg("executed")
+	return resp, nil
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/fan_out"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/review_override"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Branches of the transferTerms fan-out
+const (
+	transferTermsBranchSource = "source_terms"
+	transferTermsBranchTarget = "target_terms"
+)
+
+// transferredTerm is the term created in the target collection
+type transferredTerm struct {
+	source *VocabularyApiProto.Term
+	result *entities.TransferTermResult
+}
+
+// termKey identifies the created term in the target collection, CreateTerms does not return the ids
+type termKey struct {
+	term              string
+	meaning           string
+	termLanguageId    uint64
+	meaningLanguageId uint64
+}
+
+func keyOfTerm(term *VocabularyApiProto.Term) termKey {
+	return termKey{
+		term:              term.Term,
+		meaning:           term.Meaning,
+		termLanguageId:    term.TermLanguageId,
+		meaningLanguageId: term.MeaningLanguageId,
+	}
+}
+
+// transferTerms copies the terms to the target collection, with move they are deleted from the source afterwards.
+// vocabulary_api can not change the collection of a term, so the term is recreated:
+//  1. the terms are created in the target in chunks
+//  2. the ids of the new terms are found by comparing the terms of the target before and after
+//  3. the statuses are restored by ChangeTermStatus
+//  4. RepeatedAt of the source is kept in the review overrides, vocabulary_api sets the time of the transfer
+//  5. move: the terms are deleted from the source
+//
+// The overrides are applied by vocabularyApiClient, so GetDueTerms and GetLearningStats see the copy
+// as reviewed at the time of the source. The two listings of the target are not atomic with the create,
+// see findTransferredTerms.
+//
+// Every term gets its own result, a failed term does not stop the others. The term is deleted from the source
+// only when its copy with the restored status and the kept review time is found in the target, so no term is lost.
+func (s *GatewayApiService) transferTerms(ctx context.Context, lgr zerolog.Logger, userId uint64, request *entities.TransferTermsRequest, move bool) (*entities.TransferTermsResponse, error) {
+	if len(request.TermIds) > s.cfg.Transfer.MaxTerms {
+		outerErr := _errors.FailedToTransferTermsTooMany
+		lgr.Error().Int("terms", len(request.TermIds)).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	source, err := s.authorizeCollection(ctx, lgr, userId, request.SourceCollectionId, accessEdit)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.authorizeCollection(ctx, lgr, userId, request.TargetCollectionId, accessEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceTerms, targetTerms []*VocabularyApiProto.Term
+	group, _ := fan_out.WithContext(ctx)
+	group.Go(transferTermsBranchSource, func(ctx context.Context) (err error) {
+		sourceTerms, err = s.listTerms(ctx, source.CollectionId)
+		return err
+	})
+	group.Go(transferTermsBranchTarget, func(ctx context.Context) (err error) {
+		targetTerms, err = s.listTerms(ctx, target.CollectionId)
+		return err
+	})
+	if err = group.Wait(); err != nil {
+		outerErr := _errors.FailedToGetTerms
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return nil, outerErr
+	}
+
+	resp, selected := s.selectTransferredTerms(request, sourceTerms, target, targetTerms)
+
+	s.createTransferredTerms(ctx, lgr, target.CollectionId, selected)
+	s.findTransferredTerms(ctx, lgr, target.CollectionId, targetTerms, selected)
+	s.restoreTransferredStatuses(ctx, lgr, target, selected)
+	s.keepTransferredReviews(ctx, lgr, target.CollectionId, selected)
+
+	created := 0
+	var moved []*transferredTerm
+	for _, term := range selected {
+		if term.result.Result == entities.TransferTermFailed {
+			continue
+		}
+		created++
+		if move && term.result.StatusRestored {
+			moved = append(moved, term)
+		} else if move && term.result.Error == "" {
+			term.result.Error = "status or review time is not restored, the term is kept in the source"
+		}
+	}
+	if len(moved) > 0 {
+		s.deleteTransferredTerms(ctx, lgr, source, moved)
+	}
+
+	for _, result := range resp.Terms {
+		switch result.Result {
+		case entities.TransferTermMoved, entities.TransferTermCopied:
+			resp.Transferred++
+		default:
+			resp.Failed++
+		}
+	}
+
+	if created > 0 {
+		s.eventPublisherClient.Publish(ctx, lgr, events.TermsCreatedName, &events.TermsCreated{
+			CollectionId: target.CollectionId,
+			UserId:       userId,
+			Count:        uint32(created),
+			OccurredAt:   timestamppb.Now(),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *GatewayApiService) listTerms(ctx context.Context, collectionId uint64) ([]*VocabularyApiProto.Term, error) {
+	getTerms, err := s.vocabularyApiClient.GetTerms(ctx, &VocabularyApiProto.GetTermsRequest{
+		CollectionId: collectionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getTerms.Terms, nil
+}
+
+// selectTransferredTerms returns the results in the order of the request and the terms to create.
+// The language pair of the target is its language and the meaning language shared by all its terms,
+// the meaning language is not checked while the target is empty or mixed.
+func (s *GatewayApiService) selectTransferredTerms(
+	request *entities.TransferTermsRequest,
+	sourceTerms []*VocabularyApiProto.Term,
+	target *collectionAccess,
+	targetTerms []*VocabularyApiProto.Term,
+) (*entities.TransferTermsResponse, []*transferredTerm) {
+	byId := make(map[uint64]*VocabularyApiProto.Term, len(sourceTerms))
+	for _, term := range sourceTerms {
+		byId[term.TermId] = term
+	}
+
+	var meaningLanguageId uint64
+	for i, term := range targetTerms {
+		if i > 0 && term.MeaningLanguageId != meaningLanguageId {
+			meaningLanguageId = 0
+			break
+		}
+		meaningLanguageId = term.MeaningLanguageId
+	}
+
+	resp := &entities.TransferTermsResponse{
+		Terms: make([]*entities.TransferTermResult, len(request.TermIds)),
+	}
+	selected := make([]*transferredTerm, 0, len(request.TermIds))
+	for i, termId := range request.TermIds {
+		result := &entities.TransferTermResult{TermId: termId}
+		resp.Terms[i] = result
+
+		term, ok := byId[termId]
+		if !ok {
+			result.Result = entities.TransferTermNotFound
+			continue
+		}
+
+		result.LanguageMismatch = (target.LanguageId != 0 && term.TermLanguageId != target.LanguageId) ||
+			(meaningLanguageId != 0 && term.MeaningLanguageId != meaningLanguageId)
+		if result.LanguageMismatch && request.OnLanguageMismatch != entities.LanguageMismatchMark {
+			result.Result = entities.TransferTermLanguageMismatch
+			continue
+		}
+
+		selected = append(selected, &transferredTerm{source: term, result: result})
+	}
+	return resp, selected
+}
+
+// createTransferredTerms a failed chunk fails its terms only
+func (s *GatewayApiService) createTransferredTerms(ctx context.Context, lgr zerolog.Logger, collectionId uint64, terms []*transferredTerm) {
+	for start := 0; start < len(terms); start += s.cfg.Transfer.ChunkSize {
+		chunk := terms[start:min(start+s.cfg.Transfer.ChunkSize, len(terms))]
+
+		items := make([]*VocabularyApiProto.CreateTerms, len(chunk))
+		for i, term := range chunk {
+			items[i] = &VocabularyApiProto.CreateTerms{
+				CollectionId:      collectionId,
+				TermLanguageId:    term.source.TermLanguageId,
+				MeaningLanguageId: term.source.MeaningLanguageId,
+				Term:              term.source.Term,
+				Meaning:           term.source.Meaning,
+				Example:           term.source.Example,
+				ImageUrl:          term.source.ImageUrl,
+			}
+		}
+
+		_, err := s.vocabularyApiClient.CreateTerms(ctx, &VocabularyApiProto.CreateTermsRequest{
+			Terms: items,
+		})
+		if err != nil {
+			lgr.Error().Err(err).Uint64("first_term_id", chunk[0].source.TermId).Msg(_errors.FailedToTransferTermsMsg)
+			for _, term := range chunk {
+				term.result.Result = entities.TransferTermFailed
+				term.result.Error = status.Convert(err).Message()
+			}
+			continue
+		}
+		for _, term := range chunk {
+			term.result.Result = entities.TransferTermCopied
+		}
+	}
+}
+
+// findTransferredTerms sets the ids of the created terms. CreateTerms does not return the ids, so the new terms
+// of the target are matched by their content. Someone else may create or delete the same terms in the target
+// between the listings, then the number of the new terms differs from the number of the created ones and
+// the copies of the key are left without the id: their status is not restored and move keeps them in the source.
+func (s *GatewayApiService) findTransferredTerms(ctx context.Context, lgr zerolog.Logger, collectionId uint64, before []*VocabularyApiProto.Term, terms []*transferredTerm) {
+	after, err := s.listTerms(ctx, collectionId)
+	if err != nil {
+		lgr.Error().Err(err).Msg(_errors.FailedToGetTermsMsg)
+	}
+
+	existing := make(map[uint64]struct{}, len(before))
+	for _, term := range before {
+		existing[term.TermId] = struct{}{}
+	}
+	created := make(map[termKey][]uint64)
+	for _, term := range after {
+		if _, ok := existing[term.TermId]; !ok {
+			created[keyOfTerm(term)] = append(created[keyOfTerm(term)], term.TermId)
+		}
+	}
+	for _, ids := range created {
+		slices.Sort(ids)
+	}
+
+	copied := make(map[termKey]int)
+	for _, term := range terms {
+		if term.result.Result == entities.TransferTermCopied {
+			copied[keyOfTerm(term.source)]++
+		}
+	}
+
+	for _, term := range terms {
+		if term.result.Result != entities.TransferTermCopied {
+			continue
+		}
+		ids := created[keyOfTerm(term.source)]
+		if len(ids) == 0 {
+			term.result.Error = "the created term is not found, the status is not restored"
+			continue
+		}
+		if len(ids) != copied[keyOfTerm(term.source)] {
+			term.result.Error = "the same term is changed in the target concurrently, the status is not restored"
+			continue
+		}
+		term.result.NewTermId = ids[0]
+		created[keyOfTerm(term.source)] = ids[1:]
+		copied[keyOfTerm(term.source)]--
+	}
+}
+
+// restoreTransferredStatuses the new terms are created as NEW, so only the other statuses are changed.
+// vocabulary_api accepts the owner only, the status is changed on behalf of the owner of the target.
+func (s *GatewayApiService) restoreTransferredStatuses(ctx context.Context, lgr zerolog.Logger, target *collectionAccess, terms []*transferredTerm) {
+	group, _ := fan_out.WithContext(ctx)
+	group.SetLimit(s.cfg.Transfer.Concurrency)
+	for _, term := range terms {
+		if term.result.NewTermId == 0 {
+			continue
+		}
+		if term.source.Status == VocabularyApiProto.StatusType_STATUS_TYPE_NEW {
+			term.result.StatusRestored = true
+			continue
+		}
+
+		term := term
+		group.Go(term.source.Term, func(ctx context.Context) error {
+			_, err := s.vocabularyApiClient.ChangeTermStatus(ctx, &VocabularyApiProto.ChangeTermStatusRequest{
+				UserId:       target.UserId,
+				CollectionId: target.CollectionId,
+				TermId:       term.result.NewTermId,
+				Status:       term.source.Status,
+			})
+			if err != nil {
+				lgr.Error().Err(err).Uint64("term_id", term.result.NewTermId).Msg(_errors.FailedToChangeTermStatusMsg)
+				term.result.Error = "status is not restored: " + status.Convert(err).Message()
+				return nil
+			}
+			term.result.StatusRestored = true
+			return nil
+		})
+	}
+	// the branches never fail, the errors are kept in the results
+	_ = group.Wait()
+}
+
+// keepTransferredReviews stores RepeatedAt of the sources of the terms with the restored status.
+// The status is taken as not restored when the store fails, so move keeps the terms in the source.
+func (s *GatewayApiService) keepTransferredReviews(ctx context.Context, lgr zerolog.Logger, collectionId uint64, terms []*transferredTerm) {
+	// the copies are repeated by the create and the status change before now
+	transferredAt := time.Now()
+
+	var kept []*transferredTerm
+	overrides := make([]*review_override.Override, 0, len(terms))
+	for _, term := range terms {
+		if !term.result.StatusRestored {
+			continue
+		}
+		override := &review_override.Override{TermId: term.result.NewTermId, TransferredAt: transferredAt}
+		if term.source.RepeatedAt != nil {
+			repeatedAt := term.source.RepeatedAt.AsTime()
+			override.RepeatedAt = &repeatedAt
+		}
+		overrides = append(overrides, override)
+		kept = append(kept, term)
+	}
+
+	if err := s.reviewOverrides.Put(ctx, collectionId, overrides); err != nil {
+		lgr.Error().Err(err).Msg(_errors.FailedToTransferTermsMsg)
+		for _, term := range kept {
+			term.result.StatusRestored = false
+			term.result.Error = "review time is not restored: " + err.Error()
+		}
+	}
+}
+
+// deleteTransferredTerms the terms stay copied if the delete fails
+func (s *GatewayApiService) deleteTransferredTerms(ctx context.Context, lgr zerolog.Logger, source *collectionAccess, terms []*transferredTerm) {
+	termIds := make([]uint64, len(terms))
+	for i, term := range terms {
+		termIds[i] = term.source.TermId
+	}
+
+	// vocabulary_api accepts the owner only, the terms of the editor are deleted on behalf of the owner
+	_, err := s.vocabularyApiClient.DeleteTerms(ctx, &VocabularyApiProto.DeleteTermsRequest{
+		UserId:       source.UserId,
+		CollectionId: source.CollectionId,
+		TermIds:      termIds,
+	})
+	if err != nil {
+		lgr.Error().Err(err).Msg(_errors.FailedToDeleteTermsMsg)
+		for _, term := range terms {
+			term.result.Error = "not deleted from the source: " + status.Convert(err).Message()
+		}
+		return
+	}
+
+	for _, term := range terms {
+		term.result.Result = entities.TransferTermMoved
+	}
+	// the source may be a copy itself, its override is gone with it
+	if err = s.reviewOverrides.Delete(ctx, source.CollectionId, termIds...); err != nil {
+		lgr.Warn().Err(err).Msg("failed to delete the review overrides of the moved terms")
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/review_override"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+)
+
+func TestFindTransferredTerms(t *testing.T) {
+	before := []*VocabularyApiProto.Term{{TermId: 1, Term: "old"}}
+
+	tests := []struct {
+		name   string
+		after  []*VocabularyApiProto.Term
+		copied []string
+		want   []uint64
+	}{
+		{
+			name:   "created terms",
+			after:  append(before, &VocabularyApiProto.Term{TermId: 3, Term: "b"}, &VocabularyApiProto.Term{TermId: 2, Term: "a"}),
+			copied: []string{"a", "b"},
+			want:   []uint64{2, 3},
+		},
+		{
+			name:   "equal terms get the ids in order",
+			after:  append(before, &VocabularyApiProto.Term{TermId: 5, Term: "a"}, &VocabularyApiProto.Term{TermId: 4, Term: "a"}),
+			copied: []string{"a", "a"},
+			want:   []uint64{4, 5},
+		},
+		{
+			name:   "not found",
+			after:  before,
+			copied: []string{"a"},
+			want:   []uint64{0},
+		},
+		{
+			name:   "same term created concurrently",
+			after:  append(before, &VocabularyApiProto.Term{TermId: 2, Term: "a"}, &VocabularyApiProto.Term{TermId: 3, Term: "a"}),
+			copied: []string{"a"},
+			want:   []uint64{0},
+		},
+		{
+			name:   "same term deleted concurrently",
+			after:  append(before, &VocabularyApiProto.Term{TermId: 2, Term: "a"}),
+			copied: []string{"a", "a"},
+			want:   []uint64{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			s.vocabularyApiClient = &fakeVocabularyApiClient{latency: &latency{}, terms: tt.after}
+
+			terms := make([]*transferredTerm, len(tt.copied))
+			for i, term := range tt.copied {
+				terms[i] = &transferredTerm{
+					source: &VocabularyApiProto.Term{Term: term},
+					result: &entities.TransferTermResult{Result: entities.TransferTermCopied},
+				}
+			}
+
+			s.findTransferredTerms(context.Background(), zerolog.Nop(), 10, before, terms)
+			for i, term := range terms {
+				if term.result.NewTermId != tt.want[i] {
+					t.Fatalf("term %d: NewTermId = %d, want %d", i, term.result.NewTermId, tt.want[i])
+				}
+				if (term.result.NewTermId == 0) != (term.result.Error != "") {
+					t.Fatalf("term %d: NewTermId = %d with error %q", i, term.result.NewTermId, term.result.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestKeepTransferredReviews(t *testing.T) {
+	ctx := context.Background()
+	sourceRepeatedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	transferTime := time.Now()
+
+	s := newTestService(t)
+	terms := []*transferredTerm{
+		{
+			source: &VocabularyApiProto.Term{TermId: 1, RepeatedAt: timestamppb.New(sourceRepeatedAt)},
+			result: &entities.TransferTermResult{NewTermId: 11, StatusRestored: true},
+		},
+		{
+			source: &VocabularyApiProto.Term{TermId: 2},
+			result: &entities.TransferTermResult{NewTermId: 12, StatusRestored: true},
+		},
+		{
+			source: &VocabularyApiProto.Term{TermId: 3, RepeatedAt: timestamppb.New(sourceRepeatedAt)},
+			result: &entities.TransferTermResult{NewTermId: 13, StatusRestored: true},
+		},
+		{
+			source: &VocabularyApiProto.Term{TermId: 4, RepeatedAt: timestamppb.New(sourceRepeatedAt)},
+			result: &entities.TransferTermResult{Error: "status is not restored"},
+		},
+	}
+	s.keepTransferredReviews(ctx, zerolog.Nop(), 20, terms)
+
+	// vocabulary_api repeats the copies at the transfer, the term 13 is reviewed after it
+	reviewedAt := time.Now().Add(time.Minute)
+	vocabulary := &reviewOverrideVocabularyApiClient{
+		IVocabularyApiClient: &fakeVocabularyApiClient{latency: &latency{}, terms: []*VocabularyApiProto.Term{
+			{TermId: 11, RepeatedAt: timestamppb.New(transferTime)},
+			{TermId: 12, RepeatedAt: timestamppb.New(transferTime)},
+			{TermId: 13, RepeatedAt: timestamppb.New(reviewedAt)},
+			{TermId: 14, RepeatedAt: timestamppb.New(transferTime)},
+		}},
+		overrides: s.reviewOverrides,
+	}
+	resp, err := vocabulary.GetTerms(ctx, &VocabularyApiProto.GetTermsRequest{CollectionId: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want *time.Time
+	}{
+		{name: "source time", want: &sourceRepeatedAt},
+		{name: "source never repeated"},
+		{name: "reviewed after the transfer", want: &reviewedAt},
+		{name: "not transferred", want: &transferTime},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resp.Terms[i].RepeatedAt
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.AsTime().Equal(*tt.want)) {
+				t.Fatalf("RepeatedAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	overrides, err := s.reviewOverrides.Collection(ctx, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := overrides[13]; ok || len(overrides) != 2 {
+		t.Fatalf("overrides = %+v, want the stale one deleted", overrides)
+	}
+}
+
+func TestKeepTransferredReviewsFailed(t *testing.T) {
+	s := newTestService(t)
+	// nothing listens on the port, the store fails
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	s.reviewOverrides = review_override.New(client, "test:review_overrides:")
+
+	term := &transferredTerm{
+		source: &VocabularyApiProto.Term{TermId: 1},
+		result: &entities.TransferTermResult{NewTermId: 11, StatusRestored: true},
+	}
+	s.keepTransferredReviews(context.Background(), zerolog.Nop(), 20, []*transferredTerm{term})
+	if term.result.StatusRestored || term.result.Error == "" {
+		t.Fatalf("result = %+v, want the status not restored, so move keeps the term", term.result)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/trash"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// purgeTrash deletes the expired items from vocabulary_api periodically.
+// Every instance runs the worker, the lock in the trash lets one of them purge per PurgeInterval.
+func (s *GatewayApiService) purgeTrash() {
+	lgr := s.lgr.With().Str("worker", "trash").Logger()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.Trash.PurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.shutdownCh:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Trash.PurgeInterval)
+				s.purgeExpiredTrash(ctx, lgr)
+				cancel()
+			}
+		}
+	}()
+}
+
+func (s *GatewayApiService) purgeExpiredTrash(ctx context.Context, lgr zerolog.Logger) {
+	now := time.Now()
+	locked, err := s.trash.LockPurge(ctx, now, s.cfg.Trash.PurgeInterval)
+	if err != nil {
+		lgr.Error().Err(err).Msg(_errors.FailedToPurgeTrashMsg)
+		return
+	}
+	if !locked {
+		lgr.Debug().Msg("the trash is purged by another instance")
+		return
+	}
+
+	collections, terms, err := s.trash.Expired(ctx, now)
+	if err != nil {
+		lgr.Error().Err(err).Msg(_errors.FailedToPurgeTrashMsg)
+		return
+	}
+	result := s.purgeTrashItems(ctx, lgr, collections, terms)
+
+	lgr.Debug().
+		Int("collections", result.PurgedCollections).
+		Int("terms", result.PurgedTerms).
+		Int("failed", result.Failed).
+		Msg("expired trash is purged")
+}
+
+// purgeTrashItems makes the delete final. The failed items stay in the trash and are purged the next time,
+// the items already deleted from vocabulary_api are taken as purged.
+func (s *GatewayApiService) purgeTrashItems(ctx context.Context, lgr zerolog.Logger, collections []*trash.Collection, terms []*trash.Term) *entities.EmptyTrashResponse {
+	result := new(entities.EmptyTrashResponse)
+
+	purged := make(map[uint64]struct{}, len(collections))
+	for _, collection := range collections {
+		_, err := s.vocabularyApiClient.DeleteCollection(ctx, &VocabularyApiProto.DeleteCollectionRequest{
+			UserId:       collection.UserId,
+			CollectionId: collection.CollectionId,
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			lgr.Error().Err(err).Uint64("collection_id", collection.CollectionId).Msg(_errors.FailedToPurgeTrashMsg)
+			result.Failed++
+			continue
+		}
+
+		if err = s.trash.RemoveCollection(ctx, collection.CollectionId); err != nil {
+			lgr.Error().Err(err).Uint64("collection_id", collection.CollectionId).Msg(_errors.FailedToPurgeTrashMsg)
+			result.Failed++
+			continue
+		}
+		if err = s.collaborators.DeleteCollection(ctx, collection.CollectionId); err != nil {
+			lgr.Warn().Err(err).Msg("failed to delete the collaborators of the collection")
+		}
+		if err = s.cloneSources.Delete(ctx, collection.CollectionId); err != nil {
+			lgr.Warn().Err(err).Msg("failed to delete the source of the cloned collection")
+		}
+		if err = s.reviewOverrides.DeleteCollection(ctx, collection.CollectionId); err != nil {
+			lgr.Warn().Err(err).Msg("failed to delete the review overrides of the collection")
+		}
+		purged[collection.CollectionId] = struct{}{}
+		result.PurgedCollections++
+	}
+
+	byCollection := make(map[uint64][]*trash.Term)
+	for _, term := range terms {
+		// the terms of the purged collections are gone with it
+		if _, ok := purged[term.CollectionId]; ok {
+			continue
+		}
+		byCollection[term.CollectionId] = append(byCollection[term.CollectionId], term)
+	}
+
+	for collectionId, collectionTerms := range byCollection {
+		termIds := make([]uint64, len(collectionTerms))
+		for i, term := range collectionTerms {
+			termIds[i] = term.TermId
+		}
+
+		_, err := s.vocabularyApiClient.DeleteTerms(ctx, &VocabularyApiProto.DeleteTermsRequest{
+			UserId:       collectionTerms[0].UserId,
+			CollectionId: collectionId,
+			TermIds:      termIds,
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			lgr.Error().Err(err).Uint64("collection_id", collectionId).Msg(_errors.FailedToPurgeTrashMsg)
+			result.Failed += len(termIds)
+			continue
+		}
+
+		removed, err := s.trash.RemoveTerms(ctx, collectionId, termIds)
+		if err != nil {
+			lgr.Error().Err(err).Uint64("collection_id", collectionId).Msg(_errors.FailedToPurgeTrashMsg)
+			result.Failed += len(termIds)
+			continue
+		}
+		result.PurgedTerms += len(removed)
+	}
+
+	return result
+}
+
+func trashedCollection(collection *trash.Collection) *entities.TrashedCollection {
+	return &entities.TrashedCollection{
+		CollectionId: collection.CollectionId,
+		LanguageId:   collection.LanguageId,
+		Name:         collection.Name,
+		TotalTerms:   collection.TotalTerms,
+		DeletedBy:    collection.DeletedBy,
+		DeletedAt:    collection.DeletedAt,
+		PurgeAt:      collection.PurgeAt,
+	}
+}
+
+func trashedTerm(term *trash.Term, collectionInTrash bool) *entities.TrashedTerm {
+	return &entities.TrashedTerm{
+		TermId:            term.TermId,
+		CollectionId:      term.CollectionId,
+		Term:              term.Term,
+		Meaning:           term.Meaning,
+		DeletedBy:         term.DeletedBy,
+		DeletedAt:         term.DeletedAt,
+		PurgeAt:           term.PurgeAt,
+		CollectionInTrash: collectionInTrash,
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/trash"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errCollectionInTrash = status.Error(codes.NotFound, "collection is in the trash")
+	errTermInTrash       = status.Error(codes.NotFound, "term is in the trash")
+)
+
+// trashVocabularyApiClient hides the trashed collections and terms, vocabulary_api keeps them until the purge.
+// The service sees the trashed items as the deleted ones, the deletes pass through for the purge.
+// The trash is kept by the gateway, the other consumers of vocabulary_api see the trashed items until the purge.
+type trashVocabularyApiClient struct {
+	IVocabularyApiClient
+	trash *trash.Store
+}
+
+func (c *trashVocabularyApiClient) GetCollection(ctx context.Context, request *VocabularyApiProto.GetCollectionRequest) (*VocabularyApiProto.GetCollectionResponse, error) {
+	if err := c.checkCollection(ctx, request.CollectionId); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.IVocabularyApiClient.GetCollection(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	trashed, err := c.trash.CollectionTerms(ctx, request.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	countTrashedTerms(resp.Collection, trashed)
+	return resp, nil
+}
+
+func (c *trashVocabularyApiClient) GetCollections(ctx context.Context, request *VocabularyApiProto.GetCollectionsRequest) (*VocabularyApiProto.GetCollectionsResponse, error) {
+	resp, err := c.IVocabularyApiClient.GetCollections(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionIds := make([]uint64, len(resp.Collections))
+	for i, collection := range resp.Collections {
+		collectionIds[i] = collection.CollectionId
+	}
+	trashedCollections, err := c.trash.Collections(ctx, collectionIds)
+	if err != nil {
+		return nil, err
+	}
+	trashedTerms, err := c.trash.CollectionsTerms(ctx, collectionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]*VocabularyApiProto.Collection, 0, len(resp.Collections))
+	for _, collection := range resp.Collections {
+		if _, ok := trashedCollections[collection.CollectionId]; !ok {
+			countTrashedTerms(collection, trashedTerms[collection.CollectionId])
+			collections = append(collections, collection)
+		}
+	}
+	resp.Collections = collections
+	return resp, nil
+}
+
+func (c *trashVocabularyApiClient) GetTerms(ctx context.Context, request *VocabularyApiProto.GetTermsRequest) (*VocabularyApiProto.GetTermsResponse, error) {
+	if err := c.checkCollection(ctx, request.CollectionId); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.IVocabularyApiClient.GetTerms(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	trashed, err := c.trash.CollectionTerms(ctx, request.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	if len(trashed) == 0 {
+		return resp, nil
+	}
+	terms := make([]*VocabularyApiProto.Term, 0, len(resp.Terms))
+	for _, term := range resp.Terms {
+		if _, ok := trashed[term.TermId]; !ok {
+			terms = append(terms, term)
+		}
+	}
+	resp.Terms = terms
+	return resp, nil
+}
+
+func (c *trashVocabularyApiClient) CreateTerms(ctx context.Context, request *VocabularyApiProto.CreateTermsRequest) (*VocabularyApiProto.CreateTermsResponse, error) {
+	collectionIds := make([]uint64, len(request.Terms))
+	for i, term := range request.Terms {
+		collectionIds[i] = term.CollectionId
+	}
+	trashed, err := c.trash.Collections(ctx, collectionIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(trashed) > 0 {
+		return nil, errCollectionInTrash
+	}
+	return c.IVocabularyApiClient.CreateTerms(ctx, request)
+}
+
+func (c *trashVocabularyApiClient) UpdateTerm(ctx context.Context, request *VocabularyApiProto.UpdateTermRequest) (*VocabularyApiProto.UpdateTermResponse, error) {
+	if err := c.checkTerm(ctx, request.TermId); err != nil {
+		return nil, err
+	}
+	return c.IVocabularyApiClient.UpdateTerm(ctx, request)
+}
+
+func (c *trashVocabularyApiClient) ChangeTermStatus(ctx context.Context, request *VocabularyApiProto.ChangeTermStatusRequest) (*VocabularyApiProto.ChangeTermStatusResponse, error) {
+	if err := c.checkCollection(ctx, request.CollectionId); err != nil {
+		return nil, err
+	}
+	if err := c.checkTerm(ctx, request.TermId); err != nil {
+		return nil, err
+	}
+	return c.IVocabularyApiClient.ChangeTermStatus(ctx, request)
+}
+
+func (c *trashVocabularyApiClient) UpdateCollection(ctx context.Context, request *VocabularyApiProto.UpdateCollectionRequest) (*VocabularyApiProto.UpdateCollectionResponse, error) {
+	if err := c.checkCollection(ctx, request.CollectionId); err != nil {
+		return nil, err
+	}
+	return c.IVocabularyApiClient.UpdateCollection(ctx, request)
+}
+
+// checkCollection returns errCollectionInTrash for the trashed collection, the failed lookup is returned as is
+func (c *trashVocabularyApiClient) checkCollection(ctx context.Context, collectionId uint64) error {
+	collection, err := c.trash.Collection(ctx, collectionId)
+	if err != nil {
+		return err
+	}
+	if collection != nil {
+		return errCollectionInTrash
+	}
+	return nil
+}
+
+func (c *trashVocabularyApiClient) checkTerm(ctx context.Context, termId uint64) error {
+	term, err := c.trash.Term(ctx, termId)
+	if err != nil {
+		return err
+	}
+	if term != nil {
+		return errTermInTrash
+	}
+	return nil
+}
+
+// countTrashedTerms removes the trashed terms from the counters of the collection.
+// LearnedTerms is taken to include the learned and the known terms, the counters never go below zero.
+func countTrashedTerms(collection *VocabularyApiProto.Collection, trashed map[uint64]*trash.Term) {
+	for _, term := range trashed {
+		if collection.TotalTerms > 0 {
+			collection.TotalTerms--
+		}
+		learned := term.Status == int32(VocabularyApiProto.StatusType_STATUS_TYPE_LEARNED) ||
+			term.Status == int32(VocabularyApiProto.StatusType_STATUS_TYPE_KNOWN)
+		if learned && collection.LearnedTerms > 0 {
+			collection.LearnedTerms--
+		}
+	}
+}
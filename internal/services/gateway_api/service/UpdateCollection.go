@@ -4,9 +4,11 @@ import (
 	"context"
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	events "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/events/v1"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"strings"
 
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
@@ -27,6 +29,10 @@ func (s *GatewayApiService) UpdateCollection(ctx context.Context, request *Gatew
 		return nil, outerErr
 	}
 
+	if _, err := s.authorizeCollection(ctx, lgr, tokenClaims.UserId, request.CollectionId, accessOwn); err != nil {
+		return nil, err
+	}
+
 	req := VocabularyApiProto.UpdateCollectionRequest{
 		UserId:       tokenClaims.UserId,
 		CollectionId: request.CollectionId,
@@ -46,12 +52,19 @@ func (s *GatewayApiService) UpdateCollection(ctx context.Context, request *Gatew
 	}
 
 	_, err := s.vocabularyApiClient.UpdateCollection(ctx, &req)
+	s.invalidateCollectionAccess(request.CollectionId)
 	if err != nil {
 		outerErr := _errors.FailedToUpdateCollection
 		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
 		return nil, outerErr
 	}
 
+	s.eventPublisherClient.Publish(ctx, lgr, events.CollectionUpdatedName, &events.CollectionUpdated{
+		CollectionId: request.CollectionId,
+		UserId:       tokenClaims.UserId,
+		OccurredAt:   timestamppb.Now(),
+	})
+
 	resp := &GatewayApiProto.UpdateCollectionResponse{}
 	lgr.Debug().Msg("executed")
 	return resp, nil
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"github.com/rs/zerolog"
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	_jwt "gitlab.com/wordbyword.io/microservices/pkg/jwt"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"strings"
 
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
@@ -53,7 +57,18 @@ func (s *GatewayApiService) UpdateTerm(ctx context.Context, request *GatewayApiP
 		req.Example = &example
 	}
 
+	// the caller owns most of the edited terms, the shared collections are searched only when vocabulary_api refuses the caller
 	_, err := s.vocabularyApiClient.UpdateTerm(ctx, &req)
+	if code := status.Code(err); code == codes.NotFound || code == codes.PermissionDenied {
+		ownerId, ownerErr := s.getSharedTermOwner(ctx, lgr, tokenClaims.UserId, request.TermId)
+		if ownerErr != nil {
+			return nil, ownerErr
+		}
+		if ownerId != 0 {
+			req.UserId = ownerId
+			_, err = s.vocabularyApiClient.UpdateTerm(ctx, &req)
+		}
+	}
 	if err != nil {
 		outerErr := _errors.FailedToUpdateTerm
 		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
@@ -64,3 +79,35 @@ func (s *GatewayApiService) UpdateTerm(ctx context.Context, request *GatewayApiP
 	lgr.Debug().Msg("executed")
 	return resp, nil
 }
+
+// getSharedTermOwner returns the owner of the collection shared with the user for editing, which has the term.
+// vocabulary_api accepts the owner only and the request has no collection, so the term is searched
+// in the shared collections. 0 is returned if the term is not found there.
+func (s *GatewayApiService) getSharedTermOwner(ctx context.Context, lgr zerolog.Logger, userId uint64, termId uint64) (uint64, error) {
+	collectionIds, err := s.collaborators.Collections(ctx, userId, collaborators.RoleEditor)
+	if err != nil {
+		outerErr := _errors.FailedToCheckCollectionAccess
+		lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+		return 0, outerErr
+	}
+
+	for _, collectionId := range collectionIds {
+		term, err := s.getTerm(ctx, collectionId, termId)
+		if err != nil {
+			lgr.Warn().Err(err).Uint64("collection_id", collectionId).Msg(_errors.FailedToGetTermsMsg)
+			continue
+		}
+		if term == nil {
+			continue
+		}
+
+		collection, err := s.getCollectionAccess(ctx, collectionId)
+		if err != nil {
+			outerErr := _errors.FailedToGetCollection
+			lgr.Error().Err(err).Msg(outerErr.ErrorMessage)
+			return 0, outerErr
+		}
+		return collection.UserId, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/clone_source"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/review_override"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/saga"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/ttl_cache"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	ActionApiProto "gitlab.com/wbwapis/go-genproto/wbw/action/action_api/v1"
+	AuthApiProto "gitlab.com/wbwapis/go-genproto/wbw/auth/auth_api/v1"
+	NotificationApiProto "gitlab.com/wbwapis/go-genproto/wbw/notification/notification_api/v1"
+	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
+	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
+)
+
+// the exporter registers its metrics globally, so it is created once for all the tests
+var (
+	testPromOnce sync.Once
+	testProm     *prometheus.Exporter
+)
+
+// latency is the injected delay of the fake clients. The delays of all the calls are summed up:
+// it is the latency of the same calls made one after the other.
+type latency struct {
+	delay time.Duration
+	total atomic.Int64
+}
+
+func (l *latency) wait(ctx context.Context) error {
+	l.total.Add(int64(l.delay))
+	select {
+	case <-time.After(l.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// The fakes embed the client interfaces, the calls not needed by the tests panic
+
+type fakeUserApiClient struct {
+	IUserApiClient
+	*latency
+	// existing is signed up already with the password, CreateUser of its email fails
+	existing *UserApiProto.User
+	password string
+	users    []*UserApiProto.User // found by GetUser, the others are not found
+}
+
+func (c *fakeUserApiClient) GetUser(ctx context.Context, request *UserApiProto.GetUserRequest) (*UserApiProto.GetUserResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	for _, user := range c.users {
+		var found bool
+		switch findBy := request.FindBy.(type) {
+		case *UserApiProto.GetUserRequest_UserId:
+			found = user.UserId == findBy.UserId
+		case *UserApiProto.GetUserRequest_Email:
+			found = user.Email == findBy.Email
+		case *UserApiProto.GetUserRequest_Username:
+			found = user.Username == findBy.Username
+		}
+		if found {
+			return &UserApiProto.GetUserResponse{User: user}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "user is not found")
+}
+
+func (c *fakeUserApiClient) CreateUser(ctx context.Context, request *UserApiProto.CreateUserRequest) (*UserApiProto.CreateUserResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	if c.existing != nil && c.existing.Email == request.Email {
+		return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
+	}
+	return &UserApiProto.CreateUserResponse{
+		User: &UserApiProto.User{
+			UserId:   1,
+			Email:    request.Email,
+			Username: request.Username,
+			Settings: request.Settings,
+		},
+	}, nil
+}
+
+func (c *fakeUserApiClient) GetUserByCredentials(ctx context.Context, request *UserApiProto.GetUserByCredentialsRequest) (*UserApiProto.GetUserByCredentialsResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	loginBy, ok := request.LoginBy.(*UserApiProto.GetUserByCredentialsRequest_Email)
+	if !ok || c.existing == nil || c.existing.Email != loginBy.Email || c.password != request.Password {
+		return nil, status.Error(codes.NotFound, "user is not found")
+	}
+	return &UserApiProto.GetUserByCredentialsResponse{User: c.existing}, nil
+}
+
+type fakeAuthApiClient struct {
+	IAuthApiClient
+	*latency
+}
+
+func (c *fakeAuthApiClient) GenerateTokens(ctx context.Context, _ *AuthApiProto.GenerateTokensRequest) (*AuthApiProto.GenerateTokensResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &AuthApiProto.GenerateTokensResponse{AccessToken: "access", RefreshToken: "refresh"}, nil
+}
+
+type fakeActionApiClient struct {
+	IActionApiClient
+	*latency
+}
+
+func (c *fakeActionApiClient) CreateAction(ctx context.Context, _ *ActionApiProto.CreateActionRequest) (*ActionApiProto.CreateActionResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &ActionApiProto.CreateActionResponse{ActionUuid: "action"}, nil
+}
+
+type fakeNotificationApiClient struct {
+	INotificationApiClient
+	*latency
+	err  error // of every send
+	sent atomic.Int32
+	last atomic.Pointer[NotificationApiProto.SendEmailRequest]
+}
+
+func (c *fakeNotificationApiClient) SendEmail(ctx context.Context, _ zerolog.Logger, request *NotificationApiProto.SendEmailRequest) error {
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+	c.sent.Add(1)
+	c.last.Store(request)
+	return c.err
+}
+
+type fakeVocabularyApiClient struct {
+	IVocabularyApiClient
+	*latency
+	collection *VocabularyApiProto.Collection
+	terms      []*VocabularyApiProto.Term
+	// collectionsByUser are returned by GetCollections, the other users fail
+	collectionsByUser map[uint64][]*VocabularyApiProto.Collection
+	// createdCollectionId is returned by CreateCollection, the terms of CreateTerms are kept in created
+	createdCollectionId uint64
+	created             []*VocabularyApiProto.CreateTerms
+}
+
+func (c *fakeVocabularyApiClient) CreateCollection(ctx context.Context, _ *VocabularyApiProto.CreateCollectionRequest) (*VocabularyApiProto.CreateCollectionResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &VocabularyApiProto.CreateCollectionResponse{CollectionId: c.createdCollectionId}, nil
+}
+
+func (c *fakeVocabularyApiClient) CreateTerms(ctx context.Context, request *VocabularyApiProto.CreateTermsRequest) (*VocabularyApiProto.CreateTermsResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	c.created = append(c.created, request.Terms...)
+	return &VocabularyApiProto.CreateTermsResponse{}, nil
+}
+
+func (c *fakeVocabularyApiClient) GetCollection(ctx context.Context, _ *VocabularyApiProto.GetCollectionRequest) (*VocabularyApiProto.GetCollectionResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &VocabularyApiProto.GetCollectionResponse{Collection: c.collection}, nil
+}
+
+func (c *fakeVocabularyApiClient) GetCollections(ctx context.Context, request *VocabularyApiProto.GetCollectionsRequest) (*VocabularyApiProto.GetCollectionsResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	collections, ok := c.collectionsByUser[request.UserId]
+	if !ok {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	return &VocabularyApiProto.GetCollectionsResponse{Collections: collections}, nil
+}
+
+func (c *fakeVocabularyApiClient) GetTerms(ctx context.Context, _ *VocabularyApiProto.GetTermsRequest) (*VocabularyApiProto.GetTermsResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &VocabularyApiProto.GetTermsResponse{Terms: c.terms}, nil
+}
+
+type fakeEventPublisherClient struct {
+	IEventPublisherClient
+	mu        sync.Mutex
+	published []string // the names of the events
+}
+
+func (c *fakeEventPublisherClient) Publish(_ context.Context, _ zerolog.Logger, name string, _ proto.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.published = append(c.published, name)
+}
+
+// newTestService returns the service without the caches and the background workers,
+// the clients are set by the tests
+func newTestService(tb testing.TB) *GatewayApiService {
+	testPromOnce.Do(func() {
+		testProm = prometheus.NewExporter("gateway_api_test")
+	})
+
+	redisClient := newTestRedisClient(tb)
+
+	return &GatewayApiService{
+		cfg:                  &config.Config{},
+		lgr:                  zerolog.Nop(),
+		prom:                 testProm,
+		eventPublisherClient: &fakeEventPublisherClient{},
+		collectionsCache:     ttl_cache.NewCache[*collectionAccess](time.Minute, 0, time.Second),
+		sagaRetrier: saga.NewRetrier(saga.RetrierConfig{
+			MaxPending:  10,
+			MaxAttempts: 1,
+			StepTimeout: time.Second,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  time.Millisecond,
+		}, zerolog.Nop()),
+		collaborators: collaborators.New(redisClient, "test:collaborators:"),
+		cloneSources:  clone_source.New(redisClient, "test:clone_sources:"),
+		shutdownCh:    make(chan struct{}),
+
+		reviewOverrides: review_override.New(redisClient, "test:review_overrides:"),
+	}
+}
+
+// newTestRedisClient returns the client of the in-memory Redis, which is stopped after the test
+func newTestRedisClient(tb testing.TB) redis.UniversalClient {
+	server := miniredis.RunT(tb)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	tb.Cleanup(func() { _ = client.Close() })
+	return client
+}
@@ -13,6 +13,7 @@ import (
 	UserApiProto "gitlab.com/wbwapis/go-genproto/wbw/user/user_api/v1"
 	VocabularyApiProto "gitlab.com/wbwapis/go-genproto/wbw/vocabulary/vocabulary_api/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	"google.golang.org/protobuf/proto"
 )
 
 // -----------------------
@@ -39,9 +40,45 @@ type IGatewayApiService interface {
 	UpdateTerm(context.Context, *GatewayApiProto.UpdateTermRequest) (*GatewayApiProto.UpdateTermResponse, error)
 	DeleteTerms(context.Context, *GatewayApiProto.DeleteTermsRequest) (*GatewayApiProto.DeleteTermsResponse, error)
 	ChangeTermStatus(context.Context, *GatewayApiProto.ChangeTermStatusRequest) (*GatewayApiProto.ChangeTermStatusResponse, error)
+	ChangeTermStatuses(context.Context, *entities.ChangeTermStatusesRequest) (*entities.ChangeTermStatusesResponse, error)
+	ExtractTerms(context.Context, *entities.ExtractTermsRequest) (*entities.ExtractTermsResponse, error)
+	ExtractSubtitleTerms(context.Context, *entities.ExtractSubtitleTermsRequest) (*entities.ExtractSubtitleTermsResponse, error)
+	AcceptSubtitleTerms(context.Context, *entities.AcceptSubtitleTermsRequest) (*entities.AcceptSubtitleTermsResponse, error)
+	ListTrash(context.Context, *entities.ListTrashRequest) (*entities.ListTrashResponse, error)
+	RestoreCollection(context.Context, *entities.RestoreCollectionRequest) (*entities.RestoreCollectionResponse, error)
+	RestoreTerms(context.Context, *entities.RestoreTermsRequest) (*entities.RestoreTermsResponse, error)
+	EmptyTrash(context.Context, *entities.EmptyTrashRequest) (*entities.EmptyTrashResponse, error)
 	GetLanguages(context.Context, *GatewayApiProto.GetLanguagesRequest) (*GatewayApiProto.GetLanguagesResponse, error)
 	GetVoiceover(context.Context, *GatewayApiProto.GetVoiceoverRequest) (*GatewayApiProto.GetVoiceoverResponse, error)
 	GetTranslation(context.Context, *GatewayApiProto.GetTranslationRequest) (*GatewayApiProto.GetTranslationResponse, error)
+	GetDueTerms(context.Context, *entities.GetDueTermsRequest) (*entities.GetDueTermsResponse, error)
+	GetLearningStats(context.Context, *entities.GetLearningStatsRequest) (*entities.GetLearningStatsResponse, error)
+	FindDuplicates(context.Context, *entities.FindDuplicatesRequest) (*entities.FindDuplicatesResponse, error)
+	SubmitReview(context.Context, *entities.SubmitReviewRequest) (*entities.SubmitReviewResponse, error)
+	GenerateQuiz(context.Context, *entities.GenerateQuizRequest) (*entities.GenerateQuizResponse, error)
+	ImportTerms(context.Context, *entities.ImportTermsRequest) (*entities.ImportTermsResponse, error)
+	ExportCollection(context.Context, *entities.ExportCollectionRequest) (*entities.ExportCollectionResponse, error)
+	CreateTermsAutoFill(context.Context, *GatewayApiProto.CreateTermsRequest) (*entities.CreateTermsAutoFillResponse, error)
+	CreateTermsDeduplicated(context.Context, *GatewayApiProto.CreateTermsRequest, *entities.CreateTermsOptions) (*entities.CreateTermsDeduplicatedResponse, error)
+	CloneCollection(context.Context, *entities.CloneCollectionRequest) (*entities.CloneCollectionResponse, error)
+	GetCollectionSource(context.Context, *entities.GetCollectionSourceRequest) (*entities.GetCollectionSourceResponse, error)
+	SearchCollections(context.Context, *entities.SearchCollectionsRequest) (*entities.SearchCollectionsResponse, error)
+	InviteCollaborator(context.Context, *entities.InviteCollaboratorRequest) (*entities.InviteCollaboratorResponse, error)
+	AcceptInvite(context.Context, *entities.AcceptInviteRequest) (*entities.AcceptInviteResponse, error)
+	RevokeInvite(context.Context, *entities.RevokeInviteRequest) (*entities.RevokeInviteResponse, error)
+	GetCollaborators(context.Context, *entities.GetCollaboratorsRequest) (*entities.GetCollaboratorsResponse, error)
+	GetInvites(context.Context, *entities.GetInvitesRequest) (*entities.GetInvitesResponse, error)
+	RemoveCollaborator(context.Context, *entities.RemoveCollaboratorRequest) (*entities.RemoveCollaboratorResponse, error)
+	CreateShareLink(context.Context, *entities.CreateShareLinkRequest) (*entities.CreateShareLinkResponse, error)
+	RevokeShareLink(context.Context, *entities.RevokeShareLinkRequest) (*entities.RevokeShareLinkResponse, error)
+	MoveTerms(context.Context, *entities.TransferTermsRequest) (*entities.TransferTermsResponse, error)
+	CopyTerms(context.Context, *entities.TransferTermsRequest) (*entities.TransferTermsResponse, error)
+	GetSharedCollection(context.Context, *entities.SharedCollectionRequest) (*GatewayApiProto.GetCollectionResponse, error)
+	GetSharedTerms(context.Context, *entities.SharedCollectionRequest) (*GatewayApiProto.GetTermsResponse, error)
+
+	// Admin:
+	InvalidateCache(context.Context, *entities.InvalidateCacheRequest) (*entities.InvalidateCacheResponse, error)
+	BackfillCatalog(context.Context, *entities.BackfillCatalogRequest) (*entities.BackfillCatalogResponse, error)
 }
 
 // -----------------------
@@ -80,6 +117,16 @@ type INotificationApiClient interface {
 	Shutdown()
 }
 
+type IEventPublisherClient interface {
+	Publish(ctx context.Context, lgr zerolog.Logger, name string, event proto.Message)
+	Shutdown()
+}
+
+type IEventConsumerClient interface {
+	Consume(routingKeys []string, handler func(ctx context.Context, routingKey string, body []byte) error) error
+	Shutdown()
+}
+
 type ILanguageApiClient interface {
 	GetLanguages(context.Context, *LanguageApiProto.GetLanguagesRequest) (*LanguageApiProto.GetLanguagesResponse, error)
 	Shutdown()
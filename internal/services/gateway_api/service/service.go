@@ -1,14 +1,41 @@
 package service
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
+	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/catalog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/clone_source"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/collaborators"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/lru_cache"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/review_override"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/saga"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/share_link"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/spaced_repetition"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/trash"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/ttl_cache"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	"gitlab.com/wordbyword.io/microservices/pkg/sequence_gen"
+	"golang.org/x/sync/singleflight"
 )
 
 const serviceName = "gateway-api"
 
+// Names of the caches, which can be invalidated through the admin api
+const (
+	languagesCacheName    = "languages"
+	translationsCacheName = "translations"
+	collectionsCacheName  = "collections"
+	statsCacheName        = "stats"
+)
+
 type GatewayApiService struct {
 	cfg       *config.Config
 	lgr       zerolog.Logger
@@ -25,6 +52,29 @@ type GatewayApiService struct {
 	languageApiClient     ILanguageApiClient
 	translationApiClient  ITranslationApiClient
 	googleAuthApiClient   IGoogleAuthClient
+	eventPublisherClient  IEventPublisherClient
+	eventConsumerClient   IEventConsumerClient
+
+	//list of caches:
+	languagesCache     *ttl_cache.Cache[*GatewayApiProto.GetLanguagesResponse]
+	translationsCache  *lru_cache.Cache[[]string]
+	translationsLoader singleflight.Group
+	collectionsCache   *ttl_cache.Cache[*collectionAccess]
+	statsCache         *ttl_cache.Cache[*entities.GetLearningStatsResponse]
+
+	cloneSources  *clone_source.Store
+	catalog       *catalog.Store
+	collaborators *collaborators.Store
+	trash         *trash.Store
+	shareLinks    *share_link.Store
+	// reviewOverrides are read by vocabularyApiClient, the service writes them
+	reviewOverrides *review_override.Store
+
+	sagaRetrier *saga.Retrier
+	scheduler   spaced_repetition.Scheduler
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
 }
 
 var _ IGatewayApiService = (*GatewayApiService)(nil)
@@ -43,10 +93,32 @@ func NewGatewayApiService(
 	languageApiClient ILanguageApiClient,
 	translationApiClient ITranslationApiClient,
 	googleAuthApiClient IGoogleAuthClient,
+	eventPublisherClient IEventPublisherClient,
+	eventConsumerClient IEventConsumerClient,
+	redisClient redis.UniversalClient,
 ) *GatewayApiService {
 	lgr = lgr.With().Str("service", serviceName).Logger()
 
-	return &GatewayApiService{
+	scheduler, err := spaced_repetition.NewScheduler(
+		cfg.Review.Algorithm,
+		len(reviewStatuses),
+		cfg.Review.LeitnerIntervals,
+		cfg.Review.SM2Ease,
+	)
+	if err != nil {
+		lgr.Fatal().Err(err).Msg("failed to init the review scheduler")
+	}
+
+	// the trashed items are hidden from the whole service, the workers started below see them too
+	trashStore := trash.New(redisClient, cfg.Redis.KeyPrefix+"trash:")
+	// the transferred terms keep RepeatedAt of their source
+	reviewOverrides := review_override.New(redisClient, cfg.Redis.KeyPrefix+"review_overrides:")
+	vocabulary := &trashVocabularyApiClient{
+		IVocabularyApiClient: &reviewOverrideVocabularyApiClient{IVocabularyApiClient: vocabularyApiClient, overrides: reviewOverrides},
+		trash:                trashStore,
+	}
+
+	s := &GatewayApiService{
 		cfg:       cfg,
 		lgr:       lgr,
 		snowflake: sequence_gen.NewSnowflake(nil),
@@ -57,14 +129,183 @@ func NewGatewayApiService(
 		authApiClient:         authApiClient,
 		userApiClient:         userApiClient,
 		actionApiClient:       actionApiClient,
-		vocabularyApiClient:   vocabularyApiClient,
+		vocabularyApiClient:   vocabulary,
 		speakerApiClient:      speakerApiClient,
 		languageApiClient:     languageApiClient,
 		translationApiClient:  translationApiClient,
 		googleAuthApiClient:   googleAuthApiClient,
+		eventPublisherClient:  eventPublisherClient,
+		eventConsumerClient:   eventConsumerClient,
+
+		//list of caches:
+		languagesCache: ttl_cache.NewCache[*GatewayApiProto.GetLanguagesResponse](
+			cfg.Cache.Languages.TTL,
+			cfg.Cache.Languages.MaxStale,
+			cfg.Cache.Languages.LoadTimeout,
+		),
+		translationsCache: lru_cache.NewCache[[]string](cfg.Cache.Translations.Size),
+		collectionsCache: ttl_cache.NewCache[*collectionAccess](
+			cfg.Cache.Collections.TTL,
+			0,
+			cfg.Cache.Collections.LoadTimeout,
+		),
+		statsCache: ttl_cache.NewCache[*entities.GetLearningStatsResponse](
+			cfg.Cache.Stats.TTL,
+			0,
+			cfg.Cache.Stats.LoadTimeout,
+		),
+
+		cloneSources:  clone_source.New(redisClient, cfg.Redis.KeyPrefix+"clone_sources:"),
+		catalog:       catalog.New(redisClient, cfg.Redis.KeyPrefix+"catalog:"),
+		collaborators: collaborators.New(redisClient, cfg.Redis.KeyPrefix+"collaborators:"),
+		shareLinks:    share_link.New(redisClient, cfg.Redis.KeyPrefix+"share_links:"),
+		trash:         trashStore,
+
+		reviewOverrides: reviewOverrides,
+
+		sagaRetrier: saga.NewRetrier(saga.RetrierConfig{
+			MaxPending:  cfg.Saga.MaxPending,
+			MaxAttempts: cfg.Saga.MaxAttempts,
+			StepTimeout: cfg.Saga.StepTimeout,
+			MinBackoff:  cfg.Saga.MinBackoff,
+			MaxBackoff:  cfg.Saga.MaxBackoff,
+		}, lgr),
+		scheduler: scheduler,
+
+		shutdownCh: make(chan struct{}),
+	}
+
+	if cfg.Cache.Translations.Enabled && cfg.Cache.Translations.FilePath != "" {
+		s.restoreTranslationsCache()
 	}
+	if cfg.Cache.Collections.Enabled {
+		s.cleanupCollectionsCache()
+	}
+	if cfg.Cache.Stats.Enabled {
+		s.cleanupStatsCache()
+	}
+	if cfg.Catalog.Enabled {
+		s.startCatalog()
+	}
+	s.consumeCollectionEvents()
+
+	s.purgeTrash()
+
+	return s
+}
+
+type cacheInvalidator struct {
+	all func()
+	key func(key string)
+}
+
+// detachContext copies the request values to a new context, which may be used after the request is finished.
+// The request context can not be used for that: gin reuses it for the next requests.
+func detachContext(ctx context.Context) context.Context {
+	detached := context.Background()
+	for _, key := range []string{
+		constants.RequestIdKey,
+		constants.AcceptLanguageKey,
+		constants.ClientIPKey,
+		constants.DeviceKey,
+	} {
+		if value := ctx.Value(key); value != nil {
+			detached = context.WithValue(detached, key, value)
+		}
+	}
+	return detached
+}
+
+// caches returns invalidation functions of all the caches by their names
+func (s *GatewayApiService) caches() map[string]cacheInvalidator {
+	return map[string]cacheInvalidator{
+		languagesCacheName:    {all: s.languagesCache.InvalidateAll, key: s.languagesCache.Invalidate},
+		translationsCacheName: {all: s.translationsCache.Purge, key: s.translationsCache.Remove},
+		collectionsCacheName:  {all: s.collectionsCache.InvalidateAll, key: s.collectionsCache.Invalidate},
+		statsCacheName:        {all: s.statsCache.InvalidateAll, key: s.statsCache.Invalidate},
+	}
+}
+
+// cleanupCollectionsCache removes the expired collections periodically
+func (s *GatewayApiService) cleanupCollectionsCache() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.Cache.Collections.CleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.shutdownCh:
+				return
+			case <-ticker.C:
+				s.collectionsCache.DeleteExpired()
+			}
+		}
+	}()
+}
+
+// cleanupStatsCache removes the expired stats periodically
+func (s *GatewayApiService) cleanupStatsCache() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.Cache.Stats.CleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.shutdownCh:
+				return
+			case <-ticker.C:
+				s.statsCache.DeleteExpired()
+			}
+		}
+	}()
+}
+
+// restoreTranslationsCache loads the translations saved before the restart and saves them periodically
+func (s *GatewayApiService) restoreTranslationsCache() {
+	cacheCfg := s.cfg.Cache.Translations
+	lgr := s.lgr.With().Str("cache", translationsCacheName).Str("path", cacheCfg.FilePath).Logger()
+
+	if err := s.translationsCache.Load(cacheCfg.FilePath); err != nil {
+		lgr.Warn().Err(err).Msg("failed to load the cache from the disk")
+	} else {
+		lgr.Info().Int("size", s.translationsCache.Len()).Msg("cache is loaded from the disk")
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(cacheCfg.SaveInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.shutdownCh:
+				return
+			case <-ticker.C:
+				if err := s.translationsCache.Save(cacheCfg.FilePath); err != nil {
+					lgr.Error().Err(err).Msg("failed to save the cache to the disk")
+				}
+			}
+		}
+	}()
 }
 
 func (s *GatewayApiService) Shutdown() {
+	s.sagaRetrier.Stop()
+	close(s.shutdownCh)
+	s.wg.Wait()
 
+	cacheCfg := s.cfg.Cache.Translations
+	if cacheCfg.Enabled && cacheCfg.FilePath != "" {
+		if err := s.translationsCache.Save(cacheCfg.FilePath); err != nil {
+			s.lgr.Error().Err(err).Str("cache", translationsCacheName).Msg("failed to save the cache to the disk")
+		}
+	}
 }
@@ -7,7 +7,9 @@ import (
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/constants"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/rate_limiter"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/middleware/admin"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/middleware/auth"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/middleware/tracing"
 	"gitlab.com/wordbyword.io/microservices/pkg/middleware/extractor"
 
 	AuthApiClient "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/clients/auth_api"
@@ -56,28 +58,60 @@ func NewHttpEndpoints(
 		lgr:  lgr,
 		prom: prom,
 		rateLimiter: rate_limiter.NewRateLimiter(map[string]int{
-			constants.SignUp:           cfg.RateLimiter.SignUp,
-			constants.SignIn:           cfg.RateLimiter.SignIn,
-			constants.RefreshTokens:    cfg.RateLimiter.RefreshTokens,
-			constants.ConfirmEmail:     cfg.RateLimiter.ConfirmEmail,
-			constants.AskResetPassword: cfg.RateLimiter.AskResetPassword,
-			constants.ResetPassword:    cfg.RateLimiter.ResetPassword,
-			constants.GetLanguages:     cfg.RateLimiter.GetLanguages,
-			constants.Logout:           cfg.RateLimiter.Logout,
-			constants.GetUser:          cfg.RateLimiter.GetUser,
-			constants.UpdateUser:       cfg.RateLimiter.UpdateUser,
-			constants.CreateCollection: cfg.RateLimiter.CreateCollection,
-			constants.UpdateCollection: cfg.RateLimiter.UpdateCollection,
-			constants.GetCollections:   cfg.RateLimiter.GetCollections,
-			constants.GetCollection:    cfg.RateLimiter.GetCollection,
-			constants.DeleteCollection: cfg.RateLimiter.DeleteCollection,
-			constants.CreateTerms:      cfg.RateLimiter.CreateTerms,
-			constants.UpdateTerm:       cfg.RateLimiter.UpdateTerm,
-			constants.GetTerms:         cfg.RateLimiter.GetTerms,
-			constants.ChangeTermStatus: cfg.RateLimiter.ChangeTermStatus,
-			constants.DeleteTerms:      cfg.RateLimiter.DeleteTerms,
-			constants.GetVoiceover:     cfg.RateLimiter.GetVoiceover,
-			constants.GetTranslation:   cfg.RateLimiter.GetTranslation,
+			constants.SignUp:               cfg.RateLimiter.SignUp,
+			constants.SignIn:               cfg.RateLimiter.SignIn,
+			constants.RefreshTokens:        cfg.RateLimiter.RefreshTokens,
+			constants.ConfirmEmail:         cfg.RateLimiter.ConfirmEmail,
+			constants.AskResetPassword:     cfg.RateLimiter.AskResetPassword,
+			constants.ResetPassword:        cfg.RateLimiter.ResetPassword,
+			constants.GetLanguages:         cfg.RateLimiter.GetLanguages,
+			constants.Logout:               cfg.RateLimiter.Logout,
+			constants.GetUser:              cfg.RateLimiter.GetUser,
+			constants.UpdateUser:           cfg.RateLimiter.UpdateUser,
+			constants.CreateCollection:     cfg.RateLimiter.CreateCollection,
+			constants.UpdateCollection:     cfg.RateLimiter.UpdateCollection,
+			constants.GetCollections:       cfg.RateLimiter.GetCollections,
+			constants.GetCollection:        cfg.RateLimiter.GetCollection,
+			constants.DeleteCollection:     cfg.RateLimiter.DeleteCollection,
+			constants.CreateTerms:          cfg.RateLimiter.CreateTerms,
+			constants.UpdateTerm:           cfg.RateLimiter.UpdateTerm,
+			constants.GetTerms:             cfg.RateLimiter.GetTerms,
+			constants.ChangeTermStatus:     cfg.RateLimiter.ChangeTermStatus,
+			constants.DeleteTerms:          cfg.RateLimiter.DeleteTerms,
+			constants.GetVoiceover:         cfg.RateLimiter.GetVoiceover,
+			constants.GetTranslation:       cfg.RateLimiter.GetTranslation,
+			constants.GetDueTerms:          cfg.RateLimiter.GetDueTerms,
+			constants.SubmitReview:         cfg.RateLimiter.SubmitReview,
+			constants.GenerateQuiz:         cfg.RateLimiter.GenerateQuiz,
+			constants.ImportTerms:          cfg.RateLimiter.ImportTerms,
+			constants.ExportCollection:     cfg.RateLimiter.ExportCollection,
+			constants.CloneCollection:      cfg.RateLimiter.CloneCollection,
+			constants.GetCollectionSource:  cfg.RateLimiter.GetCollectionSource,
+			constants.SearchCollections:    cfg.RateLimiter.SearchCollections,
+			constants.InviteCollaborator:   cfg.RateLimiter.InviteCollaborator,
+			constants.RevokeInvite:         cfg.RateLimiter.RevokeInvite,
+			constants.RemoveCollaborator:   cfg.RateLimiter.RemoveCollaborator,
+			constants.AcceptInvite:         cfg.RateLimiter.AcceptInvite,
+			constants.GetCollaborators:     cfg.RateLimiter.GetCollaborators,
+			constants.GetInvites:           cfg.RateLimiter.GetInvites,
+			constants.CreateShareLink:      cfg.RateLimiter.CreateShareLink,
+			constants.RevokeShareLink:      cfg.RateLimiter.RevokeShareLink,
+			constants.GetSharedCollection:  cfg.RateLimiter.GetSharedCollection,
+			constants.GetSharedTerms:       cfg.RateLimiter.GetSharedTerms,
+			constants.MoveTerms:            cfg.RateLimiter.MoveTerms,
+			constants.CopyTerms:            cfg.RateLimiter.CopyTerms,
+			constants.FindDuplicates:       cfg.RateLimiter.FindDuplicates,
+			constants.GetLearningStats:     cfg.RateLimiter.GetLearningStats,
+			constants.ChangeTermStatuses:   cfg.RateLimiter.ChangeTermStatuses,
+			constants.ListTrash:            cfg.RateLimiter.ListTrash,
+			constants.RestoreCollection:    cfg.RateLimiter.RestoreCollection,
+			constants.RestoreTerms:         cfg.RateLimiter.RestoreTerms,
+			constants.EmptyTrash:           cfg.RateLimiter.EmptyTrash,
+			constants.ExtractTerms:         cfg.RateLimiter.ExtractTerms,
+			constants.ExtractSubtitleTerms: cfg.RateLimiter.ExtractSubtitleTerms,
+			constants.AcceptSubtitleTerms:  cfg.RateLimiter.AcceptSubtitleTerms,
+			constants.InvalidateCache:      cfg.RateLimiter.InvalidateCache,
+			constants.BackfillCatalog:      cfg.RateLimiter.BackfillCatalog,
 		}),
 
 		//list of endpoints:
@@ -103,6 +137,7 @@ func (ep *HttpEndpoints) routeRegister(rg *gin.RouterGroup, prefixOptions ...str
 	r.Use(extractor.ExtractRequestId())
 	r.Use(extractor.ExtractClientIP())
 	r.Use(extractor.ExtractAcceptLanguage())
+	r.Use(tracing.ExtractTraceContext())
 	r.Use(rate_limiter.HttpMiddleware(ep.cfg, ep.lgr, ep.prom, ep.rateLimiter))
 
 	r.POST(constants.SignUp, ep.gatewayApiHttpEndpoint.SignUp)
@@ -113,6 +148,9 @@ func (ep *HttpEndpoints) routeRegister(rg *gin.RouterGroup, prefixOptions ...str
 	r.POST(constants.ResetPassword, ep.gatewayApiHttpEndpoint.ResetPassword)
 	r.POST(constants.GetLanguages, ep.gatewayApiHttpEndpoint.GetLanguages)
 	r.POST(constants.GetVoiceover, ep.gatewayApiHttpEndpoint.GetVoiceover)
+	r.POST(constants.SearchCollections, ep.gatewayApiHttpEndpoint.SearchCollections)
+	r.POST(constants.GetSharedCollection, ep.gatewayApiHttpEndpoint.GetSharedCollection)
+	r.POST(constants.GetSharedTerms, ep.gatewayApiHttpEndpoint.GetSharedTerms)
 
 	// With auth:
 	authorized := r.Group("", auth.Auth(ep.cfg, ep.lgr, ep.prom))
@@ -124,12 +162,44 @@ func (ep *HttpEndpoints) routeRegister(rg *gin.RouterGroup, prefixOptions ...str
 	authorized.POST(constants.GetCollection, ep.gatewayApiHttpEndpoint.GetCollection)
 	authorized.POST(constants.GetTerms, ep.gatewayApiHttpEndpoint.GetTerms)
 	authorized.POST(constants.GetTranslation, ep.gatewayApiHttpEndpoint.GetTranslation)
-
-	// Only owner:
+	authorized.POST(constants.GetDueTerms, ep.gatewayApiHttpEndpoint.GetDueTerms)
+	authorized.POST(constants.GenerateQuiz, ep.gatewayApiHttpEndpoint.GenerateQuiz)
+	authorized.POST(constants.ExportCollection, ep.gatewayApiHttpEndpoint.ExportCollection)
+	authorized.POST(constants.CloneCollection, ep.gatewayApiHttpEndpoint.CloneCollection)
+	authorized.POST(constants.GetCollectionSource, ep.gatewayApiHttpEndpoint.GetCollectionSource)
+	authorized.POST(constants.AcceptInvite, ep.gatewayApiHttpEndpoint.AcceptInvite)
+	authorized.POST(constants.GetCollaborators, ep.gatewayApiHttpEndpoint.GetCollaborators)
+	authorized.POST(constants.GetInvites, ep.gatewayApiHttpEndpoint.GetInvites)
+	authorized.POST(constants.FindDuplicates, ep.gatewayApiHttpEndpoint.FindDuplicates)
+	authorized.POST(constants.GetLearningStats, ep.gatewayApiHttpEndpoint.GetLearningStats)
+	authorized.POST(constants.ListTrash, ep.gatewayApiHttpEndpoint.ListTrash)
+	authorized.POST(constants.EmptyTrash, ep.gatewayApiHttpEndpoint.EmptyTrash)
+	authorized.POST(constants.ExtractTerms, ep.gatewayApiHttpEndpoint.ExtractTerms)
+	authorized.POST(constants.ExtractSubtitleTerms, ep.gatewayApiHttpEndpoint.ExtractSubtitleTerms)
+
+	// Only owner or editor:
 	authorized.POST(constants.UpdateCollection, ep.gatewayApiHttpEndpoint.UpdateCollection)
 	authorized.POST(constants.DeleteCollection, ep.gatewayApiHttpEndpoint.DeleteCollection)
 	authorized.POST(constants.CreateTerms, ep.gatewayApiHttpEndpoint.CreateTerms)
 	authorized.POST(constants.DeleteTerms, ep.gatewayApiHttpEndpoint.DeleteTerms)
 	authorized.POST(constants.UpdateTerm, ep.gatewayApiHttpEndpoint.UpdateTerm)
 	authorized.POST(constants.ChangeTermStatus, ep.gatewayApiHttpEndpoint.ChangeTermStatus)
+	authorized.POST(constants.SubmitReview, ep.gatewayApiHttpEndpoint.SubmitReview)
+	authorized.POST(constants.ImportTerms, ep.gatewayApiHttpEndpoint.ImportTerms)
+	authorized.POST(constants.InviteCollaborator, ep.gatewayApiHttpEndpoint.InviteCollaborator)
+	authorized.POST(constants.RevokeInvite, ep.gatewayApiHttpEndpoint.RevokeInvite)
+	authorized.POST(constants.RemoveCollaborator, ep.gatewayApiHttpEndpoint.RemoveCollaborator)
+	authorized.POST(constants.CreateShareLink, ep.gatewayApiHttpEndpoint.CreateShareLink)
+	authorized.POST(constants.RevokeShareLink, ep.gatewayApiHttpEndpoint.RevokeShareLink)
+	authorized.POST(constants.MoveTerms, ep.gatewayApiHttpEndpoint.MoveTerms)
+	authorized.POST(constants.CopyTerms, ep.gatewayApiHttpEndpoint.CopyTerms)
+	authorized.POST(constants.ChangeTermStatuses, ep.gatewayApiHttpEndpoint.ChangeTermStatuses)
+	authorized.POST(constants.RestoreCollection, ep.gatewayApiHttpEndpoint.RestoreCollection)
+	authorized.POST(constants.RestoreTerms, ep.gatewayApiHttpEndpoint.RestoreTerms)
+	authorized.POST(constants.AcceptSubtitleTerms, ep.gatewayApiHttpEndpoint.AcceptSubtitleTerms)
+
+	// Only admin:
+	administrative := r.Group("", admin.Admin(ep.cfg, ep.lgr, ep.prom))
+	administrative.POST(constants.InvalidateCache, ep.gatewayApiHttpEndpoint.InvalidateCache)
+	administrative.POST(constants.BackfillCatalog, ep.gatewayApiHttpEndpoint.BackfillCatalog)
 }
@@ -1,14 +1,18 @@
 package gateway_api
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	GatewayApiProto "gitlab.com/wbwapis/go-genproto/wbw/gateway/gateway_api/v1"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/entities"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
 	"gitlab.com/wordbyword.io/microservices/pkg/constants"
 	"gitlab.com/wordbyword.io/microservices/pkg/errors/outer"
 	"gitlab.com/wordbyword.io/microservices/pkg/utils"
 	"google.golang.org/protobuf/encoding/protojson"
 	"io"
+	"mime"
 	"net/http"
 	"strconv"
 	"time"
@@ -1023,6 +1027,72 @@ func (e *GatewayApiHttpEndpoint) CreateTerms(c *gin.Context) {
 		return
 	}
 
+	// on_duplicate=allow|skip|merge compares the terms with the own collections, the response reports every term.
+	// ignore_diacritics=true and stemming=true loosen the comparison, auto_fill works with it as well
+	autoFill, _ := strconv.ParseBool(c.Query("auto_fill"))
+	if onDuplicate := c.Query("on_duplicate"); onDuplicate != "" {
+		options := &entities.CreateTermsOptions{
+			OnDuplicate: onDuplicate,
+			AutoFill:    autoFill,
+		}
+		options.IgnoreDiacritics, _ = strconv.ParseBool(c.Query("ignore_diacritics"))
+		options.Stemming, _ = strconv.ParseBool(c.Query("stemming"))
+		if err = options.Validate(); err != nil {
+			lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+			code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+			e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+			c.PureJSON(code, obj)
+			return
+		}
+
+		deduplicatedResp, err := e.gatewayApiService.CreateTermsDeduplicated(c, req, options)
+		if err != nil {
+			lgr.Error().Err(err).Msg("failed")
+			code, obj := outer.GetHTTPError(err)
+			e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+			c.PureJSON(code, obj)
+			return
+		}
+
+		lgr.Debug().Interface("response", deduplicatedResp).Msg("executed")
+
+		buf, err := json.Marshal(deduplicatedResp)
+		if err != nil {
+			lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+			code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+			e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+			c.PureJSON(code, obj)
+			return
+		}
+		c.Data(http.StatusOK, "application/json", buf)
+		return
+	}
+
+	// auto_fill=true fills the empty meanings and loads the voiceovers, the response reports every term
+	if autoFill {
+		autoFillResp, err := e.gatewayApiService.CreateTermsAutoFill(c, req)
+		if err != nil {
+			lgr.Error().Err(err).Msg("failed")
+			code, obj := outer.GetHTTPError(err)
+			e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+			c.PureJSON(code, obj)
+			return
+		}
+
+		lgr.Debug().Interface("response", autoFillResp).Msg("executed")
+
+		buf, err := json.Marshal(autoFillResp)
+		if err != nil {
+			lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+			code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+			e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+			c.PureJSON(code, obj)
+			return
+		}
+		c.Data(http.StatusOK, "application/json", buf)
+		return
+	}
+
 	resp, err := e.gatewayApiService.CreateTerms(c, req)
 	if err != nil {
 		lgr.Error().Err(err).Msg("failed")
@@ -1531,3 +1601,2146 @@ func (e *GatewayApiHttpEndpoint) GetTranslation(c *gin.Context) {
 	}
 	c.Data(http.StatusOK, "application/json", buf)
 }
+
+func (e *GatewayApiHttpEndpoint) InvalidateCache(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("InvalidateCache").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "InvalidateCache").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.InvalidateCacheRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.InvalidateCache(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) BackfillCatalog(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("BackfillCatalog").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "BackfillCatalog").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.BackfillCatalogRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.BackfillCatalog(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GetDueTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GetDueTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GetDueTerms").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.GetDueTermsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GetDueTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) SubmitReview(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("SubmitReview").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "SubmitReview").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.SubmitReviewRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.SubmitReview(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GenerateQuiz(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GenerateQuiz").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GenerateQuiz").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.GenerateQuizRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GenerateQuiz(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) ImportTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("ImportTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "ImportTerms").Logger()
+
+	req, err := readImportTermsRequest(c)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.ImportTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+// readImportTermsRequest reads the multipart form of ImportTerms
+func readImportTermsRequest(c *gin.Context) (*entities.ImportTermsRequest, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entities.ImportTermsRequest{
+		Format:   c.PostForm("format"),
+		Encoding: c.PostForm("encoding"),
+		FileName: fileHeader.Filename,
+		File:     data,
+	}
+	for field, value := range map[string]*uint64{
+		"collection_id":       &req.CollectionId,
+		"term_language_id":    &req.TermLanguageId,
+		"meaning_language_id": &req.MeaningLanguageId,
+	} {
+		if *value, err = strconv.ParseUint(c.PostForm(field), 10, 64); err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+	}
+	return req, nil
+}
+
+func (e *GatewayApiHttpEndpoint) ExportCollection(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("ExportCollection").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "ExportCollection").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.ExportCollectionRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.ExportCollection(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	// The headers are sent with the first bytes, the failure after them can only be logged
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": resp.FileName}))
+	c.Header("Content-Type", resp.ContentType)
+	c.Status(http.StatusOK)
+	if err = resp.Write(c.Writer); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToExportCollectionMsg)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+}
+
+func (e *GatewayApiHttpEndpoint) CloneCollection(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("CloneCollection").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "CloneCollection").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.CloneCollectionRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.CloneCollection(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GetCollectionSource(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GetCollectionSource").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GetCollectionSource").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.GetCollectionSourceRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GetCollectionSource(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) SearchCollections(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("SearchCollections").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "SearchCollections").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.SearchCollectionsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.SearchCollections(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) InviteCollaborator(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("InviteCollaborator").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "InviteCollaborator").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.InviteCollaboratorRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.InviteCollaborator(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) RevokeInvite(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("RevokeInvite").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "RevokeInvite").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.RevokeInviteRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.RevokeInvite(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) RemoveCollaborator(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("RemoveCollaborator").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "RemoveCollaborator").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.RemoveCollaboratorRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.RemoveCollaborator(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) AcceptInvite(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("AcceptInvite").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "AcceptInvite").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.AcceptInviteRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.AcceptInvite(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GetCollaborators(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GetCollaborators").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GetCollaborators").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.GetCollaboratorsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GetCollaborators(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GetInvites(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GetInvites").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GetInvites").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.GetInvitesRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GetInvites(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) CreateShareLink(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("CreateShareLink").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "CreateShareLink").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.CreateShareLinkRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.CreateShareLink(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) RevokeShareLink(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("RevokeShareLink").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "RevokeShareLink").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.RevokeShareLinkRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.RevokeShareLink(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GetSharedCollection(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GetSharedCollection").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GetSharedCollection").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.SharedCollectionRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	// the request is not logged: the token opens the collection
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GetSharedCollection(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := protojson.MarshalOptions{
+		UseEnumNumbers:  false,
+		EmitUnpopulated: true,
+		UseProtoNames:   true,
+	}.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GetSharedTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GetSharedTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GetSharedTerms").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.SharedCollectionRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	// the request is not logged: the token opens the collection
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GetSharedTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := protojson.MarshalOptions{
+		UseEnumNumbers:  false,
+		EmitUnpopulated: true,
+		UseProtoNames:   true,
+	}.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) MoveTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("MoveTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "MoveTerms").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.TransferTermsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.MoveTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) CopyTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("CopyTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "CopyTerms").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.TransferTermsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.CopyTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) FindDuplicates(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("FindDuplicates").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "FindDuplicates").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.FindDuplicatesRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.FindDuplicates(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) GetLearningStats(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("GetLearningStats").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "GetLearningStats").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.GetLearningStatsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.GetLearningStats(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) ChangeTermStatuses(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("ChangeTermStatuses").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "ChangeTermStatuses").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.ChangeTermStatusesRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.ChangeTermStatuses(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) ListTrash(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("ListTrash").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "ListTrash").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.ListTrashRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.ListTrash(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) RestoreCollection(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("RestoreCollection").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "RestoreCollection").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.RestoreCollectionRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.RestoreCollection(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) RestoreTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("RestoreTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "RestoreTerms").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.RestoreTermsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.RestoreTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) EmptyTrash(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("EmptyTrash").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "EmptyTrash").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.EmptyTrashRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.EmptyTrash(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) ExtractTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("ExtractTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "ExtractTerms").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.ExtractTermsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Int("text_length", len(req.Text)).Logger() // the text may be long
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.ExtractTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) ExtractSubtitleTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("ExtractSubtitleTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "ExtractSubtitleTerms").Logger()
+
+	req, err := readExtractSubtitleTermsRequest(c)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.ExtractSubtitleTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+func (e *GatewayApiHttpEndpoint) AcceptSubtitleTerms(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start)
+		e.prom.HttpReqDuration.WithLabelValues("AcceptSubtitleTerms").Observe(duration.Seconds())
+	}()
+
+	requestID := utils.AnyToString(c.Value(constants.RequestIdKey))
+	lgr := e.lgr.With().
+		Str(constants.RequestIdKey, requestID).
+		Str("handler", "AcceptSubtitleTerms").Logger()
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToGetRequestBody)
+		if c.Writer.Status() == http.StatusRequestEntityTooLarge {
+			return
+		}
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	defer c.Request.Body.Close()
+
+	req := new(entities.AcceptSubtitleTermsRequest)
+	if err = json.Unmarshal(body, req); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToUnmarshalRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	lgr = lgr.With().Interface("request", req).Logger()
+
+	if err = req.Validate(); err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToValidateRequestBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	resp, err := e.gatewayApiService.AcceptSubtitleTerms(c, req)
+	if err != nil {
+		lgr.Error().Err(err).Msg("failed")
+		code, obj := outer.GetHTTPError(err)
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+
+	lgr.Debug().Msg("executed")
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		lgr.Error().Err(err).Msg(errors.FailedToMarshalResponseBody)
+		code, obj := outer.GetHTTPError(errors.BadRequestError(err))
+		e.prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+		c.PureJSON(code, obj)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", buf)
+}
+
+// readExtractSubtitleTermsRequest reads the multipart form of ExtractSubtitleTerms
+func readExtractSubtitleTermsRequest(c *gin.Context) (*entities.ExtractSubtitleTermsRequest, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entities.ExtractSubtitleTermsRequest{
+		Format:   c.PostForm("format"),
+		Encoding: c.PostForm("encoding"),
+		Source:   c.PostForm("source"),
+		FileName: fileHeader.Filename,
+		File:     data,
+	}
+	if req.CollectionId, err = strconv.ParseUint(c.PostForm("collection_id"), 10, 64); err != nil {
+		return nil, fmt.Errorf("collection_id: %w", err)
+	}
+	// the optional fields are zero when they are not sent
+	if value := c.PostForm("language_id"); value != "" {
+		if req.LanguageId, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("language_id: %w", err)
+		}
+	}
+	if value := c.PostForm("max_terms"); value != "" {
+		if req.MaxTerms, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("max_terms: %w", err)
+		}
+	}
+	return req, nil
+}
@@ -29,6 +29,38 @@ type IGatewayApiHttpEndpoint interface {
 	GetLanguages(c *gin.Context)
 	GetVoiceover(c *gin.Context)
 	GetTranslation(c *gin.Context)
+	GetDueTerms(c *gin.Context)
+	SubmitReview(c *gin.Context)
+	GenerateQuiz(c *gin.Context)
+	ImportTerms(c *gin.Context)
+	ExportCollection(c *gin.Context)
+	CloneCollection(c *gin.Context)
+	GetCollectionSource(c *gin.Context)
+	SearchCollections(c *gin.Context)
+	InviteCollaborator(c *gin.Context)
+	RevokeInvite(c *gin.Context)
+	RemoveCollaborator(c *gin.Context)
+	AcceptInvite(c *gin.Context)
+	GetCollaborators(c *gin.Context)
+	GetInvites(c *gin.Context)
+	CreateShareLink(c *gin.Context)
+	RevokeShareLink(c *gin.Context)
+	GetSharedCollection(c *gin.Context)
+	GetSharedTerms(c *gin.Context)
+	MoveTerms(c *gin.Context)
+	CopyTerms(c *gin.Context)
+	FindDuplicates(c *gin.Context)
+	GetLearningStats(c *gin.Context)
+	ChangeTermStatuses(c *gin.Context)
+	ListTrash(c *gin.Context)
+	RestoreCollection(c *gin.Context)
+	RestoreTerms(c *gin.Context)
+	EmptyTrash(c *gin.Context)
+	ExtractTerms(c *gin.Context)
+	ExtractSubtitleTerms(c *gin.Context)
+	AcceptSubtitleTerms(c *gin.Context)
+	InvalidateCache(c *gin.Context)
+	BackfillCatalog(c *gin.Context)
 }
 
 type GatewayApiHttpEndpoint struct {
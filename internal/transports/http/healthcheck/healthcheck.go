@@ -6,10 +6,9 @@ import (
 	"github.com/etherlabsio/healthcheck/checkers"
 	"github.com/etherlabsio/healthcheck/v2"
 	"github.com/gin-gonic/gin"
-	"github.com/rabbitmq/amqp091-go"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
-	"net"
-	"runtime"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
+	"net/http"
 	"time"
 )
 
@@ -25,144 +24,81 @@ func getPrefix(prefixOptions ...string) string {
 	return prefix
 }
 
-func Register(cfg *config.Config, r *gin.Engine, prefixOptions ...string) {
-	RouteRegister(cfg, &(r.RouterGroup), prefixOptions...)
+// Register adds the probes and the status of the dependencies, statusAuth protects the status:
+// it dials every dependency and shows their addresses, so it is not public
+func Register(cfg *config.Config, deps *dependency_status.Registry, r *gin.Engine, statusAuth gin.HandlerFunc, prefixOptions ...string) {
+	RouteRegister(cfg, deps, &(r.RouterGroup), statusAuth, prefixOptions...)
 }
 
-func RouteRegister(cfg *config.Config, rg *gin.RouterGroup, prefixOptions ...string) {
+func RouteRegister(cfg *config.Config, deps *dependency_status.Registry, rg *gin.RouterGroup, statusAuth gin.HandlerFunc, prefixOptions ...string) {
 	prefixRouter := rg.Group(getPrefix(prefixOptions...))
 	prefixRouter.GET("/_live", gin.WrapF(healthcheck.HandlerFunc(
 		// Checking the application address
 		healthcheck.WithChecker(
 			"tcp", healthcheck.CheckerFunc(
 				func(ctx context.Context) error {
-					return TCPDialCheck(cfg.HTTP.Address, 50*time.Millisecond)
+					return TCPDialCheck(ctx, cfg.HTTP.Address, 50*time.Millisecond)
 				},
 			),
 		),
+	)))
+
+	readinessOptions := []healthcheck.Option{
+		//Total timeout
+		healthcheck.WithTimeout(5 * time.Second),
 
-		// Checking for the number of Goroutine
+		// The gateway is not ready while it is processing more requests than it can handle
 		healthcheck.WithChecker(
-			"goroutine", healthcheck.CheckerFunc(
+			"in_flight_requests", healthcheck.CheckerFunc(
 				func(ctx context.Context) error {
-					count := runtime.NumGoroutine()
-					if count > cfg.HealthCheck.GoroutineThreshold {
-						return fmt.Errorf("too many goroutines (%d > %d)", count, cfg.HealthCheck.GoroutineThreshold)
+					inFlight := deps.InFlight().Snapshot()
+					if inFlight.Saturation*100 >= float64(cfg.HealthCheck.SaturationThreshold) {
+						return fmt.Errorf("too many in-flight requests (%d of %d)", inFlight.Current, inFlight.Capacity)
 					}
 					return nil
 				},
 			),
 		),
-	)))
-
-	prefixRouter.GET("/_ready", gin.WrapF(
-		healthcheck.HandlerFunc(
-			//Total timeout
-			healthcheck.WithTimeout(5*time.Second),
 
-			//Checking the AuthApi connection
-			healthcheck.WithObserver(
-				"auth_api_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						return TCPDialCheck(cfg.AuthApi.URI, 200*time.Millisecond)
-					},
-				),
-			),
-
-			//Checking the AuthApi connection
-			healthcheck.WithObserver(
-				"user_api_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						return TCPDialCheck(cfg.UserApi.URI, 200*time.Millisecond)
-					},
-				),
-			),
-
-			//Checking the AuthApi connection
-			healthcheck.WithObserver(
-				"action_api_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						return TCPDialCheck(cfg.ActionApi.URI, 200*time.Millisecond)
-					},
-				),
-			),
+		//We output an error if the disk is occupied by more than a threshold (Take out in a hundred)
+		healthcheck.WithObserver(
+			"diskspace", checkers.DiskSpace("/", cfg.HealthCheck.DiskspaceThreshold),
+		),
+	}
 
-			//Checking the VocabularyApi connection
-			healthcheck.WithObserver(
-				"vocabulary_api_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						return TCPDialCheck(cfg.VocabularyApi.URI, 200*time.Millisecond)
-					},
-				),
-			),
+	for _, dependency := range deps.Dependencies() {
+		dependency := dependency
 
-			//Checking the SpeakerApi connection
-			healthcheck.WithObserver(
-				"speaker_api_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						return TCPDialCheck(cfg.SpeakerApi.URI, 200*time.Millisecond)
-					},
-				),
-			),
+		// Checking the client connection
+		readinessOptions = append(readinessOptions, healthcheck.WithObserver(
+			dependency.Name()+"_connection", healthcheck.CheckerFunc(dependency.Check),
+		))
 
-			//Checking the LanguageApi connection
-			healthcheck.WithObserver(
-				"language_api_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						return TCPDialCheck(cfg.LanguageApi.URI, 200*time.Millisecond)
-					},
-				),
-			),
-
-			//Checking the LanguageApi connection
-			healthcheck.WithObserver(
-				"translation_api_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						return TCPDialCheck(cfg.TranslationApi.URI, 200*time.Millisecond)
-					},
-				),
+		// Checking that the client connection pool is not exhausted
+		readinessOptions = append(readinessOptions, healthcheck.WithObserver(
+			dependency.Name()+"_pool", healthcheck.CheckerFunc(
+				func(ctx context.Context) error {
+					pool := dependency.Snapshot().Pool
+					if pool != nil && pool.Capacity > 0 && pool.Available == 0 {
+						return fmt.Errorf("all %d pool connections are in use", pool.Capacity)
+					}
+					return nil
+				},
 			),
+		))
+	}
 
-			//-------------------
-			// Checking the Rabbit connection
-			//-------------------
-			healthcheck.WithObserver(
-				"rabbit_connection", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						connection, err := amqp091.ParseURI(cfg.Rabbit.URI)
-						if err != nil {
-							return err
-						}
-						return TCPDialCheck(fmt.Sprintf("%s:%d", connection.Host, connection.Port), 50*time.Millisecond)
-					},
-				),
-			),
+	prefixRouter.GET("/_ready", gin.WrapF(healthcheck.HandlerFunc(readinessOptions...)))
 
-			//Number Goroutine
-			healthcheck.WithObserver(
-				"goroutine", healthcheck.CheckerFunc(
-					func(ctx context.Context) error {
-						count := runtime.NumGoroutine()
-						if count > cfg.HealthCheck.GoroutineReadiness {
-							return fmt.Errorf("too many goroutines (%d > %d)", count, cfg.HealthCheck.GoroutineReadiness)
-						}
-						return nil
-					},
-				),
-			),
+	// Detailed state of the gateway and its downstream clients for on-call engineers
+	prefixRouter.GET("/status", statusAuth, func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c, cfg.HealthCheck.StatusTimeout)
+		defer cancel()
 
-			//We output an error if the disk is occupied by more than a threshold (Take out in a hundred)
-			healthcheck.WithObserver(
-				"diskspace", checkers.DiskSpace("/", cfg.HealthCheck.DiskspaceThreshold),
-			),
-		),
-	))
+		c.JSON(http.StatusOK, deps.Status(ctx))
+	})
 }
 
-func TCPDialCheck(addr string, timeout time.Duration) error {
-	conn, err := net.DialTimeout("tcp", addr, timeout)
-	if err != nil {
-		return err
-	}
-	return conn.Close()
+func TCPDialCheck(ctx context.Context, addr string, timeout time.Duration) error {
+	return dependency_status.TCPDialCheck(ctx, addr, timeout)
 }
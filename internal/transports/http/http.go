@@ -8,9 +8,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/pkg/dependency_status"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/healthcheck"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/metrics"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/middleware/admin"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/middleware/cors"
 	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/transports/http/middleware/recovery"
 	"net"
@@ -41,6 +43,7 @@ func NewServer(
 	cfg *config.Config,
 	lgr zerolog.Logger,
 	prom *prometheus.Exporter,
+	deps *dependency_status.Registry,
 	listener net.Listener,
 	ep Endpointer,
 ) (*Server, error) {
@@ -63,8 +66,10 @@ func NewServer(
 	if cfg.Metrics.Enabled {
 		metrics.Register(router, "/metrics")
 	}
-	healthcheck.Register(cfg, router, "/")
+	healthcheck.Register(cfg, deps, router, admin.Admin(cfg, lgr, prom), "/")
 
+	// Registered after the health and metrics routes, so only API requests are counted as in-flight
+	router.Use(dependency_status.HttpMiddleware(deps.InFlight()))
 	ep.RegisterServer(router, "/")
 
 	httpSrv := &Server{
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/config"
+	_errors "gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/errors"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/prometheus"
+	"gitlab.com/wordbyword.io/microservices/pkg/errors/outer"
+	"strconv"
+	"strings"
+)
+
+type adminHeader struct {
+	Token string `header:"Authorization"`
+}
+
+// Admin allows the request only with the static admin token, all the requests are rejected while the token is not set
+func Admin(cfg *config.Config, lgr zerolog.Logger, prom *prometheus.Exporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := new(adminHeader)
+		_ = c.ShouldBindHeader(h)
+
+		token, found := strings.CutPrefix(h.Token, "Bearer ")
+		if cfg.Admin.Token == "" || !found ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Admin.Token)) != 1 {
+			outerErr := _errors.BadAuthorizationTokenError(_errors.AdminTokenIsInvalid)
+			code, obj := outer.GetHTTPError(outerErr)
+			lgr.Warn().Str("path", c.Request.URL.Path).Msg(outerErr.ErrorMessage)
+			prom.HttpRespCount.WithLabelValues(strconv.FormatInt(int64(code), 10)).Add(1)
+			c.PureJSON(code, obj)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"github.com/gin-gonic/gin"
+	"gitlab.com/wordbyword.io/microservices/gateways/gateway-api/internal/constants"
+)
+
+// ExtractTraceContext keeps the W3C trace context of the request, so it can be passed to the published messages
+func ExtractTraceContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if traceParent := c.GetHeader(constants.TraceParentKey); traceParent != "" {
+			c.Set(constants.TraceParentKey, traceParent)
+		}
+		if traceState := c.GetHeader(constants.TraceStateKey); traceState != "" {
+			c.Set(constants.TraceStateKey, traceState)
+		}
+
+		c.Next()
+	}
+}